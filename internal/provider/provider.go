@@ -604,6 +604,8 @@ func New(ctx context.Context) (*schema.Provider, error) {
 			"aws_vpc_ipam_pools":                             ec2.DataSourceIPAMPools(),
 			"aws_vpc_ipam_pool_cidrs":                        ec2.DataSourceIPAMPoolCIDRs(),
 			"aws_vpc_ipam_preview_next_cidr":                 ec2.DataSourceIPAMPreviewNextCIDR(),
+			"aws_vpc_ipam_resource_discovery":                ec2.DataSourceIPAMResourceDiscovery(),
+			"aws_vpc_ipam_scope":                             ec2.DataSourceIPAMScope(),
 			"aws_vpc_peering_connection":                     ec2.DataSourceVPCPeeringConnection(),
 			"aws_vpc_peering_connections":                    ec2.DataSourceVPCPeeringConnections(),
 			"aws_vpc":                                        ec2.DataSourceVPC(),
@@ -829,6 +831,7 @@ func New(ctx context.Context) (*schema.Provider, error) {
 			"aws_db_event_categories":            rds.DataSourceEventCategories(),
 			"aws_db_instance":                    rds.DataSourceInstance(),
 			"aws_db_instances":                   rds.DataSourceInstances(),
+			"aws_db_parameter_group_diff":        rds.DataSourceParameterGroupDiff(),
 			"aws_db_proxy":                       rds.DataSourceProxy(),
 			"aws_db_snapshot":                    rds.DataSourceSnapshot(),
 			"aws_db_subnet_group":                rds.DataSourceSubnetGroup(),
@@ -1427,6 +1430,7 @@ func New(ctx context.Context) (*schema.Provider, error) {
 			"aws_vpc_ipam_pool":                                    ec2.ResourceIPAMPool(),
 			"aws_vpc_ipam_pool_cidr_allocation":                    ec2.ResourceIPAMPoolCIDRAllocation(),
 			"aws_vpc_ipam_pool_cidr":                               ec2.ResourceIPAMPoolCIDR(),
+			"aws_vpc_ipam_pool_cidrs":                              ec2.ResourceIPAMPoolCIDRs(),
 			"aws_vpc_ipam_preview_next_cidr":                       ec2.ResourceIPAMPreviewNextCIDR(),
 			"aws_vpc_ipam_scope":                                   ec2.ResourceIPAMScope(),
 			"aws_vpc_ipv4_cidr_block_association":                  ec2.ResourceVPCIPv4CIDRBlockAssociation(),
@@ -1895,7 +1899,9 @@ func New(ctx context.Context) (*schema.Provider, error) {
 			"aws_db_instance_automated_backups_replication": rds.ResourceInstanceAutomatedBackupsReplication(),
 			"aws_db_instance_role_association":              rds.ResourceInstanceRoleAssociation(),
 			"aws_db_option_group":                           rds.ResourceOptionGroup(),
+			"aws_db_parameter":                              rds.ResourceParameter(),
 			"aws_db_parameter_group":                        rds.ResourceParameterGroup(),
+			"aws_db_parameter_group_copy":                   rds.ResourceParameterGroupCopy(),
 			"aws_db_proxy":                                  rds.ResourceProxy(),
 			"aws_db_proxy_default_target_group":             rds.ResourceProxyDefaultTargetGroup(),
 			"aws_db_proxy_endpoint":                         rds.ResourceProxyEndpoint(),
@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/service/ec2"
 )
 
 const (
@@ -38,3 +39,30 @@ func InstanceProfileARNToName(inputARN string) (string, error) {
 
 	return resourceParts[len(resourceParts)-1], nil
 }
+
+// IPAMResourceARNToID converts the Amazon Resource Name (ARN) of an IPAM, IPAM Scope or IPAM Pool to its ID.
+func IPAMResourceARNToID(inputARN string) (string, error) {
+	parsedARN, err := arn.Parse(inputARN)
+
+	if err != nil {
+		return "", fmt.Errorf("error parsing ARN (%s): %w", inputARN, err)
+	}
+
+	if actual, expected := parsedARN.Service, ec2.ServiceName; actual != expected {
+		return "", fmt.Errorf("expected service %s in ARN (%s), got: %s", expected, inputARN, actual)
+	}
+
+	resourceParts := strings.Split(parsedARN.Resource, ARNSeparator)
+
+	if actual, expected := len(resourceParts), 2; actual != expected || resourceParts[1] == "" {
+		return "", fmt.Errorf("expected %d resource parts in ARN (%s), got: %d", expected, inputARN, actual)
+	}
+
+	switch prefix := resourceParts[0]; prefix {
+	case ec2.ResourceTypeIpam, ec2.ResourceTypeIpamScope, ec2.ResourceTypeIpamPool:
+	default:
+		return "", fmt.Errorf("expected IPAM resource prefix in ARN (%s), got: %s", inputARN, prefix)
+	}
+
+	return resourceParts[1], nil
+}
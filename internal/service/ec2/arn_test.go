@@ -78,3 +78,95 @@ func TestInstanceProfileARNToName(t *testing.T) {
 		})
 	}
 }
+
+func TestIPAMResourceARNToID(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		TestName      string
+		InputARN      string
+		ExpectedError *regexp.Regexp
+		ExpectedID    string
+	}{
+		{
+			TestName:      "empty ARN",
+			InputARN:      "",
+			ExpectedError: regexp.MustCompile(`error parsing ARN`),
+		},
+		{
+			TestName:      "unparsable ARN",
+			InputARN:      "test",
+			ExpectedError: regexp.MustCompile(`error parsing ARN`),
+		},
+		{
+			TestName:      "invalid ARN service",
+			InputARN:      "arn:aws:iam::123456789012:ipam-scope/ipam-scope-12345678", //lintignore:AWSAT005
+			ExpectedError: regexp.MustCompile(`expected service ec2`),
+		},
+		{
+			TestName:      "invalid ARN resource parts",
+			InputARN:      "arn:aws:ec2::123456789012:ipam-scope", //lintignore:AWSAT005
+			ExpectedError: regexp.MustCompile(`expected 2 resource parts`),
+		},
+		{
+			TestName:      "empty ARN resource ID",
+			InputARN:      "arn:aws:ec2::123456789012:ipam-scope/", //lintignore:AWSAT005
+			ExpectedError: regexp.MustCompile(`expected 2 resource parts`),
+		},
+		{
+			TestName:      "invalid ARN resource prefix",
+			InputARN:      "arn:aws:ec2:us-east-1:123456789012:instance/i-12345678", //lintignore:AWSAT003,AWSAT005
+			ExpectedError: regexp.MustCompile(`expected IPAM resource prefix`),
+		},
+		{
+			TestName:   "valid IPAM ARN",
+			InputARN:   "arn:aws:ec2::123456789012:ipam/ipam-12345678", //lintignore:AWSAT005
+			ExpectedID: "ipam-12345678",
+		},
+		{
+			TestName:   "valid IPAM Scope ARN",
+			InputARN:   "arn:aws:ec2::123456789012:ipam-scope/ipam-scope-12345678", //lintignore:AWSAT005
+			ExpectedID: "ipam-scope-12345678",
+		},
+		{
+			TestName:   "valid IPAM Pool ARN",
+			InputARN:   "arn:aws:ec2::123456789012:ipam-pool/ipam-pool-12345678", //lintignore:AWSAT005
+			ExpectedID: "ipam-pool-12345678",
+		},
+		{
+			TestName:   "valid GovCloud ARN",
+			InputARN:   "arn:aws-us-gov:ec2::123456789012:ipam-scope/ipam-scope-12345678", //lintignore:AWSAT005
+			ExpectedID: "ipam-scope-12345678",
+		},
+		{
+			TestName:   "valid China ARN",
+			InputARN:   "arn:aws-cn:ec2::123456789012:ipam-scope/ipam-scope-12345678", //lintignore:AWSAT005
+			ExpectedID: "ipam-scope-12345678",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.TestName, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := tfec2.IPAMResourceARNToID(testCase.InputARN)
+
+			if err == nil && testCase.ExpectedError != nil {
+				t.Fatalf("expected error %s, got no error", testCase.ExpectedError.String())
+			}
+
+			if err != nil && testCase.ExpectedError == nil {
+				t.Fatalf("got unexpected error: %s", err)
+			}
+
+			if err != nil && !testCase.ExpectedError.MatchString(err.Error()) {
+				t.Fatalf("expected error %s, got: %s", testCase.ExpectedError.String(), err)
+			}
+
+			if got != testCase.ExpectedID {
+				t.Errorf("got %s, expected %s", got, testCase.ExpectedID)
+			}
+		})
+	}
+}
@@ -45,6 +45,7 @@ const (
 	errCodeInvalidIPAMIdNotFound                          = "InvalidIpamId.NotFound"
 	errCodeInvalidIPAMPoolAllocationIdNotFound            = "InvalidIpamPoolAllocationId.NotFound"
 	errCodeInvalidIPAMPoolIdNotFound                      = "InvalidIpamPoolId.NotFound"
+	errCodeInvalidIPAMResourceDiscoveryIdNotFound         = "InvalidIpamResourceDiscoveryId.NotFound"
 	errCodeInvalidIPAMScopeIdNotFound                     = "InvalidIpamScopeId.NotFound"
 	errCodeInvalidKeyPairNotFound                         = "InvalidKeyPair.NotFound"
 	errCodeInvalidLaunchTemplateIdMalformed               = "InvalidLaunchTemplateId.Malformed"
@@ -101,8 +102,10 @@ const (
 	errCodeInvalidVPNGatewayIDNotFound                    = "InvalidVpnGatewayID.NotFound"
 	errCodeNatGatewayNotFound                             = "NatGatewayNotFound"
 	errCodePrefixListVersionMismatch                      = "PrefixListVersionMismatch"
+	errCodeRequestLimitExceeded                           = "RequestLimitExceeded"
 	errCodeResourceNotReady                               = "ResourceNotReady"
 	errCodeSnapshotCreationPerVolumeRateExceeded          = "SnapshotCreationPerVolumeRateExceeded"
+	errCodeUnauthorizedOperation                          = "UnauthorizedOperation"
 	errCodeUnsupportedOperation                           = "UnsupportedOperation"
 	errCodeVolumeInUse                                    = "VolumeInUse"
 )
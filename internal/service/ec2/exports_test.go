@@ -2,6 +2,45 @@ package ec2
 
 // Exports for use in tests only.
 var (
-	ResourceSecurityGroupEgressRule  = newResourceSecurityGroupEgressRule
-	ResourceSecurityGroupIngressRule = newResourceSecurityGroupIngressRule
+	CreateIPAM                              = createIPAM
+	CreateIPAMPool                          = createIPAMPool
+	CreateIPAMPoolWithTagsFallback          = createIPAMPoolWithTagsFallback
+	CreateIPAMScope                         = createIPAMScope
+	CreateIPAMScopeWithTagsFallback         = createIPAMScopeWithTagsFallback
+	DeprovisionIPAMPoolCIDR                 = deprovisionIPAMPoolCIDR
+	DeprovisionIPAMPoolCIDRs                = deprovisionIPAMPoolCIDRs
+	ExpandIPAMPreviewNextCIDRInput          = expandIPAMPreviewNextCIDRInput
+	ExpandModifyIPAMPoolInput               = expandModifyIPAMPoolInput
+	ExpandProvisionIPAMPoolCIDRInput        = expandProvisionIPAMPoolCIDRInput
+	ExpandIPAMPoolAllocationResourceTags    = expandIPAMPoolAllocationResourceTags
+	FindIPAMPoolCIDRAllocations             = findIPAMPoolCIDRAllocations
+	FindIPAMPoolManagedCIDRBlocks           = findIPAMPoolManagedCIDRBlocks
+	FindIPAMPoolProvisionedCIDRBlocks       = findIPAMPoolProvisionedCIDRBlocks
+	FlattenIPAMPool                         = flattenIPAMPool
+	FlattenIPAMPoolAllocationResourceTags   = flattenIPAMPoolAllocationResourceTags
+	IPAMOperatingRegionsUpdate              = ipamOperatingRegionsUpdate
+	IPAMPoolAllocationCount                 = ipamPoolAllocationCount
+	IPAMPoolCIDRAllocationImportID          = ipamPoolCIDRAllocationImportID
+	IPAMPoolCIDRBlockAvailable              = ipamPoolCIDRBlockAvailable
+	IPAMPoolCIDRBlockCount                  = ipamPoolCIDRBlockCount
+	IPAMPoolCIDRsUpdate                     = ipamPoolCIDRsUpdate
+	IPAMResourceAlreadyDeleting             = ipamResourceAlreadyDeleting
+	IPAMResourceTags                        = ipamResourceTags
+	IPAMScopeImportID                       = ipamScopeImportID
+	IPAMTagSpecifications                   = ipamTagSpecifications
+	ModifyIPAMPool                          = modifyIPAMPool
+	ModifyIPAMPoolCIDRs                     = modifyIPAMPoolCIDRs
+	ProvisionIPAMPoolCIDR                   = provisionIPAMPoolCIDR
+	ResourceSecurityGroupEgressRule         = newResourceSecurityGroupEgressRule
+	ResourceSecurityGroupIngressRule        = newResourceSecurityGroupIngressRule
+	SetIPAMDefaultScopeIDs                  = setIPAMDefaultScopeIDs
+	SetIPAMPoolAllocationNetmaskLengths     = setIPAMPoolAllocationNetmaskLengths
+	TagsFromIPAMAllocationTags              = tagsFromIPAMAllocationTags
+	ValidateIPAMOperatingRegionsHomeRegion  = validateIPAMOperatingRegionsHomeRegion
+	ValidateIPAMOperatingRegionsRemoval     = validateIPAMOperatingRegionsRemoval
+	ValidateIPAMPoolAllocationNetmaskLength = validateIPAMPoolAllocationNetmaskLength
+	ValidateIPAMPoolAllocationRelease       = validateIPAMPoolAllocationRelease
+	ValidateIPAMPoolCIDRAmazonProvided      = validateIPAMPoolCIDRAmazonProvided
+	ValidateIPAMPoolLocale                  = validateIPAMPoolLocale
+	ValidateIPAMPoolPubliclyAdvertisable    = validateIPAMPoolPubliclyAdvertisable
 )
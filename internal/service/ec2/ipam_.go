@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -18,6 +19,7 @@ import (
 	tftags "github.com/hashicorp/terraform-provider-aws/internal/tags"
 	"github.com/hashicorp/terraform-provider-aws/internal/tfresource"
 	"github.com/hashicorp/terraform-provider-aws/internal/verify"
+	"golang.org/x/exp/slices"
 )
 
 func ResourceIPAM() *schema.Resource {
@@ -28,7 +30,7 @@ func ResourceIPAM() *schema.Resource {
 		DeleteWithoutTimeout: resourceIPAMDelete,
 
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: resourceIPAMImport,
 		},
 
 		Timeouts: &schema.ResourceTimeout{
@@ -46,6 +48,19 @@ func ResourceIPAM() *schema.Resource {
 				Type:     schema.TypeBool,
 				Optional: true,
 			},
+			"deletion_protection": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"default_resource_discovery_association_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"default_resource_discovery_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 			"description": {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -53,6 +68,7 @@ func ResourceIPAM() *schema.Resource {
 			"operating_regions": {
 				Type:     schema.TypeSet,
 				Required: true,
+				MinItems: 1,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"region_name": {
@@ -75,6 +91,10 @@ func ResourceIPAM() *schema.Resource {
 				Type:     schema.TypeInt,
 				Computed: true,
 			},
+			"state": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 			"tags":     tftags.TagsSchema(),
 			"tags_all": tftags.TagsSchemaComputed(),
 		},
@@ -82,16 +102,26 @@ func ResourceIPAM() *schema.Resource {
 		CustomizeDiff: customdiff.Sequence(
 			verify.SetTagsDiff,
 			func(_ context.Context, diff *schema.ResourceDiff, meta interface{}) error {
-				if diff.Id() == "" { // Create.
-					currentRegion := meta.(*conns.AWSClient).Region
+				// The home region can't be removed from an existing IPAM, so dropping it is reported at plan rather than at apply.
+				if diff.Id() == "" || diff.HasChange("operating_regions") {
+					return validateIPAMOperatingRegionsHomeRegion(diff.Get("operating_regions").(*schema.Set).List(), meta.(*conns.AWSClient).Region)
+				}
+
+				return nil
+			},
+			func(_ context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+				partition := meta.(*conns.AWSClient).Partition
 
-					for _, v := range diff.Get("operating_regions").(*schema.Set).List() {
-						if v.(map[string]interface{})["region_name"].(string) == currentRegion {
-							return nil
-						}
+				for _, v := range diff.Get("operating_regions").(*schema.Set).List() {
+					regionName := v.(map[string]interface{})["region_name"].(string)
+
+					if regionName == "" {
+						continue
 					}
 
-					return fmt.Errorf("`operating_regions` must include %s", currentRegion)
+					if err := verify.ValidRegionNameInPartition(regionName, partition); err != nil {
+						return fmt.Errorf("`operating_regions`: %w", err)
+					}
 				}
 
 				return nil
@@ -103,20 +133,18 @@ func ResourceIPAM() *schema.Resource {
 func resourceIPAMCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 	conn := meta.(*conns.AWSClient).EC2Conn()
-	defaultTagsConfig := meta.(*conns.AWSClient).DefaultTagsConfig
-	tags := defaultTagsConfig.MergeTags(tftags.New(d.Get("tags").(map[string]interface{})))
 
 	input := &ec2.CreateIpamInput{
 		ClientToken:       aws.String(resource.UniqueId()),
 		OperatingRegions:  expandIPAMOperatingRegions(d.Get("operating_regions").(*schema.Set).List()),
-		TagSpecifications: tagSpecificationsFromKeyValueTags(tags, ec2.ResourceTypeIpam),
+		TagSpecifications: ipamTagSpecifications(d, meta.(*conns.AWSClient).DefaultTagsConfig, ec2.ResourceTypeIpam),
 	}
 
 	if v, ok := d.GetOk("description"); ok {
 		input.Description = aws.String(v.(string))
 	}
 
-	output, err := conn.CreateIpamWithContext(ctx, input)
+	output, err := createIPAM(ctx, conn, input, d.Timeout(schema.TimeoutCreate))
 
 	if err != nil {
 		return sdkdiag.AppendErrorf(diags, "creating IPAM: %s", err)
@@ -124,6 +152,9 @@ func resourceIPAMCreate(ctx context.Context, d *schema.ResourceData, meta interf
 
 	d.SetId(aws.StringValue(output.Ipam.IpamId))
 
+	// The default scopes are created with the IPAM, so their IDs are known before the IPAM is visible to reads.
+	setIPAMDefaultScopeIDs(d, output.Ipam)
+
 	if _, err := WaitIPAMCreated(ctx, conn, d.Id(), d.Timeout(schema.TimeoutCreate)); err != nil {
 		return sdkdiag.AppendErrorf(diags, "waiting for IPAM (%s) created: %s", d.Id(), err)
 	}
@@ -131,13 +162,48 @@ func resourceIPAMCreate(ctx context.Context, d *schema.ResourceData, meta interf
 	return append(diags, resourceIPAMRead(ctx, d, meta)...)
 }
 
+// createIPAM creates an IPAM, retrying while the request is throttled.
+// Every attempt sends the input's client token, so that an attempt that AWS processed despite failing can't create a duplicate IPAM.
+func createIPAM(ctx context.Context, conn *ec2.EC2, input *ec2.CreateIpamInput, timeout time.Duration) (*ec2.CreateIpamOutput, error) {
+	outputRaw, err := tfresource.RetryWhenAWSErrCodeEquals(ctx, timeout, func() (interface{}, error) {
+		return conn.CreateIpamWithContext(ctx, input)
+	}, errCodeRequestLimitExceeded)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return outputRaw.(*ec2.CreateIpamOutput), nil
+}
+
+// setIPAMDefaultScopeIDs sets the IDs of the IPAM's default private and public scopes.
+func setIPAMDefaultScopeIDs(d *schema.ResourceData, ipam *ec2.Ipam) {
+	d.Set("private_default_scope_id", ipam.PrivateDefaultScopeId)
+	d.Set("public_default_scope_id", ipam.PublicDefaultScopeId)
+}
+
 func resourceIPAMRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 	conn := meta.(*conns.AWSClient).EC2Conn()
 	defaultTagsConfig := meta.(*conns.AWSClient).DefaultTagsConfig
 	ignoreTagsConfig := meta.(*conns.AWSClient).IgnoreTagsConfig
 
-	ipam, err := FindIPAMByID(ctx, conn, d.Id())
+	outputRaw, err := tfresource.RetryWhenNewResourceNotFound(ctx, propagationTimeout, func() (interface{}, error) {
+		ipam, err := FindIPAMByID(ctx, conn, d.Id())
+
+		if err != nil {
+			return nil, err
+		}
+
+		// The default scopes of a new IPAM may not be visible yet.
+		if d.IsNewResource() && (aws.Int64Value(ipam.ScopeCount) < 2 || ipam.PrivateDefaultScopeId == nil || ipam.PublicDefaultScopeId == nil) {
+			return nil, &resource.NotFoundError{
+				Message: "IPAM default scopes not yet visible",
+			}
+		}
+
+		return ipam, nil
+	}, d.IsNewResource())
 
 	if !d.IsNewResource() && tfresource.NotFound(err) {
 		log.Printf("[WARN] IPAM (%s) not found, removing from state", d.Id())
@@ -149,12 +215,16 @@ func resourceIPAMRead(ctx context.Context, d *schema.ResourceData, meta interfac
 		return sdkdiag.AppendErrorf(diags, "reading IPAM (%s): %s", d.Id(), err)
 	}
 
+	ipam := outputRaw.(*ec2.Ipam)
+
 	d.Set("arn", ipam.IpamArn)
+	d.Set("default_resource_discovery_association_id", ipam.DefaultResourceDiscoveryAssociationId)
+	d.Set("default_resource_discovery_id", ipam.DefaultResourceDiscoveryId)
 	d.Set("description", ipam.Description)
 	d.Set("operating_regions", flattenIPAMOperatingRegions(ipam.OperatingRegions))
-	d.Set("public_default_scope_id", ipam.PublicDefaultScopeId)
-	d.Set("private_default_scope_id", ipam.PrivateDefaultScopeId)
+	setIPAMDefaultScopeIDs(d, ipam)
 	d.Set("scope_count", ipam.ScopeCount)
+	d.Set("state", ipam.State)
 
 	tags := KeyValueTags(ipam.Tags).IgnoreAWS().IgnoreConfig(ignoreTagsConfig)
 
@@ -192,28 +262,37 @@ func resourceIPAMUpdate(ctx context.Context, d *schema.ResourceData, meta interf
 				n = new(schema.Set)
 			}
 
-			os := o.(*schema.Set)
-			ns := n.(*schema.Set)
-			operatingRegionUpdateAdd := expandIPAMOperatingRegionsUpdateAddRegions(ns.Difference(os).List())
-			operatingRegionUpdateRemove := expandIPAMOperatingRegionsUpdateDeleteRegions(os.Difference(ns).List())
+			operatingRegionUpdateAdd, operatingRegionUpdateRemove := ipamOperatingRegionsUpdate(o.(*schema.Set).List(), n.(*schema.Set).List(), meta.(*conns.AWSClient).Region)
 
 			if len(operatingRegionUpdateAdd) != 0 {
 				input.AddOperatingRegions = operatingRegionUpdateAdd
 			}
 
 			if len(operatingRegionUpdateRemove) != 0 {
+				var regions []string
+				for _, v := range operatingRegionUpdateRemove {
+					regions = append(regions, aws.StringValue(v.RegionName))
+				}
+
+				if err := validateIPAMOperatingRegionsRemoval(ctx, conn, d.Get("arn").(string), regions); err != nil {
+					return sdkdiag.AppendErrorf(diags, "updating IPAM (%s): %s", d.Id(), err)
+				}
+
 				input.RemoveOperatingRegions = operatingRegionUpdateRemove
 			}
 		}
 
-		_, err := conn.ModifyIpamWithContext(ctx, input)
+		// An equivalent set of operating regions, e.g. reordered or with a region repeated, leaves nothing to modify.
+		if input.Description != nil || input.AddOperatingRegions != nil || input.RemoveOperatingRegions != nil {
+			_, err := conn.ModifyIpamWithContext(ctx, input)
 
-		if err != nil {
-			return sdkdiag.AppendErrorf(diags, "updating IPAM (%s): %s", d.Id(), err)
-		}
+			if err != nil {
+				return sdkdiag.AppendErrorf(diags, "updating IPAM (%s): %s", d.Id(), err)
+			}
 
-		if _, err := WaitIPAMUpdated(ctx, conn, d.Id(), d.Timeout(schema.TimeoutUpdate)); err != nil {
-			return sdkdiag.AppendErrorf(diags, "waiting for IPAM (%s) update: %s", d.Id(), err)
+			if _, err := WaitIPAMUpdated(ctx, conn, d.Id(), d.Timeout(schema.TimeoutUpdate)); err != nil {
+				return sdkdiag.AppendErrorf(diags, "waiting for IPAM (%s) update: %s", d.Id(), err)
+			}
 		}
 	}
 
@@ -230,6 +309,12 @@ func resourceIPAMUpdate(ctx context.Context, d *schema.ResourceData, meta interf
 
 func resourceIPAMDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
+
+	// AWS has no deletion protection for IPAMs, so it's enforced by the provider.
+	if d.Get("deletion_protection").(bool) {
+		return sdkdiag.AppendErrorf(diags, "deleting IPAM (%s): deletion_protection is enabled; set it to false and apply before destroying the IPAM", d.Id())
+	}
+
 	conn := meta.(*conns.AWSClient).EC2Conn()
 
 	input := &ec2.DeleteIpamInput{
@@ -247,7 +332,7 @@ func resourceIPAMDelete(ctx context.Context, d *schema.ResourceData, meta interf
 		return diags
 	}
 
-	if err != nil {
+	if err != nil && !ipamResourceAlreadyDeleting(err, StatusIPAMState(ctx, conn, d.Id()), ec2.IpamStateDeleteInProgress) {
 		return sdkdiag.AppendErrorf(diags, "deleting IPAM: (%s): %s", d.Id(), err)
 	}
 
@@ -258,6 +343,43 @@ func resourceIPAMDelete(ctx context.Context, d *schema.ResourceData, meta interf
 	return diags
 }
 
+func resourceIPAMImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	d.Set("deletion_protection", false)
+	return []*schema.ResourceData{d}, nil
+}
+
+// ipamResourceAlreadyDeleting returns whether a delete error reports that an IPAM resource is in an incorrect state
+// because it is already being deleted, e.g. by a concurrent process, in which case the delete waiter can proceed.
+// The resource's current state is read with the specified refresh function.
+func ipamResourceAlreadyDeleting(err error, refresh resource.StateRefreshFunc, deleteInProgressState string) bool {
+	if !tfawserr.ErrCodeEquals(err, errCodeIncorrectState) {
+		return false
+	}
+
+	output, state, err := refresh()
+
+	if err != nil {
+		return false
+	}
+
+	return output == nil || state == deleteInProgressState
+}
+
+// ipamTagSpecifications returns the tag specifications for an IPAM, IPAM scope or IPAM pool of the given resource type,
+// merging the provider default tags with the resource's tags.
+func ipamTagSpecifications(d *schema.ResourceData, defaultTagsConfig *tftags.DefaultConfig, resourceType string) []*ec2.TagSpecification {
+	tags := defaultTagsConfig.MergeTags(tftags.New(d.Get("tags").(map[string]interface{})))
+
+	return tagSpecificationsFromKeyValueTags(tags, resourceType)
+}
+
+// ipamTagOnCreateUnauthorized returns whether a create that requested tags failed because the caller isn't authorized.
+// EC2 may encode the authorization failure message, so any UnauthorizedOperation error of a tagged create is taken
+// to be caused by the tags; a create retried without them fails again if it isn't.
+func ipamTagOnCreateUnauthorized(err error, tagSpecifications []*ec2.TagSpecification) bool {
+	return len(tagSpecifications) > 0 && tfawserr.ErrCodeEquals(err, errCodeUnauthorizedOperation)
+}
+
 func expandIPAMOperatingRegions(operatingRegions []interface{}) []*ec2.AddIpamOperatingRegion {
 	regions := make([]*ec2.AddIpamOperatingRegion, 0, len(operatingRegions))
 	for _, regionRaw := range operatingRegions {
@@ -289,34 +411,100 @@ func flattenIPAMOperatingRegion(operatingRegion *ec2.IpamOperatingRegion) map[st
 	return region
 }
 
-func expandIPAMOperatingRegionsUpdateAddRegions(operatingRegions []interface{}) []*ec2.AddIpamOperatingRegion {
-	regionUpdates := make([]*ec2.AddIpamOperatingRegion, 0, len(operatingRegions))
-	for _, regionRaw := range operatingRegions {
-		region := regionRaw.(map[string]interface{})
-		regionUpdates = append(regionUpdates, expandIPAMOperatingRegionsUpdateAddRegion(region))
+// validateIPAMOperatingRegionsRemoval returns an error listing the IPAM's pools that are localized to any of the
+// operating regions being removed, as AWS doesn't remove an operating region that still has pools.
+func validateIPAMOperatingRegionsRemoval(ctx context.Context, conn *ec2.EC2, ipamARN string, regions []string) error {
+	input := &ec2.DescribeIpamPoolsInput{
+		Filters: []*ec2.Filter{{
+			Name:   aws.String("locale"),
+			Values: aws.StringSlice(regions),
+		}},
 	}
-	return regionUpdates
+
+	pools, err := FindIPAMPools(ctx, conn, input)
+
+	if err != nil {
+		return fmt.Errorf("reading IPAM Pools: %w", err)
+	}
+
+	var blocking []string
+	for _, v := range pools {
+		if aws.StringValue(v.IpamArn) != ipamARN {
+			continue
+		}
+
+		blocking = append(blocking, fmt.Sprintf("%s (%s)", aws.StringValue(v.IpamPoolId), aws.StringValue(v.Locale)))
+	}
+
+	if len(blocking) > 0 {
+		return fmt.Errorf("operating regions (%s) can't be removed while IPAM Pools are localized to them: %s", strings.Join(regions, ", "), strings.Join(blocking, ", "))
+	}
+
+	return nil
 }
 
-func expandIPAMOperatingRegionsUpdateAddRegion(operatingRegion map[string]interface{}) *ec2.AddIpamOperatingRegion {
-	regionUpdate := &ec2.AddIpamOperatingRegion{
-		RegionName: aws.String(operatingRegion["region_name"].(string)),
+// validateIPAMOperatingRegionsHomeRegion returns an error if the operating regions don't include the IPAM's home region,
+// i.e. the provider's Region.
+func validateIPAMOperatingRegionsHomeRegion(tfList []interface{}, homeRegion string) error {
+	for _, tfMapRaw := range tfList {
+		tfMap, ok := tfMapRaw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		if strings.EqualFold(strings.TrimSpace(tfMap["region_name"].(string)), homeRegion) {
+			return nil
+		}
 	}
-	return regionUpdate
+
+	return fmt.Errorf("`operating_regions` must include %s", homeRegion)
 }
 
-func expandIPAMOperatingRegionsUpdateDeleteRegions(operatingRegions []interface{}) []*ec2.RemoveIpamOperatingRegion {
-	regionUpdates := make([]*ec2.RemoveIpamOperatingRegion, 0, len(operatingRegions))
-	for _, regionRaw := range operatingRegions {
-		region := regionRaw.(map[string]interface{})
-		regionUpdates = append(regionUpdates, expandIPAMOperatingRegionsUpdateDeleteRegion(region))
+// ipamOperatingRegionsUpdate returns the operating regions to add to and remove from an IPAM for a change from the old
+// to the new operating regions. The regions are compared by normalized name, without duplicates and always including
+// the IPAM's home region, which can't be removed, so that equivalent sets of regions result in no update.
+func ipamOperatingRegionsUpdate(o, n []interface{}, homeRegion string) ([]*ec2.AddIpamOperatingRegion, []*ec2.RemoveIpamOperatingRegion) {
+	oldRegions := normalizeIPAMOperatingRegions(o, homeRegion)
+	newRegions := normalizeIPAMOperatingRegions(n, homeRegion)
+
+	var add []*ec2.AddIpamOperatingRegion
+	for _, v := range newRegions {
+		if !slices.Contains(oldRegions, v) {
+			add = append(add, &ec2.AddIpamOperatingRegion{
+				RegionName: aws.String(v),
+			})
+		}
 	}
-	return regionUpdates
+
+	var remove []*ec2.RemoveIpamOperatingRegion
+	for _, v := range oldRegions {
+		if !slices.Contains(newRegions, v) {
+			remove = append(remove, &ec2.RemoveIpamOperatingRegion{
+				RegionName: aws.String(v),
+			})
+		}
+	}
+
+	return add, remove
 }
 
-func expandIPAMOperatingRegionsUpdateDeleteRegion(operatingRegion map[string]interface{}) *ec2.RemoveIpamOperatingRegion {
-	regionUpdate := &ec2.RemoveIpamOperatingRegion{
-		RegionName: aws.String(operatingRegion["region_name"].(string)),
+// normalizeIPAMOperatingRegions returns the sorted, lower-cased names of the operating regions and the home region,
+// without duplicates.
+func normalizeIPAMOperatingRegions(tfList []interface{}, homeRegion string) []string {
+	regions := []string{strings.ToLower(strings.TrimSpace(homeRegion))}
+
+	for _, tfMapRaw := range tfList {
+		tfMap, ok := tfMapRaw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		if v := strings.ToLower(strings.TrimSpace(tfMap["region_name"].(string))); v != "" && !slices.Contains(regions, v) {
+			regions = append(regions, v)
+		}
 	}
-	return regionUpdate
+
+	slices.Sort(regions)
+
+	return regions
 }
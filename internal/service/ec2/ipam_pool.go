@@ -2,7 +2,9 @@ package ec2
 
 import (
 	"context"
+	"fmt"
 	"log"
+	"sort"
 	"strings"
 	"time"
 
@@ -10,6 +12,7 @@ import (
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/hashicorp/aws-sdk-go-base/v2/awsv1shim/v2/tfawserr"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -28,11 +31,12 @@ func ResourceIPAMPool() *schema.Resource {
 		DeleteWithoutTimeout: ResourceIPAMPoolDelete,
 
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: resourceIPAMPoolImport,
 		},
 
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(3 * time.Minute),
+			Read:   schema.DefaultTimeout(3 * time.Minute),
 			Update: schema.DefaultTimeout(3 * time.Minute),
 			Delete: schema.DefaultTimeout(3 * time.Minute),
 		},
@@ -44,6 +48,10 @@ func ResourceIPAMPool() *schema.Resource {
 				ForceNew:     true,
 				ValidateFunc: validation.StringInSlice(ec2.AddressFamily_Values(), false),
 			},
+			"allocation_count": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
 			"allocation_default_netmask_length": {
 				Type:         schema.TypeInt,
 				Optional:     true,
@@ -52,11 +60,13 @@ func ResourceIPAMPool() *schema.Resource {
 			"allocation_max_netmask_length": {
 				Type:         schema.TypeInt,
 				Optional:     true,
+				Computed:     true,
 				ValidateFunc: validation.IntBetween(0, 128),
 			},
 			"allocation_min_netmask_length": {
 				Type:         schema.TypeInt,
 				Optional:     true,
+				Computed:     true,
 				ValidateFunc: validation.IntBetween(0, 128),
 			},
 			"allocation_resource_tags": tftags.TagsSchema(),
@@ -79,6 +89,15 @@ func ResourceIPAMPool() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			"force_destroy": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"ipam_scope_arn": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 			"ipam_scope_id": {
 				Type:     schema.TypeString,
 				Required: true,
@@ -102,6 +121,33 @@ func ResourceIPAMPool() *schema.Resource {
 				Type:     schema.TypeInt,
 				Computed: true,
 			},
+			"provisioned_cidrs": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"cidr": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"netmask_length": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"state": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"public_ip_source": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice(ec2.IpamPoolPublicIpSource_Values(), false),
+			},
 			"publicly_advertisable": {
 				Type:     schema.TypeBool,
 				Optional: true,
@@ -119,22 +165,88 @@ func ResourceIPAMPool() *schema.Resource {
 			"tags_all": tftags.TagsSchemaComputed(),
 		},
 
-		CustomizeDiff: verify.SetTagsDiff,
+		CustomizeDiff: customdiff.Sequence(
+			verify.SetTagsDiff,
+			func(_ context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+				if v := diff.Get("locale").(string); v != "" && v != "None" {
+					if err := verify.ValidRegionNameInPartition(v, meta.(*conns.AWSClient).Partition); err != nil {
+						return fmt.Errorf("`locale`: %w", err)
+					}
+				}
+
+				return nil
+			},
+			func(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+				if !diff.Get("publicly_advertisable").(bool) || !diff.NewValueKnown("ipam_scope_id") {
+					return nil
+				}
+
+				if diff.Id() != "" && !diff.HasChanges("ipam_scope_id", "locale", "publicly_advertisable") {
+					return nil
+				}
+
+				// A locale that isn't known until apply can't be checked.
+				var locale string
+				if diff.NewValueKnown("locale") {
+					locale = diff.Get("locale").(string)
+				}
+
+				return validateIPAMPoolPubliclyAdvertisable(ctx, meta.(*conns.AWSClient).EC2Conn(), diff.Get("ipam_scope_id").(string), locale)
+			},
+		),
+	}
+}
+
+// validateIPAMPoolPubliclyAdvertisable returns an error if a pool in the IPAM Scope with the locale can't be publicly
+// advertisable, i.e. the scope is private or the pool has no locale (`None`), as the space is advertised from the
+// locale's Region. This way the misconfiguration is reported at plan time rather than when advertising.
+func validateIPAMPoolPubliclyAdvertisable(ctx context.Context, conn *ec2.EC2, scopeID, locale string) error {
+	scope, err := FindIPAMScopeByID(ctx, conn, scopeID)
+
+	if err != nil {
+		return fmt.Errorf("reading IPAM Scope (%s): %w", scopeID, err)
+	}
+
+	if scopeType := aws.StringValue(scope.IpamScopeType); scopeType != ec2.IpamScopeTypePublic {
+		return fmt.Errorf("`publicly_advertisable` can only be set for pools in a public IPAM Scope, IPAM Scope (%s) is %s", scopeID, scopeType)
 	}
+
+	if locale == "None" {
+		return fmt.Errorf("`locale` must be set to a Region for publicly advertisable pools in a public IPAM Scope (%s)", scopeID)
+	}
+
+	return nil
+}
+
+// ipamPoolAllocationCount returns the number of allocations from the IPAM Pool, e.g. to VPCs or child pools.
+// The allocations are read in pages of the maximum size, to keep the number of calls low for large pools.
+func ipamPoolAllocationCount(ctx context.Context, conn *ec2.EC2, id string) (int, error) {
+	allocations, err := FindIPAMPoolAllocations(ctx, conn, &ec2.GetIpamPoolAllocationsInput{
+		IpamPoolId: aws.String(id),
+		MaxResults: aws.Int64(1000),
+	})
+
+	if tfresource.NotFound(err) {
+		return 0, nil
+	}
+
+	if err != nil {
+		return 0, fmt.Errorf("reading allocations: %w", err)
+	}
+
+	return len(allocations), nil
 }
 
 func ResourceIPAMPoolCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 	conn := meta.(*conns.AWSClient).EC2Conn()
-	defaultTagsConfig := meta.(*conns.AWSClient).DefaultTagsConfig
-	tags := defaultTagsConfig.MergeTags(tftags.New(d.Get("tags").(map[string]interface{})))
 
 	addressFamily := d.Get("address_family").(string)
 	input := &ec2.CreateIpamPoolInput{
 		AddressFamily:     aws.String(addressFamily),
 		ClientToken:       aws.String(resource.UniqueId()),
 		IpamScopeId:       aws.String(d.Get("ipam_scope_id").(string)),
-		TagSpecifications: tagSpecificationsFromKeyValueTags(tags, ec2.ResourceTypeIpamPool),
+		TagSpecifications: ipamTagSpecifications(d, meta.(*conns.AWSClient).DefaultTagsConfig, ec2.ResourceTypeIpamPool),
 	}
 
 	if v, ok := d.GetOk("allocation_default_netmask_length"); ok {
@@ -149,8 +261,8 @@ func ResourceIPAMPoolCreate(ctx context.Context, d *schema.ResourceData, meta in
 		input.AllocationMinNetmaskLength = aws.Int64(int64(v.(int)))
 	}
 
-	if v, ok := d.GetOk("allocation_resource_tags"); ok && len(v.(map[string]interface{})) > 0 {
-		input.AllocationResourceTags = ipamResourceTags(tftags.New(v.(map[string]interface{})))
+	if v := expandIPAMPoolAllocationResourceTags(d); len(v) > 0 {
+		input.AllocationResourceTags = v
 	}
 
 	if v, ok := d.GetOk("auto_import"); ok {
@@ -162,22 +274,38 @@ func ResourceIPAMPoolCreate(ctx context.Context, d *schema.ResourceData, meta in
 	}
 
 	if v, ok := d.GetOk("locale"); ok && v != "None" {
-		input.Locale = aws.String(v.(string))
+		locale := v.(string)
+
+		if err := validateIPAMPoolLocale(ctx, conn, locale, d.Get("ipam_scope_id").(string)); err != nil {
+			return sdkdiag.AppendErrorf(diags, "creating IPAM Pool: %s", err)
+		}
+
+		input.Locale = aws.String(locale)
 	}
 
 	if v, ok := d.GetOk("aws_service"); ok {
 		input.AwsService = aws.String(v.(string))
 	}
 
+	if v, ok := d.GetOk("public_ip_source"); ok {
+		input.PublicIpSource = aws.String(v.(string))
+	}
+
 	if v := d.Get("publicly_advertisable"); v != "" && addressFamily == ec2.AddressFamilyIpv6 {
 		input.PubliclyAdvertisable = aws.Bool(v.(bool))
 	}
 
 	if v, ok := d.GetOk("source_ipam_pool_id"); ok {
-		input.SourceIpamPoolId = aws.String(v.(string))
+		sourcePoolID := v.(string)
+
+		if err := validateIPAMPoolSourcePoolLocale(ctx, conn, d.Get("locale").(string), sourcePoolID); err != nil {
+			return sdkdiag.AppendErrorf(diags, "creating IPAM Pool: %s", err)
+		}
+
+		input.SourceIpamPoolId = aws.String(sourcePoolID)
 	}
 
-	output, err := conn.CreateIpamPoolWithContext(ctx, input)
+	output, untagged, err := createIPAMPoolWithTagsFallback(ctx, conn, input, d.Timeout(schema.TimeoutCreate))
 
 	if err != nil {
 		return sdkdiag.AppendErrorf(diags, "creating IPAM Pool: %s", err)
@@ -189,9 +317,64 @@ func ResourceIPAMPoolCreate(ctx context.Context, d *schema.ResourceData, meta in
 		return sdkdiag.AppendErrorf(diags, "waiting for IPAM Pool (%s) create: %s", d.Id(), err)
 	}
 
+	if untagged {
+		diags = sdkdiag.AppendWarningf(diags, "IPAM Pool (%s) was created without tags because tagging on create is not authorized; tagging it after create instead", d.Id())
+
+		tags := meta.(*conns.AWSClient).DefaultTagsConfig.MergeTags(tftags.New(d.Get("tags").(map[string]interface{})))
+
+		if err := UpdateTags(ctx, conn, d.Id(), nil, tags); err != nil {
+			return sdkdiag.AppendErrorf(diags, "adding IPAM Pool (%s) tags: %s", d.Id(), err)
+		}
+	}
+
 	return append(diags, ResourceIPAMPoolRead(ctx, d, meta)...)
 }
 
+// setIPAMPoolAllocationNetmaskLengths sets the pool's allocation netmask length policy, which the resource and the data
+// source share. AWS omits the default netmask length once it is cleared, and reports the minimum and maximum netmask
+// lengths for the address family when they weren't configured.
+func setIPAMPoolAllocationNetmaskLengths(d *schema.ResourceData, pool *ec2.IpamPool) {
+	d.Set("allocation_default_netmask_length", pool.AllocationDefaultNetmaskLength)
+	d.Set("allocation_max_netmask_length", pool.AllocationMaxNetmaskLength)
+	d.Set("allocation_min_netmask_length", pool.AllocationMinNetmaskLength)
+}
+
+// createIPAMPoolWithTagsFallback creates an IPAM Pool. If tagging on create is not authorized, the pool is created again
+// without tags, with a new client token, and the returned bool is true so that the caller can tag the pool afterwards.
+func createIPAMPoolWithTagsFallback(ctx context.Context, conn *ec2.EC2, input *ec2.CreateIpamPoolInput, timeout time.Duration) (*ec2.CreateIpamPoolOutput, bool, error) {
+	output, err := createIPAMPool(ctx, conn, input, timeout)
+
+	if !ipamTagOnCreateUnauthorized(err, input.TagSpecifications) {
+		return output, false, err
+	}
+
+	log.Printf("[WARN] Creating IPAM Pool with tags not authorized, creating without tags: %s", err)
+	input.ClientToken = aws.String(resource.UniqueId())
+	input.TagSpecifications = nil
+
+	output, err = createIPAMPool(ctx, conn, input, timeout)
+
+	if err != nil {
+		return nil, false, err
+	}
+
+	return output, true, nil
+}
+
+// createIPAMPool creates an IPAM Pool, retrying while the request is throttled.
+// Every attempt sends the input's client token, so that an attempt that AWS processed despite failing can't create a duplicate pool.
+func createIPAMPool(ctx context.Context, conn *ec2.EC2, input *ec2.CreateIpamPoolInput, timeout time.Duration) (*ec2.CreateIpamPoolOutput, error) {
+	outputRaw, err := tfresource.RetryWhenAWSErrCodeEquals(ctx, timeout, func() (interface{}, error) {
+		return conn.CreateIpamPoolWithContext(ctx, input)
+	}, errCodeRequestLimitExceeded)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return outputRaw.(*ec2.CreateIpamPoolOutput), nil
+}
+
 func ResourceIPAMPoolRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 	conn := meta.(*conns.AWSClient).EC2Conn()
@@ -200,6 +383,14 @@ func ResourceIPAMPoolRead(ctx context.Context, d *schema.ResourceData, meta inte
 
 	pool, err := FindIPAMPoolByID(ctx, conn, d.Id())
 
+	// Don't report the transient state of a create or modify made outside of this resource.
+	if err == nil {
+		switch aws.StringValue(pool.State) {
+		case ec2.IpamPoolStateCreateInProgress, ec2.IpamPoolStateModifyInProgress:
+			pool, err = WaitIPAMPoolStable(ctx, conn, d.Id(), d.Timeout(schema.TimeoutRead))
+		}
+	}
+
 	if !d.IsNewResource() && tfresource.NotFound(err) {
 		log.Printf("[WARN] IPAM Pool (%s) not found, removing from state", d.Id())
 		d.SetId("")
@@ -211,20 +402,43 @@ func ResourceIPAMPoolRead(ctx context.Context, d *schema.ResourceData, meta inte
 	}
 
 	d.Set("address_family", pool.AddressFamily)
-	d.Set("allocation_resource_tags", KeyValueTags(tagsFromIPAMAllocationTags(pool.AllocationResourceTags)).Map())
+	setIPAMPoolAllocationNetmaskLengths(d, pool)
+	allocationCount, err := ipamPoolAllocationCount(ctx, conn, d.Id())
+	if err != nil {
+		return sdkdiag.AppendErrorf(diags, "reading IPAM Pool (%s): %s", d.Id(), err)
+	}
+	d.Set("allocation_count", allocationCount)
+	d.Set("allocation_resource_tags", flattenIPAMPoolAllocationResourceTags(pool.AllocationResourceTags, ignoreTagsConfig))
 	d.Set("arn", pool.IpamPoolArn)
 	d.Set("auto_import", pool.AutoImport)
 	d.Set("aws_service", pool.AwsService)
 	d.Set("description", pool.Description)
-	scopeID := strings.Split(aws.StringValue(pool.IpamScopeArn), "/")[1]
+	scopeID, err := IPAMResourceARNToID(aws.StringValue(pool.IpamScopeArn))
+	if err != nil {
+		return sdkdiag.AppendErrorf(diags, "reading IPAM Pool (%s): %s", d.Id(), err)
+	}
+	d.Set("ipam_scope_arn", pool.IpamScopeArn)
 	d.Set("ipam_scope_id", scopeID)
 	d.Set("ipam_scope_type", pool.IpamScopeType)
 	d.Set("locale", pool.Locale)
 	d.Set("pool_depth", pool.PoolDepth)
+	d.Set("public_ip_source", pool.PublicIpSource)
 	d.Set("publicly_advertisable", pool.PubliclyAdvertisable)
 	d.Set("source_ipam_pool_id", pool.SourceIpamPoolId)
 	d.Set("state", pool.State)
 
+	cidrs, err := FindIPAMPoolCIDRs(ctx, conn, &ec2.GetIpamPoolCidrsInput{
+		IpamPoolId: aws.String(d.Id()),
+	})
+
+	if err != nil {
+		return sdkdiag.AppendErrorf(diags, "reading IPAM Pool (%s) CIDRs: %s", d.Id(), err)
+	}
+
+	if err := d.Set("provisioned_cidrs", flattenIPAMPoolCIDRs(provisionedIPAMPoolCIDRs(cidrs))); err != nil {
+		return sdkdiag.AppendErrorf(diags, "setting provisioned_cidrs: %s", err)
+	}
+
 	tags := KeyValueTags(pool.Tags).IgnoreAWS().IgnoreConfig(ignoreTagsConfig)
 
 	//lintignore:AWSR002
@@ -244,70 +458,96 @@ func ResourceIPAMPoolUpdate(ctx context.Context, d *schema.ResourceData, meta in
 	conn := meta.(*conns.AWSClient).EC2Conn()
 
 	if d.HasChangesExcept("tags", "tags_all") {
-		input := &ec2.ModifyIpamPoolInput{
-			IpamPoolId: aws.String(d.Id()),
-		}
-
-		if v, ok := d.GetOk("allocation_default_netmask_length"); ok {
-			input.AllocationDefaultNetmaskLength = aws.Int64(int64(v.(int)))
+		if err := modifyIPAMPool(ctx, conn, expandModifyIPAMPoolInput(d), d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return sdkdiag.AppendErrorf(diags, "updating IPAM Pool (%s): %s", d.Id(), err)
 		}
+	}
 
-		if v, ok := d.GetOk("allocation_max_netmask_length"); ok {
-			input.AllocationMaxNetmaskLength = aws.Int64(int64(v.(int)))
-		}
+	if d.HasChange("tags_all") {
+		o, n := d.GetChange("tags_all")
 
-		if v, ok := d.GetOk("allocation_min_netmask_length"); ok {
-			input.AllocationMinNetmaskLength = aws.Int64(int64(v.(int)))
+		if err := UpdateTags(ctx, conn, d.Id(), o, n); err != nil {
+			return sdkdiag.AppendErrorf(diags, "updating IPAM Pool (%s) tags: %s", d.Id(), err)
 		}
+	}
 
-		if d.HasChange("allocation_resource_tags") {
-			o, n := d.GetChange("allocation_resource_tags")
-			oldTags := tftags.New(o)
-			newTags := tftags.New(n)
+	return append(diags, ResourceIPAMPoolRead(ctx, d, meta)...)
+}
 
-			if removedTags := oldTags.Removed(newTags); len(removedTags) > 0 {
-				input.RemoveAllocationResourceTags = ipamResourceTags(removedTags.IgnoreAWS())
-			}
+// expandModifyIPAMPoolInput returns the input that modifies all of the IPAM Pool's changed arguments, including the
+// allocation resource tags to add and remove, in a single ModifyIpamPool call.
+func expandModifyIPAMPoolInput(d *schema.ResourceData) *ec2.ModifyIpamPoolInput {
+	input := &ec2.ModifyIpamPoolInput{
+		IpamPoolId: aws.String(d.Id()),
+	}
 
-			if updatedTags := oldTags.Updated(newTags); len(updatedTags) > 0 {
-				input.AddAllocationResourceTags = ipamResourceTags(updatedTags.IgnoreAWS())
-			}
+	if d.HasChange("allocation_default_netmask_length") {
+		// Removing the argument clears the default, which otherwise stays as it was.
+		if v, ok := d.GetOk("allocation_default_netmask_length"); ok {
+			input.AllocationDefaultNetmaskLength = aws.Int64(int64(v.(int)))
+		} else {
+			input.ClearAllocationDefaultNetmaskLength = aws.Bool(true)
 		}
+	}
 
-		if v, ok := d.GetOk("auto_import"); ok {
-			input.AutoImport = aws.Bool(v.(bool))
-		}
+	if v, ok := d.GetOk("allocation_max_netmask_length"); ok {
+		input.AllocationMaxNetmaskLength = aws.Int64(int64(v.(int)))
+	}
 
-		if v, ok := d.GetOk("description"); ok {
-			input.Description = aws.String(v.(string))
-		}
+	if v, ok := d.GetOk("allocation_min_netmask_length"); ok {
+		input.AllocationMinNetmaskLength = aws.Int64(int64(v.(int)))
+	}
 
-		_, err := conn.ModifyIpamPoolWithContext(ctx, input)
+	if d.HasChange("allocation_resource_tags") {
+		o, n := d.GetChange("allocation_resource_tags")
+		oldTags := tftags.New(o)
+		newTags := tftags.New(n)
 
-		if err != nil {
-			return sdkdiag.AppendErrorf(diags, "updating IPAM Pool (%s): %s", d.Id(), err)
+		if removedTags := oldTags.Removed(newTags); len(removedTags) > 0 {
+			input.RemoveAllocationResourceTags = ipamResourceTags(removedTags.IgnoreAWS())
 		}
 
-		if _, err := WaitIPAMPoolUpdated(ctx, conn, d.Id(), d.Timeout(schema.TimeoutUpdate)); err != nil {
-			return sdkdiag.AppendErrorf(diags, "waiting for IPAM Pool (%s) update: %s", d.Id(), err)
+		if updatedTags := oldTags.Updated(newTags); len(updatedTags) > 0 {
+			input.AddAllocationResourceTags = ipamResourceTags(updatedTags.IgnoreAWS())
 		}
 	}
 
-	if d.HasChange("tags_all") {
-		o, n := d.GetChange("tags_all")
+	if d.HasChange("auto_import") {
+		input.AutoImport = aws.Bool(d.Get("auto_import").(bool))
+	}
 
-		if err := UpdateTags(ctx, conn, d.Id(), o, n); err != nil {
-			return sdkdiag.AppendErrorf(diags, "updating IPAM Pool (%s) tags: %s", d.Id(), err)
-		}
+	if v, ok := d.GetOk("description"); ok {
+		input.Description = aws.String(v.(string))
 	}
 
-	return append(diags, ResourceIPAMPoolRead(ctx, d, meta)...)
+	return input
+}
+
+// modifyIPAMPool modifies an IPAM Pool with a single ModifyIpamPool call and waits for the modification to complete.
+func modifyIPAMPool(ctx context.Context, conn *ec2.EC2, input *ec2.ModifyIpamPoolInput, timeout time.Duration) error {
+	id := aws.StringValue(input.IpamPoolId)
+
+	if _, err := conn.ModifyIpamPoolWithContext(ctx, input); err != nil {
+		return err
+	}
+
+	if _, err := WaitIPAMPoolUpdated(ctx, conn, id, timeout); err != nil {
+		return fmt.Errorf("waiting for update: %w", err)
+	}
+
+	return nil
 }
 
 func ResourceIPAMPoolDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 	conn := meta.(*conns.AWSClient).EC2Conn()
 
+	if d.Get("force_destroy").(bool) {
+		if err := deprovisionIPAMPoolCIDRs(ctx, conn, d.Id(), d.Timeout(schema.TimeoutDelete)); err != nil {
+			return sdkdiag.AppendErrorf(diags, "deleting IPAM Pool (%s): %s", d.Id(), err)
+		}
+	}
+
 	log.Printf("[DEBUG] Deleting IPAM Pool: %s", d.Id())
 	_, err := conn.DeleteIpamPoolWithContext(ctx, &ec2.DeleteIpamPoolInput{
 		IpamPoolId: aws.String(d.Id()),
@@ -317,6 +557,16 @@ func ResourceIPAMPoolDelete(ctx context.Context, d *schema.ResourceData, meta in
 		return diags
 	}
 
+	if ipamResourceAlreadyDeleting(err, StatusIPAMPoolState(ctx, conn, d.Id()), ec2.IpamPoolStateDeleteInProgress) {
+		err = nil
+	}
+
+	// A pool that still has CIDRs provisioned, e.g. advertised ones, is in an incorrect state for deletion.
+	if tfawserr.ErrCodeEquals(err, errCodeIncorrectState) && d.Get("publicly_advertisable").(bool) {
+		// Advertised CIDRs can't be withdrawn by modifying the pool.
+		return sdkdiag.AppendErrorf(diags, "deleting IPAM Pool (%s): %s. Publicly advertisable CIDRs must be withdrawn from advertising (e.g. with `aws ec2 withdraw-byoip-cidr`) and deprovisioned before the pool can be deleted", d.Id(), err)
+	}
+
 	if err != nil {
 		return sdkdiag.AppendErrorf(diags, "deleting IPAM Pool (%s): %s", d.Id(), err)
 	}
@@ -328,13 +578,138 @@ func ResourceIPAMPoolDelete(ctx context.Context, d *schema.ResourceData, meta in
 	return diags
 }
 
+func resourceIPAMPoolImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	d.Set("force_destroy", false)
+	return []*schema.ResourceData{d}, nil
+}
+
+// validateIPAMPoolLocale returns an error if the locale isn't one of the operating regions of the IPAM of the specified scope.
+func validateIPAMPoolLocale(ctx context.Context, conn *ec2.EC2, locale, scopeID string) error {
+	scope, err := FindIPAMScopeByID(ctx, conn, scopeID)
+
+	if err != nil {
+		return fmt.Errorf("reading IPAM Scope (%s): %w", scopeID, err)
+	}
+
+	ipamID, err := IPAMResourceARNToID(aws.StringValue(scope.IpamArn))
+
+	if err != nil {
+		return err
+	}
+
+	ipam, err := FindIPAMByID(ctx, conn, ipamID)
+
+	if err != nil {
+		return fmt.Errorf("reading IPAM (%s): %w", ipamID, err)
+	}
+
+	var regions []string
+	for _, v := range ipam.OperatingRegions {
+		region := aws.StringValue(v.RegionName)
+
+		if region == locale {
+			return nil
+		}
+
+		regions = append(regions, region)
+	}
+
+	return fmt.Errorf("locale (%s) is not an operating region of IPAM (%s), expected one of %s", locale, ipamID, strings.Join(regions, ", "))
+}
+
+// validateIPAMPoolSourcePoolLocale returns an error if a pool with the specified locale cannot be created from the source pool.
+// A localized source pool only allows child pools with no locale or with the same locale.
+func validateIPAMPoolSourcePoolLocale(ctx context.Context, conn *ec2.EC2, locale, sourcePoolID string) error {
+	sourcePool, err := FindIPAMPoolByID(ctx, conn, sourcePoolID)
+
+	if err != nil {
+		return fmt.Errorf("reading source IPAM Pool (%s): %w", sourcePoolID, err)
+	}
+
+	sourceLocale := aws.StringValue(sourcePool.Locale)
+
+	if locale == "" || locale == "None" || sourceLocale == "" || sourceLocale == "None" || locale == sourceLocale {
+		return nil
+	}
+
+	return fmt.Errorf("locale (%s) is incompatible with source IPAM Pool (%s) locale (%s), expected None or %[3]s", locale, sourcePoolID, sourceLocale)
+}
+
+// deprovisionIPAMPoolCIDRs deprovisions all of the CIDRs provisioned to the specified pool and waits for them to be deprovisioned.
+// CIDRs that are already being deprovisioned are only waited on.
+func deprovisionIPAMPoolCIDRs(ctx context.Context, conn *ec2.EC2, poolID string, timeout time.Duration) error {
+	cidrs, err := FindIPAMPoolCIDRs(ctx, conn, &ec2.GetIpamPoolCidrsInput{
+		IpamPoolId: aws.String(poolID),
+	})
+
+	if tfresource.NotFound(err) {
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("reading IPAM Pool (%s) CIDRs: %w", poolID, err)
+	}
+
+	cidrs = provisionedIPAMPoolCIDRs(cidrs)
+
+	for _, v := range cidrs {
+		cidrBlock := aws.StringValue(v.Cidr)
+
+		if aws.StringValue(v.State) == ec2.IpamPoolCidrStatePendingDeprovision {
+			continue
+		}
+
+		log.Printf("[DEBUG] Deprovisioning IPAM Pool (%s) CIDR: %s", poolID, cidrBlock)
+		_, err := conn.DeprovisionIpamPoolCidrWithContext(ctx, &ec2.DeprovisionIpamPoolCidrInput{
+			Cidr:       aws.String(cidrBlock),
+			IpamPoolId: aws.String(poolID),
+		})
+
+		if tfawserr.ErrCodeEquals(err, errCodeInvalidIPAMPoolIdNotFound) {
+			return nil
+		}
+
+		// IncorrectState error can mean: State = "deprovisioned" || State = "pending-deprovision".
+		if err != nil && !tfawserr.ErrCodeEquals(err, errCodeIncorrectState) {
+			return fmt.Errorf("deprovisioning IPAM Pool (%s) CIDR (%s): %w", poolID, cidrBlock, err)
+		}
+	}
+
+	for _, v := range cidrs {
+		cidrBlock := aws.StringValue(v.Cidr)
+
+		if _, err := WaitIPAMPoolCIDRDeleted(ctx, conn, cidrBlock, poolID, timeout); err != nil {
+			return fmt.Errorf("waiting for IPAM Pool (%s) CIDR (%s) deprovision: %w", poolID, cidrBlock, err)
+		}
+	}
+
+	return nil
+}
+
+// provisionedIPAMPoolCIDRs returns the CIDRs that are not deprovisioned.
+func provisionedIPAMPoolCIDRs(cidrs []*ec2.IpamPoolCidr) []*ec2.IpamPoolCidr {
+	var output []*ec2.IpamPoolCidr
+
+	for _, v := range cidrs {
+		if aws.StringValue(v.State) != ec2.IpamPoolCidrStateDeprovisioned {
+			output = append(output, v)
+		}
+	}
+
+	return output
+}
+
+// ipamResourceTags returns the IPAM resource tags sorted by key so that requests are deterministic.
 func ipamResourceTags(tags tftags.KeyValueTags) []*ec2.RequestIpamResourceTag {
-	result := make([]*ec2.RequestIpamResourceTag, 0, len(tags))
+	m := tags.Map()
+	keys := tags.Keys()
+	sort.Strings(keys)
+	result := make([]*ec2.RequestIpamResourceTag, 0, len(keys))
 
-	for k, v := range tags.Map() {
+	for _, k := range keys {
 		tag := &ec2.RequestIpamResourceTag{
 			Key:   aws.String(k),
-			Value: aws.String(v),
+			Value: aws.String(m[k]),
 		}
 
 		result = append(result, tag)
@@ -343,6 +718,19 @@ func ipamResourceTags(tags tftags.KeyValueTags) []*ec2.RequestIpamResourceTag {
 	return result
 }
 
+// expandIPAMPoolAllocationResourceTags returns the configured allocation resource tags.
+// They are required of the resources allocated from the pool rather than applied to the pool,
+// so unlike the pool's tags they don't include the provider's default tags.
+func expandIPAMPoolAllocationResourceTags(d *schema.ResourceData) []*ec2.RequestIpamResourceTag {
+	return ipamResourceTags(tftags.New(d.Get("allocation_resource_tags").(map[string]interface{})).IgnoreAWS())
+}
+
+// flattenIPAMPoolAllocationResourceTags returns the pool's allocation resource tags without those ignored by the provider.
+// The provider's default tags aren't removed, as they are never added to the allocation resource tags.
+func flattenIPAMPoolAllocationResourceTags(apiObjects []*ec2.IpamResourceTag, ignoreTagsConfig *tftags.IgnoreConfig) map[string]string {
+	return KeyValueTags(tagsFromIPAMAllocationTags(apiObjects)).IgnoreAWS().IgnoreConfig(ignoreTagsConfig).Map()
+}
+
 func tagsFromIPAMAllocationTags(rts []*ec2.IpamResourceTag) []*ec2.Tag {
 	if len(rts) == 0 {
 		return nil
@@ -350,6 +738,10 @@ func tagsFromIPAMAllocationTags(rts []*ec2.IpamResourceTag) []*ec2.Tag {
 
 	tags := []*ec2.Tag{}
 	for _, ts := range rts {
+		if ts == nil || ts.Key == nil {
+			continue
+		}
+
 		tags = append(tags, &ec2.Tag{
 			Key:   ts.Key,
 			Value: ts.Value,
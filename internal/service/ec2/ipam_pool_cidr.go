@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"math/big"
+	"net/netip"
 	"strings"
 	"time"
 
@@ -11,6 +13,7 @@ import (
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/hashicorp/aws-sdk-go-base/v2/awsv1shim/v2/tfawserr"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/hashicorp/terraform-provider-aws/internal/conns"
@@ -37,10 +40,11 @@ func ResourceIPAMPoolCIDR() *schema.Resource {
 
 		Schema: map[string]*schema.Schema{
 			"cidr": {
-				Type:     schema.TypeString,
-				Optional: true,
-				ForceNew: true,
-				Computed: true,
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				Computed:      true,
+				ConflictsWith: []string{"netmask_length"},
 				ValidateFunc: validation.Any(
 					verify.ValidIPv4CIDRNetworkAddress,
 					verify.ValidIPv6CIDRNetworkAddress,
@@ -55,13 +59,14 @@ func ResourceIPAMPoolCIDR() *schema.Resource {
 					Schema: map[string]*schema.Schema{
 						"message": {
 							Type:     schema.TypeString,
-							Optional: true,
+							Required: true,
 							ForceNew: true,
 						},
 						"signature": {
-							Type:     schema.TypeString,
-							Optional: true,
-							ForceNew: true,
+							Type:      schema.TypeString,
+							Required:  true,
+							ForceNew:  true,
+							Sensitive: true,
 						},
 					},
 				},
@@ -71,37 +76,96 @@ func ResourceIPAMPoolCIDR() *schema.Resource {
 				Required: true,
 				ForceNew: true,
 			},
+			"netmask_length": {
+				Type:          schema.TypeInt,
+				Optional:      true,
+				ForceNew:      true,
+				Computed:      true,
+				ValidateFunc:  validation.IntBetween(0, 128),
+				ConflictsWith: []string{"cidr"},
+			},
 		},
 	}
 }
 
+// findIPAMPoolCIDRAllocations returns the allocations from the pool that lie within the provisioned CIDR.
+func findIPAMPoolCIDRAllocations(ctx context.Context, conn *ec2.EC2, cidrBlock, poolID string) ([]*ec2.IpamPoolAllocation, error) {
+	prefix, err := netip.ParsePrefix(cidrBlock)
+
+	if err != nil {
+		return nil, fmt.Errorf("parsing IPAM Pool CIDR (%s): %w", cidrBlock, err)
+	}
+
+	allocations, err := FindIPAMPoolAllocations(ctx, conn, &ec2.GetIpamPoolAllocationsInput{
+		IpamPoolId: aws.String(poolID),
+	})
+
+	if tfresource.NotFound(err) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("reading IPAM Pool (%s) allocations: %w", poolID, err)
+	}
+
+	var output []*ec2.IpamPoolAllocation
+
+	for _, v := range allocations {
+		if allocation, err := netip.ParsePrefix(aws.StringValue(v.Cidr)); err == nil && allocation.Overlaps(prefix) {
+			output = append(output, v)
+		}
+	}
+
+	return output, nil
+}
+
 func resourceIPAMPoolCIDRCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 	conn := meta.(*conns.AWSClient).EC2Conn()
 
 	poolID := d.Get("ipam_pool_id").(string)
-	input := &ec2.ProvisionIpamPoolCidrInput{
-		IpamPoolId: aws.String(poolID),
+	input := expandProvisionIPAMPoolCIDRInput(d)
+
+	pool, err := FindIPAMPoolByID(ctx, conn, poolID)
+
+	if err != nil {
+		return sdkdiag.AppendErrorf(diags, "reading IPAM Pool (%s): %s", poolID, err)
 	}
 
-	if v, ok := d.GetOk("cidr"); ok {
-		input.Cidr = aws.String(v.(string))
+	if err := validateIPAMPoolCIDRAmazonProvided(pool, d.Get("cidr").(string), d.Get("netmask_length").(int)); err != nil {
+		return sdkdiag.AppendErrorf(diags, "creating IPAM Pool (%s) CIDR: %s", poolID, err)
 	}
 
-	if v, ok := d.GetOk("cidr_authorization_context"); ok && len(v.([]interface{})) > 0 && v.([]interface{})[0] != nil {
-		input.CidrAuthorizationContext = expandIPAMCIDRAuthorizationContext(v.([]interface{})[0].(map[string]interface{}))
+	if v, ok := d.GetOk("netmask_length"); ok {
+		if err := validateIPAMPoolCIDRNetmaskLength(ctx, conn, pool, v.(int)); err != nil {
+			return sdkdiag.AppendErrorf(diags, "creating IPAM Pool (%s) CIDR: %s", poolID, err)
+		}
 	}
 
-	output, err := conn.ProvisionIpamPoolCidrWithContext(ctx, input)
+	output, err := provisionIPAMPoolCIDR(ctx, conn, input, d.Timeout(schema.TimeoutCreate))
 
 	if err != nil {
 		return sdkdiag.AppendErrorf(diags, "creating IPAM Pool (%s) CIDR: %s", poolID, err)
 	}
 
 	cidrBlock := aws.StringValue(output.IpamPoolCidr.Cidr)
+
+	// The CIDR of a pool CIDR provisioned by netmask length may not be known immediately.
+	if cidrBlock == "" {
+		poolCIDRID := aws.StringValue(output.IpamPoolCidr.IpamPoolCidrId)
+		outputRaw, err := tfresource.RetryWhenNotFound(ctx, propagationTimeout, func() (interface{}, error) {
+			return FindIPAMPoolCIDRByPoolCIDRID(ctx, conn, poolCIDRID, poolID)
+		})
+
+		if err != nil {
+			return sdkdiag.AppendErrorf(diags, "reading IPAM Pool (%s) CIDR (%s): %s", poolID, poolCIDRID, err)
+		}
+
+		cidrBlock = aws.StringValue(outputRaw.(*ec2.IpamPoolCidr).Cidr)
+	}
 	d.SetId(IPAMPoolCIDRCreateResourceID(cidrBlock, poolID))
 
-	if _, err := WaitIPAMPoolCIDRCreated(ctx, conn, cidrBlock, poolID, d.Timeout(schema.TimeoutDelete)); err != nil {
+	if _, err := WaitIPAMPoolCIDRCreated(ctx, conn, cidrBlock, poolID, d.Timeout(schema.TimeoutCreate)); err != nil {
 		return sdkdiag.AppendErrorf(diags, "waiting for IPAM Pool CIDR (%s) create: %s", d.Id(), err)
 	}
 
@@ -132,6 +196,7 @@ func resourceIPAMPoolCIDRRead(ctx context.Context, d *schema.ResourceData, meta
 
 	d.Set("cidr", output.Cidr)
 	d.Set("ipam_pool_id", poolID)
+	d.Set("netmask_length", output.NetmaskLength)
 
 	return diags
 }
@@ -147,25 +212,62 @@ func resourceIPAMPoolCIDRDelete(ctx context.Context, d *schema.ResourceData, met
 	}
 
 	log.Printf("[DEBUG] Deleting IPAM Pool CIDR: %s", d.Id())
-	_, err = conn.DeprovisionIpamPoolCidrWithContext(ctx, &ec2.DeprovisionIpamPoolCidrInput{
+	if err := deprovisionIPAMPoolCIDR(ctx, conn, cidrBlock, poolID, d.Timeout(schema.TimeoutDelete)); err != nil {
+		return sdkdiag.AppendErrorf(diags, "deleting IPAM Pool CIDR (%s): %s", d.Id(), err)
+	}
+
+	return diags
+}
+
+// deprovisionIPAMPoolCIDR deprovisions the CIDR from the pool and waits for it to be deprovisioned.
+// If deprovisioning fails, the error lists the allocations from the pool that remain in the CIDR and block it.
+func deprovisionIPAMPoolCIDR(ctx context.Context, conn *ec2.EC2, cidrBlock, poolID string, timeout time.Duration) error {
+	_, err := conn.DeprovisionIpamPoolCidrWithContext(ctx, &ec2.DeprovisionIpamPoolCidrInput{
 		Cidr:       aws.String(cidrBlock),
 		IpamPoolId: aws.String(poolID),
 	})
 
 	if tfawserr.ErrCodeEquals(err, errCodeInvalidIPAMPoolIdNotFound) {
-		return diags
+		return nil
 	}
 
 	// IncorrectState error can mean: State = "deprovisioned" || State = "pending-deprovision".
 	if err != nil && !tfawserr.ErrCodeEquals(err, errCodeIncorrectState) {
-		return sdkdiag.AppendErrorf(diags, "deleting IPAM Pool CIDR (%s): %s", d.Id(), err)
+		return ipamPoolCIDRAllocationsError(ctx, conn, cidrBlock, poolID, err)
 	}
 
-	if _, err := WaitIPAMPoolCIDRDeleted(ctx, conn, cidrBlock, poolID, d.Timeout(schema.TimeoutDelete)); err != nil {
-		return sdkdiag.AppendErrorf(diags, "waiting for IPAM Pool CIDR (%s) delete: %s", d.Id(), err)
+	if _, err := WaitIPAMPoolCIDRDeleted(ctx, conn, cidrBlock, poolID, timeout); err != nil {
+		return ipamPoolCIDRAllocationsError(ctx, conn, cidrBlock, poolID, fmt.Errorf("waiting for deprovision: %w", err))
 	}
 
-	return diags
+	return nil
+}
+
+// ipamPoolCIDRAllocationsError returns err with the allocations from the pool that lie within the CIDR, if any.
+// The allocations are only added on a best-effort basis, as they don't change the outcome.
+func ipamPoolCIDRAllocationsError(ctx context.Context, conn *ec2.EC2, cidrBlock, poolID string, err error) error {
+	allocations, findErr := findIPAMPoolCIDRAllocations(ctx, conn, cidrBlock, poolID)
+
+	if findErr != nil {
+		log.Printf("[WARN] Listing IPAM Pool CIDR (%s) allocations: %s", IPAMPoolCIDRCreateResourceID(cidrBlock, poolID), findErr)
+		return err
+	}
+
+	if len(allocations) == 0 {
+		return err
+	}
+
+	var blocking []string
+	for _, v := range allocations {
+		owner := aws.StringValue(v.ResourceType)
+		if id := aws.StringValue(v.ResourceId); id != "" {
+			owner = fmt.Sprintf("%s %s", owner, id)
+		}
+
+		blocking = append(blocking, fmt.Sprintf("%s (%s, %s)", aws.StringValue(v.IpamPoolAllocationId), aws.StringValue(v.Cidr), owner))
+	}
+
+	return fmt.Errorf("%w; %d allocations remain in the CIDR: %s", err, len(allocations), strings.Join(blocking, ", "))
 }
 
 const ipamPoolCIDRIDSeparator = "_"
@@ -187,6 +289,232 @@ func IPAMPoolCIDRParseResourceID(id string) (string, string, error) {
 	return parts[0], parts[1], nil
 }
 
+// expandProvisionIPAMPoolCIDRInput returns the ProvisionIpamPoolCidr input for the resource.
+// The CIDR authorization context is only sent on provision and is never read back.
+func expandProvisionIPAMPoolCIDRInput(d *schema.ResourceData) *ec2.ProvisionIpamPoolCidrInput {
+	input := &ec2.ProvisionIpamPoolCidrInput{
+		IpamPoolId: aws.String(d.Get("ipam_pool_id").(string)),
+	}
+
+	if v, ok := d.GetOk("cidr"); ok {
+		input.Cidr = aws.String(v.(string))
+	}
+
+	if v, ok := d.GetOk("cidr_authorization_context"); ok && len(v.([]interface{})) > 0 && v.([]interface{})[0] != nil {
+		input.CidrAuthorizationContext = expandIPAMCIDRAuthorizationContext(v.([]interface{})[0].(map[string]interface{}))
+	}
+
+	if v, ok := d.GetOk("netmask_length"); ok {
+		input.NetmaskLength = aws.Int64(int64(v.(int)))
+	}
+
+	return input
+}
+
+// provisionIPAMPoolCIDR provisions a CIDR into an IPAM pool.
+// Shortly after an overlapping CIDR is deprovisioned, AWS can report an overlap until the deprovision has propagated,
+// so an overlap is retried until the timeout expires and only then reported as a genuine overlap.
+func provisionIPAMPoolCIDR(ctx context.Context, conn *ec2.EC2, input *ec2.ProvisionIpamPoolCidrInput, timeout time.Duration) (*ec2.ProvisionIpamPoolCidrOutput, error) {
+	outputRaw, err := tfresource.RetryWhenAWSErrMessageContains(ctx, timeout, func() (interface{}, error) {
+		// Each attempt is a new request, not a replay of the one that failed.
+		input.ClientToken = aws.String(resource.UniqueId())
+
+		return conn.ProvisionIpamPoolCidrWithContext(ctx, input)
+	}, errCodeInvalidParameterValue, "overlap")
+
+	if tfawserr.ErrMessageContains(err, errCodeInvalidParameterValue, "overlap") {
+		return nil, fmt.Errorf("CIDR still overlaps after retrying for %s: %w", timeout, err)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return outputRaw.(*ec2.ProvisionIpamPoolCidrOutput), nil
+}
+
+// Netmask lengths of the Amazon-provided IPv6 CIDRs that can be provisioned to a public pool.
+// See https://docs.aws.amazon.com/vpc/latest/ipam/intro-create-ipv6-pools.html.
+const (
+	ipamPoolAmazonProvidedIPv6NetmaskLengthMin = 40
+	ipamPoolAmazonProvidedIPv6NetmaskLengthMax = 52
+)
+
+// validateIPAMPoolCIDRAmazonProvided returns an error if the CIDR arguments can't be used to provision
+// Amazon-provided space to a pool with an `amazon` public IP source. Amazon picks the CIDR from the netmask length.
+func validateIPAMPoolCIDRAmazonProvided(pool *ec2.IpamPool, cidr string, netmaskLength int) error {
+	if aws.StringValue(pool.PublicIpSource) != ec2.IpamPoolPublicIpSourceAmazon {
+		return nil
+	}
+
+	poolID := aws.StringValue(pool.IpamPoolId)
+
+	if cidr != "" {
+		return fmt.Errorf("cidr can't be set for IPAM Pool (%s) with public_ip_source %s, set netmask_length and Amazon picks the CIDR", poolID, ec2.IpamPoolPublicIpSourceAmazon)
+	}
+
+	if netmaskLength == 0 {
+		return fmt.Errorf("netmask_length is required for IPAM Pool (%s) with public_ip_source %s", poolID, ec2.IpamPoolPublicIpSourceAmazon)
+	}
+
+	if aws.StringValue(pool.AddressFamily) == ec2.AddressFamilyIpv6 && (netmaskLength < ipamPoolAmazonProvidedIPv6NetmaskLengthMin || netmaskLength > ipamPoolAmazonProvidedIPv6NetmaskLengthMax) {
+		return fmt.Errorf("netmask_length (%d) must be between %d and %d for Amazon-provided IPv6 CIDRs in IPAM Pool (%s)", netmaskLength, ipamPoolAmazonProvidedIPv6NetmaskLengthMin, ipamPoolAmazonProvidedIPv6NetmaskLengthMax, poolID)
+	}
+
+	return nil
+}
+
+// validateIPAMPoolCIDRNetmaskLength returns an error if a CIDR with the specified netmask length cannot be provisioned
+// into the pool because no free block of that size remains in the pool's source pool.
+func validateIPAMPoolCIDRNetmaskLength(ctx context.Context, conn *ec2.EC2, pool *ec2.IpamPool, netmaskLength int) error {
+	sourcePoolID := aws.StringValue(pool.SourceIpamPoolId)
+
+	if sourcePoolID == "" {
+		return nil
+	}
+
+	provisioned, allocated, err := findIPAMPoolProvisionedAndAllocatedCIDRs(ctx, conn, sourcePoolID)
+
+	if err != nil {
+		return err
+	}
+
+	ok, err := ipamPoolCIDRBlockAvailable(provisioned, allocated, netmaskLength)
+
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		return fmt.Errorf("no /%d block is available in source IPAM Pool (%s): its provisioned CIDRs are exhausted or smaller than the requested netmask length", netmaskLength, sourcePoolID)
+	}
+
+	return nil
+}
+
+// findIPAMPoolProvisionedAndAllocatedCIDRs returns the pool's provisioned CIDRs and the CIDRs allocated from them.
+func findIPAMPoolProvisionedAndAllocatedCIDRs(ctx context.Context, conn *ec2.EC2, poolID string) ([]string, []string, error) {
+	cidrs, err := FindIPAMPoolCIDRs(ctx, conn, &ec2.GetIpamPoolCidrsInput{
+		IpamPoolId: aws.String(poolID),
+	})
+
+	if err != nil {
+		return nil, nil, fmt.Errorf("reading IPAM Pool (%s) CIDRs: %w", poolID, err)
+	}
+
+	allocations, err := FindIPAMPoolAllocations(ctx, conn, &ec2.GetIpamPoolAllocationsInput{
+		IpamPoolId: aws.String(poolID),
+	})
+
+	if err != nil {
+		return nil, nil, fmt.Errorf("reading IPAM Pool (%s) allocations: %w", poolID, err)
+	}
+
+	var provisioned, allocated []string
+
+	for _, v := range cidrs {
+		if aws.StringValue(v.State) == ec2.IpamPoolCidrStateProvisioned {
+			provisioned = append(provisioned, aws.StringValue(v.Cidr))
+		}
+	}
+
+	for _, v := range allocations {
+		allocated = append(allocated, aws.StringValue(v.Cidr))
+	}
+
+	return provisioned, allocated, nil
+}
+
+// ipamPoolCIDRBlockAvailable returns whether an aligned block of the specified netmask length
+// lies within the provisioned CIDRs without overlapping any of the allocated CIDRs.
+func ipamPoolCIDRBlockAvailable(provisioned, allocated []string, netmaskLength int) (bool, error) {
+	count, err := ipamPoolCIDRBlockCount(provisioned, allocated, netmaskLength)
+
+	if err != nil {
+		return false, err
+	}
+
+	return count.Sign() > 0, nil
+}
+
+// ipamPoolCIDRBlockCount returns the number of aligned blocks of the specified netmask length
+// that lie within the provisioned CIDRs without overlapping any of the allocated CIDRs.
+// The count is unbounded as IPv6 pools can hold more blocks than fit in an int64.
+func ipamPoolCIDRBlockCount(provisioned, allocated []string, netmaskLength int) (*big.Int, error) {
+	var allocatedPrefixes []netip.Prefix
+
+	for _, v := range allocated {
+		prefix, err := netip.ParsePrefix(v)
+
+		if err != nil {
+			return nil, fmt.Errorf("parsing allocated CIDR (%s): %w", v, err)
+		}
+
+		allocatedPrefixes = append(allocatedPrefixes, prefix.Masked())
+	}
+
+	count := new(big.Int)
+
+	for _, v := range provisioned {
+		prefix, err := netip.ParsePrefix(v)
+
+		if err != nil {
+			return nil, fmt.Errorf("parsing provisioned CIDR (%s): %w", v, err)
+		}
+
+		count.Add(count, ipamPrefixFreeBlockCount(prefix.Masked(), allocatedPrefixes, netmaskLength))
+	}
+
+	return count, nil
+}
+
+func ipamPrefixFreeBlockCount(block netip.Prefix, allocated []netip.Prefix, netmaskLength int) *big.Int {
+	if block.Bits() > netmaskLength || netmaskLength > block.Addr().BitLen() {
+		return new(big.Int)
+	}
+
+	var overlapping []netip.Prefix
+
+	for _, v := range allocated {
+		if !v.Overlaps(block) {
+			continue
+		}
+
+		// The allocation covers the whole block.
+		if v.Bits() <= block.Bits() {
+			return new(big.Int)
+		}
+
+		overlapping = append(overlapping, v)
+	}
+
+	// A free block holds 2^(netmaskLength - bits) blocks of the requested size.
+	if len(overlapping) == 0 {
+		return new(big.Int).Lsh(big.NewInt(1), uint(netmaskLength-block.Bits()))
+	}
+
+	if block.Bits() == netmaskLength {
+		return new(big.Int)
+	}
+
+	lower, upper := ipamPrefixHalves(block)
+
+	return new(big.Int).Add(
+		ipamPrefixFreeBlockCount(lower, overlapping, netmaskLength),
+		ipamPrefixFreeBlockCount(upper, overlapping, netmaskLength),
+	)
+}
+
+// ipamPrefixHalves splits a masked prefix into its two halves.
+func ipamPrefixHalves(block netip.Prefix) (netip.Prefix, netip.Prefix) {
+	bits := block.Bits()
+	b := block.Addr().AsSlice()
+	b[bits/8] |= 0x80 >> (bits % 8)
+	upper, _ := netip.AddrFromSlice(b)
+
+	return netip.PrefixFrom(block.Addr(), bits+1), netip.PrefixFrom(upper, bits+1)
+}
+
 func expandIPAMCIDRAuthorizationContext(tfMap map[string]interface{}) *ec2.IpamCidrAuthorizationContext {
 	if tfMap == nil {
 		return nil
@@ -4,7 +4,9 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"net"
 	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/ec2"
@@ -27,7 +29,12 @@ func ResourceIPAMPoolCIDRAllocation() *schema.Resource {
 		DeleteWithoutTimeout: resourceIPAMPoolCIDRAllocationDelete,
 
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: resourceIPAMPoolCIDRAllocationImport,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			// Allocations release are eventually consistent with a max time of 20m.
+			Delete: schema.DefaultTimeout(20 * time.Minute),
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -73,6 +80,7 @@ func ResourceIPAMPoolCIDRAllocation() *schema.Resource {
 				Type:          schema.TypeInt,
 				Optional:      true,
 				ForceNew:      true,
+				Computed:      true,
 				ValidateFunc:  validation.IntBetween(0, 32),
 				ConflictsWith: []string{"cidr"},
 			},
@@ -115,6 +123,16 @@ func resourceIPAMPoolCIDRAllocationCreate(ctx context.Context, d *schema.Resourc
 	}
 
 	if v := d.Get("netmask_length"); v != 0 {
+		pool, err := FindIPAMPoolByID(ctx, conn, ipamPoolID)
+
+		if err != nil {
+			return sdkdiag.AppendErrorf(diags, "reading IPAM Pool (%s): %s", ipamPoolID, err)
+		}
+
+		if err := validateIPAMPoolAllocationNetmaskLength(pool, v.(int)); err != nil {
+			return sdkdiag.AppendErrorf(diags, "creating IPAM Pool CIDR Allocation: %s", err)
+		}
+
 		input.NetmaskLength = aws.Int64(int64(v.(int)))
 	}
 
@@ -155,8 +173,13 @@ func resourceIPAMPoolCIDRAllocationRead(ctx context.Context, d *schema.ResourceD
 	}
 
 	d.Set("cidr", allocation.Cidr)
+	d.Set("description", allocation.Description)
 	d.Set("ipam_pool_allocation_id", allocation.IpamPoolAllocationId)
 	d.Set("ipam_pool_id", poolID)
+	if _, ipNet, err := net.ParseCIDR(aws.StringValue(allocation.Cidr)); err == nil {
+		netmaskLength, _ := ipNet.Mask.Size()
+		d.Set("netmask_length", netmaskLength)
+	}
 	d.Set("resource_id", allocation.ResourceId)
 	d.Set("resource_owner", allocation.ResourceOwner)
 	d.Set("resource_type", allocation.ResourceType)
@@ -174,6 +197,10 @@ func resourceIPAMPoolCIDRAllocationDelete(ctx context.Context, d *schema.Resourc
 		return sdkdiag.AppendErrorf(diags, "deleting IPAM Pool CIDR Allocation (%s): %s", d.Id(), err)
 	}
 
+	if err := validateIPAMPoolAllocationRelease(d.Get("resource_type").(string), d.Get("resource_id").(string)); err != nil {
+		return sdkdiag.AppendErrorf(diags, "deleting IPAM Pool CIDR Allocation (%s): %s", d.Id(), err)
+	}
+
 	log.Printf("[DEBUG] Deleting IPAM Pool CIDR Allocation: %s", d.Id())
 	_, err = conn.ReleaseIpamPoolAllocationWithContext(ctx, &ec2.ReleaseIpamPoolAllocationInput{
 		Cidr:                 aws.String(d.Get("cidr").(string)),
@@ -189,10 +216,60 @@ func resourceIPAMPoolCIDRAllocationDelete(ctx context.Context, d *schema.Resourc
 		return sdkdiag.AppendErrorf(diags, "deleting IPAM Pool CIDR Allocation (%s): %s", d.Id(), err)
 	}
 
+	if _, err := WaitIPAMPoolCIDRAllocationDeleted(ctx, conn, allocationID, poolID, d.Timeout(schema.TimeoutDelete)); err != nil {
+		return sdkdiag.AppendErrorf(diags, "waiting for IPAM Pool CIDR Allocation (%s) delete: %s", d.Id(), err)
+	}
+
 	return diags
 }
 
-const ipamPoolCIDRAllocationIDSeparator = "_"
+// validateIPAMPoolAllocationRelease returns an error if an allocation of the specified resource type can't be released.
+// Only custom allocations can be released. Others, e.g. those of VPCs, are released with the resource that consumes them.
+func validateIPAMPoolAllocationRelease(resourceType, resourceID string) error {
+	if resourceType == "" || resourceType == ec2.IpamPoolAllocationResourceTypeCustom {
+		return nil
+	}
+
+	return fmt.Errorf("allocation is managed by %s (%s) and can't be released directly; delete the %[1]s to release it or remove the allocation from the Terraform state", resourceType, resourceID)
+}
+
+// validateIPAMPoolAllocationNetmaskLength returns an error if the netmask length is outside the pool's allocation netmask length range.
+func validateIPAMPoolAllocationNetmaskLength(pool *ec2.IpamPool, netmaskLength int) error {
+	poolID := aws.StringValue(pool.IpamPoolId)
+
+	if v := pool.AllocationMinNetmaskLength; v != nil && int64(netmaskLength) < aws.Int64Value(v) {
+		return fmt.Errorf("netmask_length (%d) is less than the IPAM Pool (%s) allocation_min_netmask_length (%d)", netmaskLength, poolID, aws.Int64Value(v))
+	}
+
+	if v := pool.AllocationMaxNetmaskLength; v != nil && int64(netmaskLength) > aws.Int64Value(v) {
+		return fmt.Errorf("netmask_length (%d) is greater than the IPAM Pool (%s) allocation_max_netmask_length (%d)", netmaskLength, poolID, aws.Int64Value(v))
+	}
+
+	return nil
+}
+
+func resourceIPAMPoolCIDRAllocationImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	d.SetId(ipamPoolCIDRAllocationImportID(d.Id()))
+
+	return []*schema.ResourceData{d}, nil
+}
+
+const (
+	ipamPoolCIDRAllocationIDSeparator = "_"
+	ipamPoolAllocationIDPrefix        = "ipam-pool-alloc-"
+)
+
+// ipamPoolCIDRAllocationImportID returns the resource ID for an import ID of either
+// allocation-id_pool-id or pool-id_allocation-id.
+func ipamPoolCIDRAllocationImportID(id string) string {
+	parts := strings.Split(id, ipamPoolCIDRAllocationIDSeparator)
+
+	if len(parts) == 2 && !strings.HasPrefix(parts[0], ipamPoolAllocationIDPrefix) && strings.HasPrefix(parts[1], ipamPoolAllocationIDPrefix) {
+		return IPAMPoolCIDRAllocationCreateResourceID(parts[1], parts[0])
+	}
+
+	return id
+}
 
 func IPAMPoolCIDRAllocationCreateResourceID(allocationID, poolID string) string {
 	parts := []string{allocationID, poolID}
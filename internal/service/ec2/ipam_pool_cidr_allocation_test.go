@@ -6,8 +6,11 @@ import (
 	"regexp"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -18,6 +21,211 @@ import (
 	"github.com/hashicorp/terraform-provider-aws/internal/tfresource"
 )
 
+func TestWaitIPAMPoolCIDRAllocationDeleted(t *testing.T) {
+	ctx := context.Background()
+	t.Parallel()
+
+	allocationID := "ipam-pool-alloc-12345678"
+	poolID := "ipam-pool-12345678"
+
+	sess, err := session.NewSession(nil)
+	if err != nil {
+		t.Fatalf("Error creating AWS session: %s", err)
+	}
+	conn := ec2.New(sess)
+	conn.Handlers.Clear()
+
+	// The allocation is still reported once after release.
+	calls := 0
+	conn.Handlers.Send.PushBack(func(r *request.Request) {
+		calls++
+		data := r.Data.(*ec2.GetIpamPoolAllocationsOutput)
+		if calls == 1 {
+			data.IpamPoolAllocations = []*ec2.IpamPoolAllocation{{
+				Cidr:                 aws.String("172.2.0.0/28"),
+				IpamPoolAllocationId: aws.String(allocationID),
+			}}
+		}
+	})
+
+	_, err = tfec2.WaitIPAMPoolCIDRAllocationDeleted(ctx, conn, allocationID, poolID, 1*time.Minute)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if calls != 2 {
+		t.Errorf("got %d GetIpamPoolAllocations calls, expected 2", calls)
+	}
+}
+
+func TestIPAMPoolCIDRAllocationImportID(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		Name     string
+		ID       string
+		Expected string
+	}{
+		{
+			Name:     "allocation id first",
+			ID:       "ipam-pool-alloc-0123456789abcdef0_ipam-pool-0123456789abcdef0",
+			Expected: "ipam-pool-alloc-0123456789abcdef0_ipam-pool-0123456789abcdef0",
+		},
+		{
+			Name:     "pool id first",
+			ID:       "ipam-pool-0123456789abcdef0_ipam-pool-alloc-0123456789abcdef0",
+			Expected: "ipam-pool-alloc-0123456789abcdef0_ipam-pool-0123456789abcdef0",
+		},
+		{
+			Name:     "invalid",
+			ID:       "ipam-pool-0123456789abcdef0",
+			Expected: "ipam-pool-0123456789abcdef0",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.Name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := tfec2.IPAMPoolCIDRAllocationImportID(testCase.ID); got != testCase.Expected {
+				t.Errorf("got %q, expected %q", got, testCase.Expected)
+			}
+		})
+	}
+}
+
+func TestValidateIPAMPoolAllocationRelease(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		Name          string
+		ResourceType  string
+		ResourceID    string
+		ExpectedError *regexp.Regexp
+	}{
+		{
+			Name:         "custom",
+			ResourceType: ec2.IpamPoolAllocationResourceTypeCustom,
+		},
+		{
+			Name: "unknown",
+		},
+		{
+			Name:          "vpc",
+			ResourceType:  ec2.IpamPoolAllocationResourceTypeVpc,
+			ResourceID:    "vpc-0123456789abcdef0",
+			ExpectedError: regexp.MustCompile(`allocation is managed by vpc \(vpc-0123456789abcdef0\) and can't be released directly; delete the vpc to release it`),
+		},
+		{
+			Name:          "ipam-pool",
+			ResourceType:  ec2.IpamPoolAllocationResourceTypeIpamPool,
+			ResourceID:    "ipam-pool-0123456789abcdef0",
+			ExpectedError: regexp.MustCompile(`allocation is managed by ipam-pool \(ipam-pool-0123456789abcdef0\)`),
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.Name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tfec2.ValidateIPAMPoolAllocationRelease(testCase.ResourceType, testCase.ResourceID)
+
+			if testCase.ExpectedError == nil {
+				if err != nil {
+					t.Errorf("unexpected error: %s", err)
+				}
+
+				return
+			}
+
+			if err == nil || !testCase.ExpectedError.MatchString(err.Error()) {
+				t.Errorf("expected error %s, got: %v", testCase.ExpectedError, err)
+			}
+		})
+	}
+}
+
+func TestValidateIPAMPoolAllocationNetmaskLength(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		Name          string
+		Pool          *ec2.IpamPool
+		NetmaskLength int
+		ExpectedError *regexp.Regexp
+	}{
+		{
+			Name:          "no range",
+			Pool:          &ec2.IpamPool{IpamPoolId: aws.String("ipam-pool-12345678")},
+			NetmaskLength: 28,
+		},
+		{
+			Name: "within range",
+			Pool: &ec2.IpamPool{
+				AllocationMaxNetmaskLength: aws.Int64(28),
+				AllocationMinNetmaskLength: aws.Int64(24),
+				IpamPoolId:                 aws.String("ipam-pool-12345678"),
+			},
+			NetmaskLength: 26,
+		},
+		{
+			Name: "at bounds",
+			Pool: &ec2.IpamPool{
+				AllocationMaxNetmaskLength: aws.Int64(24),
+				AllocationMinNetmaskLength: aws.Int64(24),
+				IpamPoolId:                 aws.String("ipam-pool-12345678"),
+			},
+			NetmaskLength: 24,
+		},
+		{
+			Name: "below min",
+			Pool: &ec2.IpamPool{
+				AllocationMaxNetmaskLength: aws.Int64(28),
+				AllocationMinNetmaskLength: aws.Int64(24),
+				IpamPoolId:                 aws.String("ipam-pool-12345678"),
+			},
+			NetmaskLength: 20,
+			ExpectedError: regexp.MustCompile(`netmask_length \(20\) is less than the IPAM Pool \(ipam-pool-12345678\) allocation_min_netmask_length \(24\)`),
+		},
+		{
+			Name: "above max",
+			Pool: &ec2.IpamPool{
+				AllocationMaxNetmaskLength: aws.Int64(28),
+				AllocationMinNetmaskLength: aws.Int64(24),
+				IpamPoolId:                 aws.String("ipam-pool-12345678"),
+			},
+			NetmaskLength: 30,
+			ExpectedError: regexp.MustCompile(`netmask_length \(30\) is greater than the IPAM Pool \(ipam-pool-12345678\) allocation_max_netmask_length \(28\)`),
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.Name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tfec2.ValidateIPAMPoolAllocationNetmaskLength(testCase.Pool, testCase.NetmaskLength)
+
+			if err == nil && testCase.ExpectedError != nil {
+				t.Fatalf("expected error %s, got no error", testCase.ExpectedError.String())
+			}
+
+			if err != nil && testCase.ExpectedError == nil {
+				t.Fatalf("got unexpected error: %s", err)
+			}
+
+			if err != nil && !testCase.ExpectedError.MatchString(err.Error()) {
+				t.Fatalf("expected error %s, got: %s", testCase.ExpectedError.String(), err)
+			}
+		})
+	}
+}
+
 func TestAccIPAMPoolCIDRAllocation_ipv4Basic(t *testing.T) {
 	ctx := acctest.Context(t)
 	var allocation ec2.IpamPoolAllocation
@@ -38,6 +246,10 @@ func TestAccIPAMPoolCIDRAllocation_ipv4Basic(t *testing.T) {
 					resource.TestMatchResourceAttr(resourceName, "id", regexp.MustCompile(`^ipam-pool-alloc-[\da-f]+_ipam-pool(-[\da-f]+)$`)),
 					resource.TestMatchResourceAttr(resourceName, "ipam_pool_allocation_id", regexp.MustCompile(`^ipam-pool-alloc-[\da-f]+$`)),
 					resource.TestCheckResourceAttrPair(resourceName, "ipam_pool_id", "aws_vpc_ipam_pool.test", "id"),
+					resource.TestCheckResourceAttr(resourceName, "netmask_length", "28"),
+					resource.TestCheckResourceAttr(resourceName, "resource_id", ""),
+					acctest.CheckResourceAttrAccountID(resourceName, "resource_owner"),
+					resource.TestCheckResourceAttr(resourceName, "resource_type", "custom"),
 				),
 			},
 			{
@@ -45,6 +257,72 @@ func TestAccIPAMPoolCIDRAllocation_ipv4Basic(t *testing.T) {
 				ImportState:       true,
 				ImportStateVerify: true,
 			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateIdFunc: testAccIPAMPoolCIDRAllocationPoolFirstImportStateIdFunc(resourceName),
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccIPAMPoolCIDRAllocation_importVPC(t *testing.T) {
+	ctx := acctest.Context(t)
+	resourceName := "aws_vpc_ipam_pool_cidr_allocation.test"
+	vpcResourceName := "aws_vpc.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ErrorCheck:               acctest.ErrorCheck(t, ec2.EndpointsID),
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckIPAMPoolAllocationDestroy(ctx),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccIPAMPoolCIDRAllocationConfig_vpc,
+			},
+			{
+				Config:            testAccIPAMPoolCIDRAllocationConfig_vpcImport,
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateIdFunc: testAccIPAMPoolCIDRAllocationVPCImportStateIdFunc(ctx, vpcResourceName),
+				ImportStateCheck: func(s []*terraform.InstanceState) error {
+					if len(s) != 1 {
+						return fmt.Errorf("expected 1 state, got %d", len(s))
+					}
+
+					if got, want := s[0].Attributes["resource_type"], ec2.IpamPoolAllocationResourceTypeVpc; got != want {
+						return fmt.Errorf("resource_type: got %q, expected %q", got, want)
+					}
+
+					if got, want := s[0].Attributes["netmask_length"], "28"; got != want {
+						return fmt.Errorf("netmask_length: got %q, expected %q", got, want)
+					}
+
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func TestAccIPAMPoolCIDRAllocation_netmaskLengthOutOfRange(t *testing.T) {
+	ctx := acctest.Context(t)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ErrorCheck:               acctest.ErrorCheck(t, ec2.EndpointsID),
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckIPAMPoolAllocationDestroy(ctx),
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccIPAMPoolCIDRAllocationConfig_netmaskLengthRange(25),
+				ExpectError: regexp.MustCompile(`is less than the IPAM Pool \(ipam-pool-[a-z0-9]+\) allocation_min_netmask_length \(26\)`),
+			},
+			{
+				Config:      testAccIPAMPoolCIDRAllocationConfig_netmaskLengthRange(29),
+				ExpectError: regexp.MustCompile(`is greater than the IPAM Pool \(ipam-pool-[a-z0-9]+\) allocation_max_netmask_length \(28\)`),
+			},
 		},
 	})
 }
@@ -93,10 +371,9 @@ func TestAccIPAMPoolCIDRAllocation_ipv4BasicNetmask(t *testing.T) {
 				),
 			},
 			{
-				ResourceName:            resourceName,
-				ImportState:             true,
-				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"netmask_length"},
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
 			},
 		},
 	})
@@ -220,6 +497,45 @@ func testAccCheckIPAMCIDRPrefix(allocation *ec2.IpamPoolAllocation, expected str
 	}
 }
 
+func testAccIPAMPoolCIDRAllocationPoolFirstImportStateIdFunc(n string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return "", fmt.Errorf("Not found: %s", n)
+		}
+
+		return fmt.Sprintf("%s_%s", rs.Primary.Attributes["ipam_pool_id"], rs.Primary.Attributes["ipam_pool_allocation_id"]), nil
+	}
+}
+
+func testAccIPAMPoolCIDRAllocationVPCImportStateIdFunc(ctx context.Context, n string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return "", fmt.Errorf("Not found: %s", n)
+		}
+
+		conn := acctest.Provider.Meta().(*conns.AWSClient).EC2Conn()
+		poolID := rs.Primary.Attributes["ipv4_ipam_pool_id"]
+
+		allocations, err := tfec2.FindIPAMPoolAllocations(ctx, conn, &ec2.GetIpamPoolAllocationsInput{
+			IpamPoolId: aws.String(poolID),
+		})
+
+		if err != nil {
+			return "", err
+		}
+
+		for _, allocation := range allocations {
+			if aws.StringValue(allocation.ResourceId) == rs.Primary.ID {
+				return fmt.Sprintf("%s_%s", poolID, aws.StringValue(allocation.IpamPoolAllocationId)), nil
+			}
+		}
+
+		return "", fmt.Errorf("IPAM Pool (%s) allocation for VPC (%s) not found", poolID, rs.Primary.ID)
+	}
+}
+
 func testAccCheckIPAMPoolCIDRAllocationExists(ctx context.Context, n string, v *ec2.IpamPoolAllocation) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[n]
@@ -361,6 +677,40 @@ resource "aws_vpc_ipam_pool_cidr_allocation" "test" {
 `, netmask))
 }
 
+func testAccIPAMPoolCIDRAllocationConfig_netmaskLengthRange(netmaskLength int) string {
+	return fmt.Sprintf(`
+data "aws_region" "current" {}
+
+resource "aws_vpc_ipam" "test" {
+  operating_regions {
+    region_name = data.aws_region.current.name
+  }
+}
+
+resource "aws_vpc_ipam_pool" "test" {
+  address_family                = "ipv4"
+  ipam_scope_id                 = aws_vpc_ipam.test.private_default_scope_id
+  locale                        = data.aws_region.current.name
+  allocation_min_netmask_length = 26
+  allocation_max_netmask_length = 28
+}
+
+resource "aws_vpc_ipam_pool_cidr" "test" {
+  ipam_pool_id = aws_vpc_ipam_pool.test.id
+  cidr         = "172.2.0.0/24"
+}
+
+resource "aws_vpc_ipam_pool_cidr_allocation" "test" {
+  ipam_pool_id   = aws_vpc_ipam_pool.test.id
+  netmask_length = %[1]d
+
+  depends_on = [
+    aws_vpc_ipam_pool_cidr.test
+  ]
+}
+`, netmaskLength)
+}
+
 func testAccIPAMPoolCIDRAllocationConfig_ipv4Disallowed(netmaskLength, disallowedCidr string) string {
 	return acctest.ConfigCompose(testAccIPAMPoolCIDRAllocationConfig_base, fmt.Sprintf(`
 resource "aws_vpc_ipam_pool_cidr_allocation" "test" {
@@ -438,3 +788,21 @@ resource "aws_vpc_ipam_pool_cidr_allocation" "test" {
 }
 `, cidr, acctest.Region(), acctest.AlternateRegion()))
 }
+
+var testAccIPAMPoolCIDRAllocationConfig_vpc = acctest.ConfigCompose(testAccIPAMPoolCIDRAllocationConfig_base, `
+resource "aws_vpc" "test" {
+  ipv4_ipam_pool_id   = aws_vpc_ipam_pool.test.id
+  ipv4_netmask_length = 28
+
+  depends_on = [
+    aws_vpc_ipam_pool_cidr.test
+  ]
+}
+`)
+
+var testAccIPAMPoolCIDRAllocationConfig_vpcImport = acctest.ConfigCompose(testAccIPAMPoolCIDRAllocationConfig_vpc, `
+resource "aws_vpc_ipam_pool_cidr_allocation" "test" {
+  ipam_pool_id   = aws_vpc_ipam_pool.test.id
+  netmask_length = 28
+}
+`)
@@ -3,10 +3,19 @@ package ec2_test
 import (
 	"context"
 	"fmt"
+	"os"
+	"reflect"
+	"regexp"
 	"testing"
+	"time"
 
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/hashicorp/terraform-provider-aws/internal/acctest"
 	"github.com/hashicorp/terraform-provider-aws/internal/conns"
@@ -14,6 +23,1021 @@ import (
 	"github.com/hashicorp/terraform-provider-aws/internal/tfresource"
 )
 
+func TestExpandProvisionIPAMPoolCIDRInput(t *testing.T) {
+	t.Parallel()
+
+	raw := map[string]interface{}{
+		"cidr":         "192.0.2.0/24",
+		"ipam_pool_id": "ipam-pool-12345678",
+		"cidr_authorization_context": []interface{}{
+			map[string]interface{}{
+				"message":   "1|aws|123456789012|192.0.2.0/24|20301231|SHA256|RSAPSS",
+				"signature": "signature",
+			},
+		},
+	}
+	d := schema.TestResourceDataRaw(t, tfec2.ResourceIPAMPoolCIDR().Schema, raw)
+
+	input := tfec2.ExpandProvisionIPAMPoolCIDRInput(d)
+
+	if got, want := aws.StringValue(input.Cidr), "192.0.2.0/24"; got != want {
+		t.Errorf("got Cidr %q, expected %q", got, want)
+	}
+
+	if got, want := aws.StringValue(input.IpamPoolId), "ipam-pool-12345678"; got != want {
+		t.Errorf("got IpamPoolId %q, expected %q", got, want)
+	}
+
+	if input.CidrAuthorizationContext == nil {
+		t.Fatal("expected CidrAuthorizationContext, got none")
+	}
+
+	if got, want := aws.StringValue(input.CidrAuthorizationContext.Message), "1|aws|123456789012|192.0.2.0/24|20301231|SHA256|RSAPSS"; got != want {
+		t.Errorf("got CidrAuthorizationContext.Message %q, expected %q", got, want)
+	}
+
+	if got, want := aws.StringValue(input.CidrAuthorizationContext.Signature), "signature"; got != want {
+		t.Errorf("got CidrAuthorizationContext.Signature %q, expected %q", got, want)
+	}
+}
+
+func TestProvisionIPAMPoolCIDR(t *testing.T) {
+	ctx := context.Background()
+	t.Parallel()
+
+	sess, err := session.NewSession(nil)
+	if err != nil {
+		t.Fatalf("Error creating AWS session: %s", err)
+	}
+
+	overlapErr := awserr.New("InvalidParameterValue", "The CIDR 10.0.0.0/16 overlaps with an existing CIDR 10.0.0.0/16.", nil)
+
+	testCases := []struct {
+		Name          string
+		Errs          []error
+		Persistent    bool
+		ExpectedCalls int
+		ExpectError   *regexp.Regexp
+	}{
+		{
+			Name:          "success",
+			ExpectedCalls: 1,
+		},
+		{
+			Name:          "transient overlap",
+			Errs:          []error{overlapErr},
+			ExpectedCalls: 2,
+		},
+		{
+			Name:        "persistent overlap",
+			Errs:        []error{overlapErr},
+			Persistent:  true,
+			ExpectError: regexp.MustCompile(`CIDR still overlaps after retrying for 2s`),
+		},
+		{
+			Name:          "other error",
+			Errs:          []error{awserr.New("InvalidParameterValue", "The CIDR 10.0.0.0/33 is invalid.", nil)},
+			ExpectedCalls: 1,
+			ExpectError:   regexp.MustCompile(`is invalid`),
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.Name, func(t *testing.T) {
+			t.Parallel()
+
+			var calls int
+			tokens := make(map[string]bool)
+			conn := ec2.New(sess)
+			conn.Handlers.Clear()
+			conn.Handlers.Send.PushBack(func(r *request.Request) {
+				input := r.Params.(*ec2.ProvisionIpamPoolCidrInput)
+				tokens[aws.StringValue(input.ClientToken)] = true
+				calls++
+
+				if calls <= len(testCase.Errs) {
+					r.Error = testCase.Errs[calls-1]
+					return
+				}
+
+				if testCase.Persistent {
+					r.Error = testCase.Errs[len(testCase.Errs)-1]
+					return
+				}
+
+				r.Data.(*ec2.ProvisionIpamPoolCidrOutput).IpamPoolCidr = &ec2.IpamPoolCidr{
+					Cidr: input.Cidr,
+				}
+			})
+
+			input := &ec2.ProvisionIpamPoolCidrInput{
+				Cidr:       aws.String("10.0.0.0/16"),
+				IpamPoolId: aws.String("ipam-pool-12345678"),
+			}
+
+			output, err := tfec2.ProvisionIPAMPoolCIDR(ctx, conn, input, 2*time.Second)
+
+			if testCase.ExpectError != nil {
+				if err == nil {
+					t.Fatal("expected error, got none")
+				}
+
+				if !testCase.ExpectError.MatchString(err.Error()) {
+					t.Errorf("got error %q, expected to match %q", err, testCase.ExpectError)
+				}
+			} else {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+
+				if got, want := aws.StringValue(output.IpamPoolCidr.Cidr), "10.0.0.0/16"; got != want {
+					t.Errorf("got CIDR %q, expected %q", got, want)
+				}
+			}
+
+			if testCase.ExpectedCalls > 0 && calls != testCase.ExpectedCalls {
+				t.Errorf("got %d ProvisionIpamPoolCidr calls, expected %d", calls, testCase.ExpectedCalls)
+			}
+
+			if got, want := len(tokens), calls; got != want {
+				t.Errorf("got %d distinct client tokens, expected %d", got, want)
+			}
+		})
+	}
+}
+
+func TestFindIPAMPoolCIDRs(t *testing.T) {
+	ctx := context.Background()
+	t.Parallel()
+
+	sess, err := session.NewSession(nil)
+	if err != nil {
+		t.Fatalf("Error creating AWS session: %s", err)
+	}
+
+	pages := map[string]*ec2.GetIpamPoolCidrsOutput{
+		"": {
+			IpamPoolCidrs: []*ec2.IpamPoolCidr{
+				{Cidr: aws.String("10.0.0.0/16")},
+				{Cidr: aws.String("10.1.0.0/16")},
+			},
+			NextToken: aws.String("page2"),
+		},
+		"page2": {
+			IpamPoolCidrs: []*ec2.IpamPoolCidr{
+				{Cidr: aws.String("10.2.0.0/16")},
+				nil,
+			},
+			NextToken: aws.String("page3"),
+		},
+		"page3": {
+			IpamPoolCidrs: []*ec2.IpamPoolCidr{
+				{Cidr: aws.String("10.3.0.0/16")},
+			},
+		},
+	}
+
+	testCases := []struct {
+		Name          string
+		Err           error
+		Expected      []string
+		ExpectedCalls int
+		NotFound      bool
+	}{
+		{
+			Name:          "multiple pages",
+			Expected:      []string{"10.0.0.0/16", "10.1.0.0/16", "10.2.0.0/16", "10.3.0.0/16"},
+			ExpectedCalls: 3,
+		},
+		{
+			Name:          "pool not found",
+			Err:           awserr.New("InvalidIpamPoolId.NotFound", "The pool ID 'ipam-pool-12345678' does not exist", nil),
+			ExpectedCalls: 1,
+			NotFound:      true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.Name, func(t *testing.T) {
+			t.Parallel()
+
+			var calls int
+			conn := ec2.New(sess)
+			conn.Handlers.Clear()
+			conn.Handlers.Send.PushBack(func(r *request.Request) {
+				input := r.Params.(*ec2.GetIpamPoolCidrsInput)
+				calls++
+
+				if testCase.Err != nil {
+					r.Error = testCase.Err
+					return
+				}
+
+				page, ok := pages[aws.StringValue(input.NextToken)]
+				if !ok {
+					r.Error = awserr.New("InvalidParameterValue", "invalid next token", nil)
+					return
+				}
+
+				*r.Data.(*ec2.GetIpamPoolCidrsOutput) = *page
+			})
+
+			output, err := tfec2.FindIPAMPoolCIDRs(ctx, conn, &ec2.GetIpamPoolCidrsInput{
+				IpamPoolId: aws.String("ipam-pool-12345678"),
+			})
+
+			if calls != testCase.ExpectedCalls {
+				t.Errorf("got %d GetIpamPoolCidrs calls, expected %d", calls, testCase.ExpectedCalls)
+			}
+
+			if testCase.NotFound {
+				if !tfresource.NotFound(err) {
+					t.Fatalf("got error %v, expected not found", err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			var got []string
+			for _, v := range output {
+				got = append(got, aws.StringValue(v.Cidr))
+			}
+
+			if !reflect.DeepEqual(got, testCase.Expected) {
+				t.Errorf("got CIDRs %v, expected %v", got, testCase.Expected)
+			}
+		})
+	}
+}
+
+func TestFindIPAMPoolCIDRAllocations(t *testing.T) {
+	ctx := context.Background()
+	t.Parallel()
+
+	sess, err := session.NewSession(nil)
+	if err != nil {
+		t.Fatalf("Error creating AWS session: %s", err)
+	}
+
+	allocations := map[string][]string{
+		"ipam-pool-11111111": nil,
+		"ipam-pool-22222222": {"10.0.0.0/26", "10.0.0.64/26", "10.0.1.0/24", "10.1.0.0/16", ""},
+	}
+
+	conn := ec2.New(sess)
+	conn.Handlers.Clear()
+	conn.Handlers.Send.PushBack(func(r *request.Request) {
+		input := r.Params.(*ec2.GetIpamPoolAllocationsInput)
+		data := r.Data.(*ec2.GetIpamPoolAllocationsOutput)
+
+		cidrs, ok := allocations[aws.StringValue(input.IpamPoolId)]
+		if !ok {
+			r.Error = awserr.New("InvalidIpamPoolId.NotFound", "not found", nil)
+			return
+		}
+
+		for i, v := range cidrs {
+			data.IpamPoolAllocations = append(data.IpamPoolAllocations, &ec2.IpamPoolAllocation{
+				Cidr:                 aws.String(v),
+				IpamPoolAllocationId: aws.String(fmt.Sprintf("ipam-pool-alloc-%d", i)),
+			})
+		}
+	})
+
+	testCases := []struct {
+		Name          string
+		CIDR          string
+		PoolID        string
+		Expected      int
+		ExpectedError bool
+	}{
+		{
+			Name:   "no allocations",
+			CIDR:   "10.0.0.0/24",
+			PoolID: "ipam-pool-11111111",
+		},
+		{
+			Name:     "allocations within the CIDR",
+			CIDR:     "10.0.0.0/24",
+			PoolID:   "ipam-pool-22222222",
+			Expected: 2,
+		},
+		{
+			Name:     "allocations across CIDRs",
+			CIDR:     "10.0.0.0/23",
+			PoolID:   "ipam-pool-22222222",
+			Expected: 3,
+		},
+		{
+			Name:   "pool not found",
+			CIDR:   "10.0.0.0/24",
+			PoolID: "ipam-pool-33333333",
+		},
+		{
+			Name:          "invalid CIDR",
+			CIDR:          "10.0.0.0",
+			PoolID:        "ipam-pool-22222222",
+			ExpectedError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.Name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := tfec2.FindIPAMPoolCIDRAllocations(ctx, conn, testCase.CIDR, testCase.PoolID)
+
+			if gotErr := err != nil; gotErr != testCase.ExpectedError {
+				t.Fatalf("got error %v, expected error %t", err, testCase.ExpectedError)
+			}
+
+			if len(got) != testCase.Expected {
+				t.Errorf("got %d allocations, expected %d", len(got), testCase.Expected)
+			}
+		})
+	}
+}
+
+func TestResourceIPAMPoolCIDR_cidrAuthorizationContextValidation(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		Name          string
+		Context       map[string]interface{}
+		ExpectedError bool
+	}{
+		{
+			Name:    "message and signature",
+			Context: map[string]interface{}{"message": "message", "signature": "signature"},
+		},
+		{
+			Name:          "message only",
+			Context:       map[string]interface{}{"message": "message"},
+			ExpectedError: true,
+		},
+		{
+			Name:          "signature only",
+			Context:       map[string]interface{}{"signature": "signature"},
+			ExpectedError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.Name, func(t *testing.T) {
+			t.Parallel()
+
+			diags := tfec2.ResourceIPAMPoolCIDR().Validate(terraform.NewResourceConfigRaw(map[string]interface{}{
+				"ipam_pool_id":               "ipam-pool-12345678",
+				"cidr_authorization_context": []interface{}{testCase.Context},
+			}))
+
+			if got := diags.HasError(); got != testCase.ExpectedError {
+				t.Errorf("got error %t, expected %t: %v", got, testCase.ExpectedError, diags)
+			}
+		})
+	}
+}
+
+func TestWaitIPAMPoolCIDRCreated(t *testing.T) {
+	ctx := context.Background()
+	t.Parallel()
+
+	cidrBlock, poolID := "10.0.0.0/24", "ipam-pool-12345678"
+
+	sess, err := session.NewSession(nil)
+	if err != nil {
+		t.Fatalf("Error creating AWS session: %s", err)
+	}
+
+	testCases := []struct {
+		Name          string
+		States        []string
+		ExpectError   *regexp.Regexp
+		ExpectedCalls int
+	}{
+		{
+			Name:          "provisioned",
+			States:        []string{ec2.IpamPoolCidrStatePendingProvision, ec2.IpamPoolCidrStatePendingProvision, ec2.IpamPoolCidrStateProvisioned},
+			ExpectedCalls: 3,
+		},
+		{
+			Name:          "not yet visible",
+			States:        []string{"", ec2.IpamPoolCidrStatePendingProvision, ec2.IpamPoolCidrStateProvisioned},
+			ExpectedCalls: 3,
+		},
+		{
+			Name:          "failed provision",
+			States:        []string{ec2.IpamPoolCidrStatePendingProvision, ec2.IpamPoolCidrStateFailedProvision, ec2.IpamPoolCidrStateProvisioned},
+			ExpectError:   regexp.MustCompile(`unexpected state 'failed-provision'.*cidr-not-available: The CIDR is not available\.`),
+			ExpectedCalls: 2,
+		},
+		{
+			Name:          "deprovisioned",
+			States:        []string{ec2.IpamPoolCidrStatePendingProvision, ec2.IpamPoolCidrStatePendingDeprovision},
+			ExpectError:   regexp.MustCompile(`unexpected state 'pending-deprovision'`),
+			ExpectedCalls: 2,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.Name, func(t *testing.T) {
+			t.Parallel()
+
+			calls := 0
+			conn := ec2.New(sess)
+			conn.Handlers.Clear()
+			conn.Handlers.Send.PushBack(func(r *request.Request) {
+				r.Data.(*ec2.GetIpamPoolCidrsOutput).IpamPoolCidrs = testIPAMPoolCIDRStates(cidrBlock, testCase.States, calls)
+				calls++
+			})
+
+			_, err := tfec2.WaitIPAMPoolCIDRCreated(ctx, conn, cidrBlock, poolID, 1*time.Minute)
+
+			if testCase.ExpectError == nil && err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if testCase.ExpectError != nil && (err == nil || !testCase.ExpectError.MatchString(err.Error())) {
+				t.Fatalf("got error %v, expected to match %q", err, testCase.ExpectError)
+			}
+
+			if calls != testCase.ExpectedCalls {
+				t.Errorf("got %d GetIpamPoolCidrs calls, expected %d", calls, testCase.ExpectedCalls)
+			}
+		})
+	}
+}
+
+func TestWaitIPAMPoolCIDRDeleted(t *testing.T) {
+	ctx := context.Background()
+	t.Parallel()
+
+	cidrBlock, poolID := "10.0.0.0/24", "ipam-pool-12345678"
+
+	sess, err := session.NewSession(nil)
+	if err != nil {
+		t.Fatalf("Error creating AWS session: %s", err)
+	}
+
+	testCases := []struct {
+		Name          string
+		States        []string
+		ExpectError   *regexp.Regexp
+		ExpectedCalls int
+	}{
+		{
+			Name:          "deprovisioned",
+			States:        []string{ec2.IpamPoolCidrStateProvisioned, ec2.IpamPoolCidrStatePendingDeprovision, ec2.IpamPoolCidrStateDeprovisioned},
+			ExpectedCalls: 3,
+		},
+		{
+			Name:          "no longer reported",
+			States:        []string{ec2.IpamPoolCidrStatePendingDeprovision, ""},
+			ExpectedCalls: 2,
+		},
+		{
+			Name:          "failed deprovision",
+			States:        []string{ec2.IpamPoolCidrStatePendingDeprovision, ec2.IpamPoolCidrStateFailedDeprovision, ec2.IpamPoolCidrStateDeprovisioned},
+			ExpectError:   regexp.MustCompile(`unexpected state 'failed-deprovision'.*cidr-not-available: The CIDR is not available\.`),
+			ExpectedCalls: 2,
+		},
+		{
+			Name:          "failed provision",
+			States:        []string{ec2.IpamPoolCidrStateFailedProvision},
+			ExpectedCalls: 1,
+		},
+		{
+			Name:          "failed import",
+			States:        []string{ec2.IpamPoolCidrStateFailedImport},
+			ExpectedCalls: 1,
+		},
+		{
+			Name:          "pending provision",
+			States:        []string{ec2.IpamPoolCidrStatePendingProvision},
+			ExpectError:   regexp.MustCompile(`unexpected state 'pending-provision'`),
+			ExpectedCalls: 1,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.Name, func(t *testing.T) {
+			t.Parallel()
+
+			calls := 0
+			conn := ec2.New(sess)
+			conn.Handlers.Clear()
+			conn.Handlers.Send.PushBack(func(r *request.Request) {
+				r.Data.(*ec2.GetIpamPoolCidrsOutput).IpamPoolCidrs = testIPAMPoolCIDRStates(cidrBlock, testCase.States, calls)
+				calls++
+			})
+
+			_, err := tfec2.WaitIPAMPoolCIDRDeleted(ctx, conn, cidrBlock, poolID, 1*time.Minute)
+
+			if testCase.ExpectError == nil && err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if testCase.ExpectError != nil && (err == nil || !testCase.ExpectError.MatchString(err.Error())) {
+				t.Fatalf("got error %v, expected to match %q", err, testCase.ExpectError)
+			}
+
+			if calls != testCase.ExpectedCalls {
+				t.Errorf("got %d GetIpamPoolCidrs calls, expected %d", calls, testCase.ExpectedCalls)
+			}
+		})
+	}
+}
+
+// testIPAMPoolCIDRStates returns the pool CIDRs reported by the specified call to GetIpamPoolCidrs, in which the CIDR
+// has the state at that index, or the last state for later calls. An empty state reports no CIDR.
+func testIPAMPoolCIDRStates(cidrBlock string, states []string, call int) []*ec2.IpamPoolCidr {
+	if call >= len(states) {
+		call = len(states) - 1
+	}
+
+	state := states[call]
+	if state == "" {
+		return nil
+	}
+
+	cidr := &ec2.IpamPoolCidr{
+		Cidr:  aws.String(cidrBlock),
+		State: aws.String(state),
+	}
+
+	switch state {
+	case ec2.IpamPoolCidrStateFailedDeprovision, ec2.IpamPoolCidrStateFailedImport, ec2.IpamPoolCidrStateFailedProvision:
+		cidr.FailureReason = &ec2.IpamPoolCidrFailureReason{
+			Code:    aws.String(ec2.IpamPoolCidrFailureCodeCidrNotAvailable),
+			Message: aws.String("The CIDR is not available."),
+		}
+	}
+
+	return []*ec2.IpamPoolCidr{cidr}
+}
+
+func TestDeprovisionIPAMPoolCIDR(t *testing.T) {
+	ctx := context.Background()
+	t.Parallel()
+
+	cidrBlock, poolID := "10.0.0.0/24", "ipam-pool-12345678"
+
+	sess, err := session.NewSession(nil)
+	if err != nil {
+		t.Fatalf("Error creating AWS session: %s", err)
+	}
+
+	allocations := []*ec2.IpamPoolAllocation{
+		{
+			Cidr:                 aws.String("10.0.0.0/26"),
+			IpamPoolAllocationId: aws.String("ipam-pool-alloc-11111111"),
+			ResourceId:           aws.String("vpc-12345678"),
+			ResourceType:         aws.String(ec2.IpamPoolAllocationResourceTypeVpc),
+		},
+		{
+			Cidr:                 aws.String("10.1.0.0/26"),
+			IpamPoolAllocationId: aws.String("ipam-pool-alloc-22222222"),
+			ResourceType:         aws.String(ec2.IpamPoolAllocationResourceTypeCustom),
+		},
+	}
+
+	testCases := []struct {
+		Name           string
+		DeprovisionErr error
+		State          string
+		Allocations    []*ec2.IpamPoolAllocation
+		ExpectError    *regexp.Regexp
+	}{
+		{
+			Name:  "deprovisioned",
+			State: ec2.IpamPoolCidrStateDeprovisioned,
+		},
+		{
+			Name:        "failed deprovision with allocation",
+			State:       ec2.IpamPoolCidrStateFailedDeprovision,
+			Allocations: allocations,
+			ExpectError: regexp.MustCompile(`cidr-has-allocations: .+; 1 allocations remain in the CIDR: ipam-pool-alloc-11111111 \(10\.0\.0\.0/26, vpc vpc-12345678\)$`),
+		},
+		{
+			Name:           "deprovision error with allocation",
+			DeprovisionErr: awserr.New("InvalidParameterValue", "The CIDR has allocations.", nil),
+			Allocations:    allocations,
+			ExpectError:    regexp.MustCompile(`The CIDR has allocations\.; 1 allocations remain in the CIDR: ipam-pool-alloc-11111111 \(10\.0\.0\.0/26, vpc vpc-12345678\)$`),
+		},
+		{
+			Name:        "failed deprovision without allocations",
+			State:       ec2.IpamPoolCidrStateFailedDeprovision,
+			ExpectError: regexp.MustCompile(`cidr-has-allocations: [^;]+$`),
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.Name, func(t *testing.T) {
+			t.Parallel()
+
+			conn := ec2.New(sess)
+			conn.Handlers.Clear()
+			conn.Handlers.Send.PushBack(func(r *request.Request) {
+				switch data := r.Data.(type) {
+				case *ec2.DeprovisionIpamPoolCidrOutput:
+					r.Error = testCase.DeprovisionErr
+				case *ec2.GetIpamPoolCidrsOutput:
+					data.IpamPoolCidrs = []*ec2.IpamPoolCidr{{
+						Cidr: aws.String(cidrBlock),
+						FailureReason: &ec2.IpamPoolCidrFailureReason{
+							Code:    aws.String("cidr-has-allocations"),
+							Message: aws.String("The CIDR has allocations."),
+						},
+						State: aws.String(testCase.State),
+					}}
+				case *ec2.GetIpamPoolAllocationsOutput:
+					data.IpamPoolAllocations = testCase.Allocations
+				}
+			})
+
+			err := tfec2.DeprovisionIPAMPoolCIDR(ctx, conn, cidrBlock, poolID, 1*time.Minute)
+
+			if testCase.ExpectError == nil {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+
+				return
+			}
+
+			if err == nil {
+				t.Fatal("expected error, got none")
+			}
+
+			if !testCase.ExpectError.MatchString(err.Error()) {
+				t.Errorf("got error %q, expected to match %q", err, testCase.ExpectError)
+			}
+		})
+	}
+}
+
+func TestIPAMPoolCIDRBlockAvailable(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		Name          string
+		Provisioned   []string
+		Allocated     []string
+		NetmaskLength int
+		Expected      bool
+		ExpectedError bool
+	}{
+		{
+			Name:          "no provisioned CIDRs",
+			NetmaskLength: 24,
+		},
+		{
+			Name:          "empty pool",
+			Provisioned:   []string{"10.0.0.0/16"},
+			NetmaskLength: 24,
+			Expected:      true,
+		},
+		{
+			Name:          "same size as provisioned CIDR",
+			Provisioned:   []string{"10.0.0.0/16"},
+			NetmaskLength: 16,
+			Expected:      true,
+		},
+		{
+			Name:          "larger than provisioned CIDR",
+			Provisioned:   []string{"10.0.0.0/16"},
+			NetmaskLength: 15,
+		},
+		{
+			Name:          "fully allocated",
+			Provisioned:   []string{"10.0.0.0/16"},
+			Allocated:     []string{"10.0.0.0/17", "10.0.128.0/17"},
+			NetmaskLength: 24,
+		},
+		{
+			Name:          "fragmented",
+			Provisioned:   []string{"10.0.0.0/24"},
+			Allocated:     []string{"10.0.0.0/26", "10.0.0.128/26"},
+			NetmaskLength: 25,
+		},
+		{
+			Name:          "fragmented with smaller block free",
+			Provisioned:   []string{"10.0.0.0/24"},
+			Allocated:     []string{"10.0.0.0/26", "10.0.0.128/26"},
+			NetmaskLength: 26,
+			Expected:      true,
+		},
+		{
+			Name:          "exhausted first CIDR, free second CIDR",
+			Provisioned:   []string{"10.0.0.0/24", "10.1.0.0/24"},
+			Allocated:     []string{"10.0.0.0/24"},
+			NetmaskLength: 28,
+			Expected:      true,
+		},
+		{
+			Name:          "IPv6",
+			Provisioned:   []string{"2001:db8::/52"},
+			Allocated:     []string{"2001:db8::/53"},
+			NetmaskLength: 56,
+			Expected:      true,
+		},
+		{
+			Name:          "IPv6 exhausted",
+			Provisioned:   []string{"2001:db8::/56"},
+			Allocated:     []string{"2001:db8::/56"},
+			NetmaskLength: 60,
+		},
+		{
+			Name:          "netmask length longer than address",
+			Provisioned:   []string{"10.0.0.0/24"},
+			NetmaskLength: 64,
+		},
+		{
+			Name:          "invalid CIDR",
+			Provisioned:   []string{"10.0.0.0"},
+			NetmaskLength: 24,
+			ExpectedError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.Name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := tfec2.IPAMPoolCIDRBlockAvailable(testCase.Provisioned, testCase.Allocated, testCase.NetmaskLength)
+
+			if gotErr := err != nil; gotErr != testCase.ExpectedError {
+				t.Fatalf("got error %v, expected error %t", err, testCase.ExpectedError)
+			}
+
+			if got != testCase.Expected {
+				t.Errorf("got %t, expected %t", got, testCase.Expected)
+			}
+		})
+	}
+}
+
+func TestIPAMPoolCIDRBlockCount(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		Name          string
+		Provisioned   []string
+		Allocated     []string
+		NetmaskLength int
+		Expected      string
+		ExpectedError bool
+	}{
+		{
+			Name:          "no provisioned CIDRs",
+			NetmaskLength: 24,
+			Expected:      "0",
+		},
+		{
+			Name:          "empty pool",
+			Provisioned:   []string{"10.0.0.0/16"},
+			NetmaskLength: 24,
+			Expected:      "256",
+		},
+		{
+			Name:          "same size as provisioned CIDR",
+			Provisioned:   []string{"10.0.0.0/16"},
+			NetmaskLength: 16,
+			Expected:      "1",
+		},
+		{
+			Name:          "larger than provisioned CIDR",
+			Provisioned:   []string{"10.0.0.0/16"},
+			NetmaskLength: 15,
+			Expected:      "0",
+		},
+		{
+			Name:          "fully allocated",
+			Provisioned:   []string{"10.0.0.0/16"},
+			Allocated:     []string{"10.0.0.0/17", "10.0.128.0/17"},
+			NetmaskLength: 24,
+			Expected:      "0",
+		},
+		{
+			Name:          "partially allocated",
+			Provisioned:   []string{"10.0.0.0/24"},
+			Allocated:     []string{"10.0.0.0/26"},
+			NetmaskLength: 28,
+			Expected:      "12",
+		},
+		{
+			Name:          "fragmented",
+			Provisioned:   []string{"10.0.0.0/24"},
+			Allocated:     []string{"10.0.0.0/26", "10.0.0.128/26"},
+			NetmaskLength: 25,
+			Expected:      "0",
+		},
+		{
+			Name:          "fragmented with smaller blocks free",
+			Provisioned:   []string{"10.0.0.0/24"},
+			Allocated:     []string{"10.0.0.0/26", "10.0.0.128/26"},
+			NetmaskLength: 26,
+			Expected:      "2",
+		},
+		{
+			Name:          "allocation smaller than requested block",
+			Provisioned:   []string{"10.0.0.0/24"},
+			Allocated:     []string{"10.0.0.1/32"},
+			NetmaskLength: 26,
+			Expected:      "3",
+		},
+		{
+			Name:          "overlapping allocations",
+			Provisioned:   []string{"10.0.0.0/24"},
+			Allocated:     []string{"10.0.0.0/25", "10.0.0.64/26"},
+			NetmaskLength: 26,
+			Expected:      "2",
+		},
+		{
+			Name:          "unmasked CIDRs",
+			Provisioned:   []string{"10.0.0.1/24"},
+			Allocated:     []string{"10.0.0.65/26"},
+			NetmaskLength: 26,
+			Expected:      "3",
+		},
+		{
+			Name:          "multiple provisioned CIDRs",
+			Provisioned:   []string{"10.0.0.0/24", "10.1.0.0/23"},
+			Allocated:     []string{"10.0.0.0/24", "10.1.1.0/24"},
+			NetmaskLength: 28,
+			Expected:      "16",
+		},
+		{
+			Name:          "IPv6",
+			Provisioned:   []string{"2001:db8::/52"},
+			Allocated:     []string{"2001:db8::/53"},
+			NetmaskLength: 56,
+			Expected:      "8",
+		},
+		{
+			Name:          "IPv6 exhausted",
+			Provisioned:   []string{"2001:db8::/56"},
+			Allocated:     []string{"2001:db8::/56"},
+			NetmaskLength: 60,
+			Expected:      "0",
+		},
+		{
+			Name:          "IPv6 larger than int64",
+			Provisioned:   []string{"2001:db8::/32"},
+			Allocated:     []string{"2001:db8::/64"},
+			NetmaskLength: 128,
+			Expected:      "79228162495817593519834398720", // 2^96 - 2^64
+		},
+		{
+			Name:          "netmask length longer than address",
+			Provisioned:   []string{"10.0.0.0/24"},
+			NetmaskLength: 64,
+			Expected:      "0",
+		},
+		{
+			Name:          "invalid provisioned CIDR",
+			Provisioned:   []string{"10.0.0.0"},
+			NetmaskLength: 24,
+			ExpectedError: true,
+		},
+		{
+			Name:          "invalid allocated CIDR",
+			Provisioned:   []string{"10.0.0.0/16"},
+			Allocated:     []string{"10.0.0.0/33"},
+			NetmaskLength: 24,
+			ExpectedError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.Name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := tfec2.IPAMPoolCIDRBlockCount(testCase.Provisioned, testCase.Allocated, testCase.NetmaskLength)
+
+			if gotErr := err != nil; gotErr != testCase.ExpectedError {
+				t.Fatalf("got error %v, expected error %t", err, testCase.ExpectedError)
+			}
+
+			if err != nil {
+				return
+			}
+
+			if got.String() != testCase.Expected {
+				t.Errorf("got %s, expected %s", got, testCase.Expected)
+			}
+		})
+	}
+}
+
+func TestValidateIPAMPoolCIDRAmazonProvided(t *testing.T) {
+	t.Parallel()
+
+	amazonIPv6 := &ec2.IpamPool{
+		AddressFamily:  aws.String(ec2.AddressFamilyIpv6),
+		IpamPoolId:     aws.String("ipam-pool-11111111"),
+		PublicIpSource: aws.String(ec2.IpamPoolPublicIpSourceAmazon),
+	}
+	byoipIPv6 := &ec2.IpamPool{
+		AddressFamily:  aws.String(ec2.AddressFamilyIpv6),
+		IpamPoolId:     aws.String("ipam-pool-22222222"),
+		PublicIpSource: aws.String(ec2.IpamPoolPublicIpSourceByoip),
+	}
+
+	testCases := []struct {
+		Name          string
+		Pool          *ec2.IpamPool
+		CIDR          string
+		NetmaskLength int
+		ExpectError   *regexp.Regexp
+	}{
+		{
+			Name:          "amazon netmask length",
+			Pool:          amazonIPv6,
+			NetmaskLength: 52,
+		},
+		{
+			Name:          "amazon largest netmask length",
+			Pool:          amazonIPv6,
+			NetmaskLength: 40,
+		},
+		{
+			Name:          "amazon netmask length too small",
+			Pool:          amazonIPv6,
+			NetmaskLength: 56,
+			ExpectError:   regexp.MustCompile(`netmask_length \(56\) must be between 40 and 52`),
+		},
+		{
+			Name:          "amazon netmask length too large",
+			Pool:          amazonIPv6,
+			NetmaskLength: 39,
+			ExpectError:   regexp.MustCompile(`netmask_length \(39\) must be between 40 and 52`),
+		},
+		{
+			Name:        "amazon cidr",
+			Pool:        amazonIPv6,
+			CIDR:        "2001:db8::/52",
+			ExpectError: regexp.MustCompile(`cidr can't be set for IPAM Pool \(ipam-pool-11111111\) with public_ip_source amazon`),
+		},
+		{
+			Name:        "amazon no netmask length",
+			Pool:        amazonIPv6,
+			ExpectError: regexp.MustCompile(`netmask_length is required`),
+		},
+		{
+			Name: "byoip cidr",
+			Pool: byoipIPv6,
+			CIDR: "2001:db8::/48",
+		},
+		{
+			Name: "private pool",
+			Pool: &ec2.IpamPool{
+				AddressFamily: aws.String(ec2.AddressFamilyIpv4),
+				IpamPoolId:    aws.String("ipam-pool-33333333"),
+			},
+			NetmaskLength: 24,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.Name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tfec2.ValidateIPAMPoolCIDRAmazonProvided(testCase.Pool, testCase.CIDR, testCase.NetmaskLength)
+
+			if testCase.ExpectError == nil {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+
+				return
+			}
+
+			if err == nil {
+				t.Fatal("expected error, got none")
+			}
+
+			if !testCase.ExpectError.MatchString(err.Error()) {
+				t.Errorf("got error %q, expected to match %q", err, testCase.ExpectError)
+			}
+		})
+	}
+}
+
 func TestAccIPAMPoolCIDR_basic(t *testing.T) {
 	ctx := acctest.Context(t)
 	var cidr ec2.IpamPoolCidr
@@ -43,6 +1067,163 @@ func TestAccIPAMPoolCIDR_basic(t *testing.T) {
 	})
 }
 
+func TestAccIPAMPoolCIDR_netmaskLength(t *testing.T) {
+	ctx := acctest.Context(t)
+	var cidr ec2.IpamPoolCidr
+	resourceName := "aws_vpc_ipam_pool_cidr.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ErrorCheck:               acctest.ErrorCheck(t, ec2.EndpointsID),
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckIPAMPoolCIDRDestroy(ctx),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccIPAMPoolCIDRConfig_netmaskLength(24),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIPAMPoolCIDRExists(ctx, resourceName, &cidr),
+					resource.TestMatchResourceAttr(resourceName, "cidr", regexp.MustCompile(`^10\.0\.\d+\.0/24$`)),
+					resource.TestCheckResourceAttrPair(resourceName, "ipam_pool_id", "aws_vpc_ipam_pool.test", "id"),
+					resource.TestCheckResourceAttr(resourceName, "netmask_length", "24"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccIPAMPoolCIDR_replace(t *testing.T) {
+	ctx := acctest.Context(t)
+	var cidr1, cidr2 ec2.IpamPoolCidr
+	resourceName := "aws_vpc_ipam_pool_cidr.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ErrorCheck:               acctest.ErrorCheck(t, ec2.EndpointsID),
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckIPAMPoolCIDRDestroy(ctx),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccIPAMPoolCIDRConfig_provisionedIPv4("10.0.0.0/24"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIPAMPoolCIDRExists(ctx, resourceName, &cidr1),
+					resource.TestCheckResourceAttr(resourceName, "cidr", "10.0.0.0/24"),
+				),
+			},
+			{
+				// The old CIDR is deprovisioned before the new one, which contains it, is provisioned.
+				Config: testAccIPAMPoolCIDRConfig_provisionedIPv4("10.0.0.0/23"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIPAMPoolCIDRExists(ctx, resourceName, &cidr2),
+					resource.TestCheckResourceAttr(resourceName, "cidr", "10.0.0.0/23"),
+					testAccCheckIPAMPoolCIDRRecreated(&cidr1, &cidr2),
+				),
+			},
+		},
+	})
+}
+
+func TestAccIPAMPoolCIDR_replaceNetmaskLength(t *testing.T) {
+	ctx := acctest.Context(t)
+	var cidr1, cidr2 ec2.IpamPoolCidr
+	resourceName := "aws_vpc_ipam_pool_cidr.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ErrorCheck:               acctest.ErrorCheck(t, ec2.EndpointsID),
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckIPAMPoolCIDRDestroy(ctx),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccIPAMPoolCIDRConfig_netmaskLength(24),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIPAMPoolCIDRExists(ctx, resourceName, &cidr1),
+					resource.TestCheckResourceAttr(resourceName, "netmask_length", "24"),
+				),
+			},
+			{
+				Config: testAccIPAMPoolCIDRConfig_netmaskLength(22),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIPAMPoolCIDRExists(ctx, resourceName, &cidr2),
+					resource.TestMatchResourceAttr(resourceName, "cidr", regexp.MustCompile(`^10\.0\.\d+\.0/22$`)),
+					resource.TestCheckResourceAttr(resourceName, "netmask_length", "22"),
+					testAccCheckIPAMPoolCIDRRecreated(&cidr1, &cidr2),
+				),
+			},
+		},
+	})
+}
+
+func TestAccIPAMPoolCIDR_netmaskLengthExhausted(t *testing.T) {
+	ctx := acctest.Context(t)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ErrorCheck:               acctest.ErrorCheck(t, ec2.EndpointsID),
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckIPAMPoolCIDRDestroy(ctx),
+		Steps: []resource.TestStep{
+			{
+				// The source pool only has a /20 provisioned.
+				Config:      testAccIPAMPoolCIDRConfig_netmaskLength(19),
+				ExpectError: regexp.MustCompile(`no /19 block is available in source IPAM Pool`),
+			},
+		},
+	})
+}
+
+// Amazon-provided IPv6 space is limited by a per-Region quota, so the test only runs when opted in.
+func TestAccIPAMPoolCIDR_amazonProvidedIPv6(t *testing.T) {
+	ctx := acctest.Context(t)
+	key := "IPAM_AMAZON_PROVIDED_IPV6"
+	if os.Getenv(key) == "" {
+		t.Skipf("Environment variable %s is not set", key)
+	}
+
+	var cidr ec2.IpamPoolCidr
+	resourceName := "aws_vpc_ipam_pool_cidr.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ErrorCheck:               acctest.ErrorCheck(t, ec2.EndpointsID),
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckIPAMPoolCIDRDestroy(ctx),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccIPAMPoolCIDRConfig_amazonProvidedIPv6(52),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIPAMPoolCIDRExists(ctx, resourceName, &cidr),
+					resource.TestMatchResourceAttr(resourceName, "cidr", regexp.MustCompile(`/52$`)),
+					resource.TestCheckResourceAttrPair(resourceName, "ipam_pool_id", "aws_vpc_ipam_pool.test", "id"),
+					resource.TestCheckResourceAttr(resourceName, "netmask_length", "52"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccIPAMPoolCIDR_amazonProvidedIPv6NetmaskLengthInvalid(t *testing.T) {
+	ctx := acctest.Context(t)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ErrorCheck:               acctest.ErrorCheck(t, ec2.EndpointsID),
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckIPAMPoolCIDRDestroy(ctx),
+		Steps: []resource.TestStep{
+			{
+				// Rejected before anything is provisioned.
+				Config:      testAccIPAMPoolCIDRConfig_amazonProvidedIPv6(56),
+				ExpectError: regexp.MustCompile(`netmask_length \(56\) must be between 40 and 52`),
+			},
+		},
+	})
+}
+
 func TestAccIPAMPoolCIDR_disappears(t *testing.T) {
 	ctx := acctest.Context(t)
 	var cidr ec2.IpamPoolCidr
@@ -123,6 +1304,16 @@ func testAccCheckIPAMPoolCIDRExists(ctx context.Context, n string, v *ec2.IpamPo
 	}
 }
 
+func testAccCheckIPAMPoolCIDRRecreated(before, after *ec2.IpamPoolCidr) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if before, after := aws.StringValue(before.IpamPoolCidrId), aws.StringValue(after.IpamPoolCidrId); before == after {
+			return fmt.Errorf("IPAM Pool CIDR (%s) not recreated", before)
+		}
+
+		return nil
+	}
+}
+
 func testAccCheckIPAMPoolCIDRDestroy(ctx context.Context) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		conn := acctest.Provider.Meta().(*conns.AWSClient).EC2Conn()
@@ -185,3 +1376,49 @@ resource "aws_vpc_ipam_pool_cidr" "test" {
 }
 `, cidr))
 }
+
+func testAccIPAMPoolCIDRConfig_amazonProvidedIPv6(netmaskLength int) string {
+	return acctest.ConfigCompose(testAccIPAMPoolCIDRConfig_base, fmt.Sprintf(`
+resource "aws_vpc_ipam_pool" "test" {
+  address_family        = "ipv6"
+  ipam_scope_id         = aws_vpc_ipam.test.public_default_scope_id
+  locale                = data.aws_region.current.name
+  public_ip_source      = "amazon"
+  aws_service           = "ec2"
+  publicly_advertisable = false
+}
+
+resource "aws_vpc_ipam_pool_cidr" "test" {
+  ipam_pool_id   = aws_vpc_ipam_pool.test.id
+  netmask_length = %[1]d
+}
+`, netmaskLength))
+}
+
+func testAccIPAMPoolCIDRConfig_netmaskLength(netmaskLength int) string {
+	return acctest.ConfigCompose(testAccIPAMPoolCIDRConfig_base, fmt.Sprintf(`
+resource "aws_vpc_ipam_pool" "parent" {
+  address_family = "ipv4"
+  ipam_scope_id  = aws_vpc_ipam.test.private_default_scope_id
+}
+
+resource "aws_vpc_ipam_pool_cidr" "parent" {
+  ipam_pool_id = aws_vpc_ipam_pool.parent.id
+  cidr         = "10.0.0.0/20"
+}
+
+resource "aws_vpc_ipam_pool" "test" {
+  address_family      = "ipv4"
+  ipam_scope_id       = aws_vpc_ipam.test.private_default_scope_id
+  locale              = data.aws_region.current.name
+  source_ipam_pool_id = aws_vpc_ipam_pool.parent.id
+}
+
+resource "aws_vpc_ipam_pool_cidr" "test" {
+  ipam_pool_id   = aws_vpc_ipam_pool.test.id
+  netmask_length = %[1]d
+
+  depends_on = [aws_vpc_ipam_pool_cidr.parent]
+}
+`, netmaskLength))
+}
@@ -0,0 +1,259 @@
+package ec2
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/hashicorp/terraform-provider-aws/internal/conns"
+	"github.com/hashicorp/terraform-provider-aws/internal/errs/sdkdiag"
+	"github.com/hashicorp/terraform-provider-aws/internal/flex"
+	"github.com/hashicorp/terraform-provider-aws/internal/tfresource"
+	"github.com/hashicorp/terraform-provider-aws/internal/verify"
+)
+
+func ResourceIPAMPoolCIDRs() *schema.Resource {
+	return &schema.Resource{
+		CreateWithoutTimeout: resourceIPAMPoolCIDRsCreate,
+		ReadWithoutTimeout:   resourceIPAMPoolCIDRsRead,
+		UpdateWithoutTimeout: resourceIPAMPoolCIDRsUpdate,
+		DeleteWithoutTimeout: resourceIPAMPoolCIDRsDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceIPAMPoolCIDRsImport,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(32 * time.Minute),
+			// Allocations release are eventually consistent with a max time of 20m.
+			Delete: schema.DefaultTimeout(32 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"cidrs": {
+				Type:     schema.TypeSet,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+					ValidateFunc: validation.Any(
+						verify.ValidIPv4CIDRNetworkAddress,
+						verify.ValidIPv6CIDRNetworkAddress,
+					),
+				},
+			},
+			"ipam_pool_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+		},
+	}
+}
+
+func resourceIPAMPoolCIDRsCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	conn := meta.(*conns.AWSClient).EC2Conn()
+
+	poolID := d.Get("ipam_pool_id").(string)
+	cidrs := flex.ExpandStringValueSet(d.Get("cidrs").(*schema.Set))
+	sort.Strings(cidrs)
+
+	d.SetId(poolID)
+
+	if err := modifyIPAMPoolCIDRs(ctx, conn, poolID, cidrs, nil, d.Timeout(schema.TimeoutCreate)); err != nil {
+		diags = sdkdiag.AppendErrorf(diags, "creating IPAM Pool (%s) CIDRs: %s", poolID, err)
+		return append(diags, readIPAMPoolCIDRsAfterFailure(ctx, conn, d, cidrs)...)
+	}
+
+	return append(diags, resourceIPAMPoolCIDRsRead(ctx, d, meta)...)
+}
+
+func resourceIPAMPoolCIDRsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	conn := meta.(*conns.AWSClient).EC2Conn()
+
+	// Other CIDRs provisioned to the pool, e.g. by aws_vpc_ipam_pool_cidr, aren't managed by this resource.
+	cidrs, err := findIPAMPoolManagedCIDRBlocks(ctx, conn, d.Id(), flex.ExpandStringValueSet(d.Get("cidrs").(*schema.Set)))
+
+	if !d.IsNewResource() && tfresource.NotFound(err) {
+		log.Printf("[WARN] IPAM Pool (%s) CIDRs not found, removing from state", d.Id())
+		d.SetId("")
+		return diags
+	}
+
+	if err != nil {
+		return sdkdiag.AppendErrorf(diags, "reading IPAM Pool (%s) CIDRs: %s", d.Id(), err)
+	}
+
+	d.Set("cidrs", cidrs)
+	d.Set("ipam_pool_id", d.Id())
+
+	return diags
+}
+
+// resourceIPAMPoolCIDRsImport imports the CIDRs of an IPAM Pool by pool ID. All CIDRs provisioned to the pool are
+// adopted, as which of them are to be managed by the resource isn't known until the next apply.
+func resourceIPAMPoolCIDRsImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	conn := meta.(*conns.AWSClient).EC2Conn()
+
+	cidrs, err := findIPAMPoolProvisionedCIDRBlocks(ctx, conn, d.Id())
+
+	if err != nil {
+		return nil, fmt.Errorf("reading IPAM Pool (%s) CIDRs: %w", d.Id(), err)
+	}
+
+	d.Set("cidrs", cidrs)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceIPAMPoolCIDRsUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	conn := meta.(*conns.AWSClient).EC2Conn()
+
+	o, n := d.GetChange("cidrs")
+	add, remove := ipamPoolCIDRsUpdate(o.(*schema.Set), n.(*schema.Set))
+
+	if err := modifyIPAMPoolCIDRs(ctx, conn, d.Id(), add, remove, d.Timeout(schema.TimeoutUpdate)); err != nil {
+		diags = sdkdiag.AppendErrorf(diags, "updating IPAM Pool (%s) CIDRs: %s", d.Id(), err)
+		return append(diags, readIPAMPoolCIDRsAfterFailure(ctx, conn, d, flex.ExpandStringValueSet(o.(*schema.Set).Union(n.(*schema.Set))))...)
+	}
+
+	return append(diags, resourceIPAMPoolCIDRsRead(ctx, d, meta)...)
+}
+
+func resourceIPAMPoolCIDRsDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	conn := meta.(*conns.AWSClient).EC2Conn()
+
+	cidrs := flex.ExpandStringValueSet(d.Get("cidrs").(*schema.Set))
+	sort.Strings(cidrs)
+
+	log.Printf("[DEBUG] Deleting IPAM Pool (%s) CIDRs: %s", d.Id(), cidrs)
+	if err := modifyIPAMPoolCIDRs(ctx, conn, d.Id(), nil, cidrs, d.Timeout(schema.TimeoutDelete)); err != nil {
+		return sdkdiag.AppendErrorf(diags, "deleting IPAM Pool (%s) CIDRs: %s", d.Id(), err)
+	}
+
+	return diags
+}
+
+// readIPAMPoolCIDRsAfterFailure sets the state to the CIDRs of the specified ones that are provisioned to the pool,
+// so that only the CIDRs that failed to be provisioned or deprovisioned show in the next plan.
+func readIPAMPoolCIDRsAfterFailure(ctx context.Context, conn *ec2.EC2, d *schema.ResourceData, cidrs []string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	provisioned, err := findIPAMPoolManagedCIDRBlocks(ctx, conn, d.Id(), cidrs)
+
+	if err != nil {
+		return sdkdiag.AppendErrorf(diags, "reading IPAM Pool (%s) CIDRs: %s", d.Id(), err)
+	}
+
+	// Nothing was provisioned, so there is nothing left to manage.
+	if len(provisioned) == 0 {
+		d.SetId("")
+		return diags
+	}
+
+	// The CIDRs read back are saved in place of the planned ones.
+	d.Set("cidrs", provisioned)
+
+	return diags
+}
+
+// ipamPoolCIDRsUpdate returns the CIDRs to provision to and deprovision from a pool for a change from the old to the
+// new CIDRs, each in sorted order.
+func ipamPoolCIDRsUpdate(o, n *schema.Set) ([]string, []string) {
+	add := flex.ExpandStringValueSet(n.Difference(o))
+	sort.Strings(add)
+
+	remove := flex.ExpandStringValueSet(o.Difference(n))
+	sort.Strings(remove)
+
+	return add, remove
+}
+
+// modifyIPAMPoolCIDRs deprovisions the CIDRs to remove from the pool, so that their space is released, then provisions
+// the CIDRs to add, waiting for each CIDR in turn. It stops at the first CIDR that fails.
+func modifyIPAMPoolCIDRs(ctx context.Context, conn *ec2.EC2, poolID string, add, remove []string, timeout time.Duration) error {
+	for _, cidrBlock := range remove {
+		log.Printf("[DEBUG] Deprovisioning IPAM Pool (%s) CIDR: %s", poolID, cidrBlock)
+		if err := deprovisionIPAMPoolCIDR(ctx, conn, cidrBlock, poolID, timeout); err != nil {
+			return fmt.Errorf("deprovisioning CIDR (%s): %w", cidrBlock, err)
+		}
+	}
+
+	for _, cidrBlock := range add {
+		log.Printf("[DEBUG] Provisioning IPAM Pool (%s) CIDR: %s", poolID, cidrBlock)
+		input := &ec2.ProvisionIpamPoolCidrInput{
+			Cidr:       aws.String(cidrBlock),
+			IpamPoolId: aws.String(poolID),
+		}
+
+		if _, err := provisionIPAMPoolCIDR(ctx, conn, input, timeout); err != nil {
+			return fmt.Errorf("provisioning CIDR (%s): %w", cidrBlock, err)
+		}
+
+		if _, err := WaitIPAMPoolCIDRCreated(ctx, conn, cidrBlock, poolID, timeout); err != nil {
+			return fmt.Errorf("waiting for CIDR (%s) provision: %w", cidrBlock, err)
+		}
+	}
+
+	return nil
+}
+
+// findIPAMPoolManagedCIDRBlocks returns the sorted CIDRs provisioned to the pool that are among the managed ones.
+func findIPAMPoolManagedCIDRBlocks(ctx context.Context, conn *ec2.EC2, poolID string, managed []string) ([]string, error) {
+	cidrs, err := findIPAMPoolProvisionedCIDRBlocks(ctx, conn, poolID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	include := make(map[string]bool, len(managed))
+	for _, v := range managed {
+		include[v] = true
+	}
+
+	output := []string{}
+	for _, v := range cidrs {
+		if include[v] {
+			output = append(output, v)
+		}
+	}
+
+	return output, nil
+}
+
+// findIPAMPoolProvisionedCIDRBlocks returns the sorted CIDRs provisioned to the pool.
+func findIPAMPoolProvisionedCIDRBlocks(ctx context.Context, conn *ec2.EC2, poolID string) ([]string, error) {
+	cidrs, err := FindIPAMPoolCIDRs(ctx, conn, &ec2.GetIpamPoolCidrsInput{
+		IpamPoolId: aws.String(poolID),
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	output := []string{}
+	for _, v := range provisionedIPAMPoolCIDRs(cidrs) {
+		// A CIDR that failed to be provisioned takes no space in the pool.
+		if state := aws.StringValue(v.State); state == ec2.IpamPoolCidrStateFailedProvision || state == ec2.IpamPoolCidrStateFailedImport {
+			continue
+		}
+
+		output = append(output, aws.StringValue(v.Cidr))
+	}
+
+	sort.Strings(output)
+
+	return output, nil
+}
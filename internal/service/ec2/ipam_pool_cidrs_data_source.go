@@ -35,6 +35,10 @@ func DataSourceIPAMPoolCIDRs() *schema.Resource {
 							Type:     schema.TypeString,
 							Computed: true,
 						},
+						"netmask_length": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
 						"state": {
 							Type:     schema.TypeString,
 							Computed: true,
@@ -86,6 +90,7 @@ func flattenIPAMPoolCIDRs(c []*ec2.IpamPoolCidr) []interface{} {
 func flattenIPAMPoolCIDR(c *ec2.IpamPoolCidr) map[string]interface{} {
 	cidr := make(map[string]interface{})
 	cidr["cidr"] = aws.StringValue(c.Cidr)
+	cidr["netmask_length"] = aws.Int64Value(c.NetmaskLength)
 	cidr["state"] = aws.StringValue(c.State)
 	return cidr
 }
@@ -0,0 +1,356 @@
+package ec2_test
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/hashicorp/terraform-provider-aws/internal/acctest"
+	"github.com/hashicorp/terraform-provider-aws/internal/conns"
+	tfec2 "github.com/hashicorp/terraform-provider-aws/internal/service/ec2"
+	"github.com/hashicorp/terraform-provider-aws/internal/tfresource"
+)
+
+func TestIPAMPoolCIDRsUpdate(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		Name           string
+		Old            []interface{}
+		New            []interface{}
+		ExpectedAdd    []string
+		ExpectedRemove []string
+	}{
+		{
+			Name:           "no change",
+			Old:            []interface{}{"10.0.0.0/24", "10.1.0.0/24"},
+			New:            []interface{}{"10.1.0.0/24", "10.0.0.0/24"},
+			ExpectedAdd:    []string{},
+			ExpectedRemove: []string{},
+		},
+		{
+			Name:           "add",
+			Old:            []interface{}{"10.0.0.0/24"},
+			New:            []interface{}{"10.2.0.0/24", "10.0.0.0/24", "10.1.0.0/24"},
+			ExpectedAdd:    []string{"10.1.0.0/24", "10.2.0.0/24"},
+			ExpectedRemove: []string{},
+		},
+		{
+			Name:           "add and remove",
+			Old:            []interface{}{"10.0.0.0/24", "10.1.0.0/24"},
+			New:            []interface{}{"10.1.0.0/24", "10.2.0.0/24"},
+			ExpectedAdd:    []string{"10.2.0.0/24"},
+			ExpectedRemove: []string{"10.0.0.0/24"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.Name, func(t *testing.T) {
+			t.Parallel()
+
+			add, remove := tfec2.IPAMPoolCIDRsUpdate(schema.NewSet(schema.HashString, testCase.Old), schema.NewSet(schema.HashString, testCase.New))
+
+			if !reflect.DeepEqual(add, testCase.ExpectedAdd) {
+				t.Errorf("got add %v, expected %v", add, testCase.ExpectedAdd)
+			}
+
+			if !reflect.DeepEqual(remove, testCase.ExpectedRemove) {
+				t.Errorf("got remove %v, expected %v", remove, testCase.ExpectedRemove)
+			}
+		})
+	}
+}
+
+func TestModifyIPAMPoolCIDRs(t *testing.T) {
+	ctx := context.Background()
+	t.Parallel()
+
+	poolID := "ipam-pool-12345678"
+
+	sess, err := session.NewSession(nil)
+	if err != nil {
+		t.Fatalf("Error creating AWS session: %s", err)
+	}
+
+	testCases := []struct {
+		Name                string
+		Provisioned         []string
+		Add                 []string
+		Remove              []string
+		Fail                string
+		ExpectError         *regexp.Regexp
+		ExpectedProvisioned []string
+	}{
+		{
+			Name:                "add and remove",
+			Provisioned:         []string{"10.0.0.0/24", "10.1.0.0/24"},
+			Add:                 []string{"10.2.0.0/24"},
+			Remove:              []string{"10.0.0.0/24"},
+			ExpectedProvisioned: []string{"10.1.0.0/24", "10.2.0.0/24"},
+		},
+		{
+			Name:                "second add fails",
+			Add:                 []string{"10.0.0.0/24", "10.1.0.0/24", "10.2.0.0/24"},
+			Fail:                "10.1.0.0/24",
+			ExpectError:         regexp.MustCompile(`provisioning CIDR \(10\.1\.0\.0/24\): .*The CIDR is invalid\.`),
+			ExpectedProvisioned: []string{"10.0.0.0/24"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.Name, func(t *testing.T) {
+			t.Parallel()
+
+			provisioned := make(map[string]bool)
+			for _, v := range testCase.Provisioned {
+				provisioned[v] = true
+			}
+
+			conn := ec2.New(sess)
+			conn.Handlers.Clear()
+			conn.Handlers.Send.PushBack(func(r *request.Request) {
+				switch data := r.Data.(type) {
+				case *ec2.ProvisionIpamPoolCidrOutput:
+					cidrBlock := aws.StringValue(r.Params.(*ec2.ProvisionIpamPoolCidrInput).Cidr)
+					if cidrBlock == testCase.Fail {
+						r.Error = awserr.New("InvalidParameterValue", "The CIDR is invalid.", nil)
+						return
+					}
+					provisioned[cidrBlock] = true
+				case *ec2.DeprovisionIpamPoolCidrOutput:
+					delete(provisioned, aws.StringValue(r.Params.(*ec2.DeprovisionIpamPoolCidrInput).Cidr))
+				case *ec2.GetIpamPoolCidrsOutput:
+					var filter string
+					if v := r.Params.(*ec2.GetIpamPoolCidrsInput).Filters; len(v) > 0 {
+						filter = aws.StringValue(v[0].Values[0])
+					}
+					for cidrBlock := range provisioned {
+						if filter == "" || filter == cidrBlock {
+							data.IpamPoolCidrs = append(data.IpamPoolCidrs, &ec2.IpamPoolCidr{
+								Cidr:  aws.String(cidrBlock),
+								State: aws.String(ec2.IpamPoolCidrStateProvisioned),
+							})
+						}
+					}
+				case *ec2.GetIpamPoolAllocationsOutput:
+				}
+			})
+
+			err := tfec2.ModifyIPAMPoolCIDRs(ctx, conn, poolID, testCase.Add, testCase.Remove, 1*time.Minute)
+
+			if testCase.ExpectError == nil && err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if testCase.ExpectError != nil && (err == nil || !testCase.ExpectError.MatchString(err.Error())) {
+				t.Fatalf("got error %v, expected to match %q", err, testCase.ExpectError)
+			}
+
+			managed := append(append([]string{}, testCase.Provisioned...), testCase.Add...)
+			got, err := tfec2.FindIPAMPoolManagedCIDRBlocks(ctx, conn, poolID, managed)
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !reflect.DeepEqual(got, testCase.ExpectedProvisioned) {
+				t.Errorf("got %v, expected %v", got, testCase.ExpectedProvisioned)
+			}
+		})
+	}
+}
+
+func TestFindIPAMPoolManagedCIDRBlocks(t *testing.T) {
+	ctx := context.Background()
+	t.Parallel()
+
+	sess, err := session.NewSession(nil)
+	if err != nil {
+		t.Fatalf("Error creating AWS session: %s", err)
+	}
+
+	conn := ec2.New(sess)
+	conn.Handlers.Clear()
+	conn.Handlers.Send.PushBack(func(r *request.Request) {
+		r.Data.(*ec2.GetIpamPoolCidrsOutput).IpamPoolCidrs = []*ec2.IpamPoolCidr{
+			{Cidr: aws.String("10.1.0.0/24"), State: aws.String(ec2.IpamPoolCidrStateProvisioned)},
+			{Cidr: aws.String("10.0.0.0/24"), State: aws.String(ec2.IpamPoolCidrStateProvisioned)},
+			{Cidr: aws.String("10.2.0.0/24"), State: aws.String(ec2.IpamPoolCidrStateFailedProvision)},
+			{Cidr: aws.String("10.3.0.0/24"), State: aws.String(ec2.IpamPoolCidrStateDeprovisioned)},
+		}
+	})
+
+	testCases := []struct {
+		Name     string
+		Managed  []string
+		Expected []string
+	}{
+		{
+			Name:     "none managed",
+			Expected: []string{},
+		},
+		{
+			Name:     "some managed",
+			Managed:  []string{"10.1.0.0/24", "10.2.0.0/24", "10.4.0.0/24"},
+			Expected: []string{"10.1.0.0/24"},
+		},
+		{
+			Name:     "all managed",
+			Managed:  []string{"10.1.0.0/24", "10.0.0.0/24"},
+			Expected: []string{"10.0.0.0/24", "10.1.0.0/24"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.Name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := tfec2.FindIPAMPoolManagedCIDRBlocks(ctx, conn, "ipam-pool-12345678", testCase.Managed)
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !reflect.DeepEqual(got, testCase.Expected) {
+				t.Errorf("got %v, expected %v", got, testCase.Expected)
+			}
+		})
+	}
+
+	t.Run("provisioned", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := tfec2.FindIPAMPoolProvisionedCIDRBlocks(ctx, conn, "ipam-pool-12345678")
+
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if expected := []string{"10.0.0.0/24", "10.1.0.0/24"}; !reflect.DeepEqual(got, expected) {
+			t.Errorf("got %v, expected %v", got, expected)
+		}
+	})
+}
+
+func TestAccIPAMPoolCIDRs_basic(t *testing.T) {
+	ctx := acctest.Context(t)
+	resourceName := "aws_vpc_ipam_pool_cidrs.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ErrorCheck:               acctest.ErrorCheck(t, ec2.EndpointsID),
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckIPAMPoolCIDRsDestroy(ctx),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccIPAMPoolCIDRsConfig_basic([]string{"10.0.0.0/24", "10.1.0.0/24"}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIPAMPoolCIDRsExists(ctx, resourceName),
+					resource.TestCheckResourceAttr(resourceName, "cidrs.#", "2"),
+					resource.TestCheckTypeSetElemAttr(resourceName, "cidrs.*", "10.0.0.0/24"),
+					resource.TestCheckTypeSetElemAttr(resourceName, "cidrs.*", "10.1.0.0/24"),
+					resource.TestCheckResourceAttrPair(resourceName, "ipam_pool_id", "aws_vpc_ipam_pool.test", "id"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccIPAMPoolCIDRsConfig_basic([]string{"10.1.0.0/24", "10.2.0.0/24"}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIPAMPoolCIDRsExists(ctx, resourceName),
+					resource.TestCheckResourceAttr(resourceName, "cidrs.#", "2"),
+					resource.TestCheckTypeSetElemAttr(resourceName, "cidrs.*", "10.1.0.0/24"),
+					resource.TestCheckTypeSetElemAttr(resourceName, "cidrs.*", "10.2.0.0/24"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckIPAMPoolCIDRsExists(ctx context.Context, n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No IPAM Pool ID is set")
+		}
+
+		conn := acctest.Provider.Meta().(*conns.AWSClient).EC2Conn()
+
+		var managed []string
+		for k, v := range rs.Primary.Attributes {
+			if regexp.MustCompile(`^cidrs\.\d+$`).MatchString(k) {
+				managed = append(managed, v)
+			}
+		}
+
+		output, err := tfec2.FindIPAMPoolManagedCIDRBlocks(ctx, conn, rs.Primary.ID, managed)
+
+		if err != nil {
+			return err
+		}
+
+		if len(output) != len(managed) {
+			return fmt.Errorf("IPAM Pool (%s) has %d of %d CIDRs provisioned", rs.Primary.ID, len(output), len(managed))
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckIPAMPoolCIDRsDestroy(ctx context.Context) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		conn := acctest.Provider.Meta().(*conns.AWSClient).EC2Conn()
+
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != "aws_vpc_ipam_pool" {
+				continue
+			}
+
+			// The IPAM pool is destroyed along with its CIDRs.
+			_, err := tfec2.FindIPAMPoolByID(ctx, conn, rs.Primary.ID)
+
+			if tfresource.NotFound(err) {
+				continue
+			}
+
+			if err != nil {
+				return err
+			}
+
+			return fmt.Errorf("IPAM Pool still exists: %s", rs.Primary.ID)
+		}
+
+		return nil
+	}
+}
+
+func testAccIPAMPoolCIDRsConfig_basic(cidrs []string) string {
+	return acctest.ConfigCompose(testAccIPAMPoolCIDRConfig_base, testAccIPAMPoolCIDRConfig_privatePool, fmt.Sprintf(`
+resource "aws_vpc_ipam_pool_cidrs" "test" {
+  ipam_pool_id = aws_vpc_ipam_pool.test.id
+  cidrs        = ["%[1]s"]
+}
+`, strings.Join(cidrs, `", "`)))
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/hashicorp/terraform-provider-aws/internal/conns"
 	"github.com/hashicorp/terraform-provider-aws/internal/errs/sdkdiag"
 	tftags "github.com/hashicorp/terraform-provider-aws/internal/tags"
@@ -25,8 +26,11 @@ func DataSourceIPAMPool() *schema.Resource {
 
 		Schema: map[string]*schema.Schema{
 			"address_family": {
-				Type:     schema.TypeString,
-				Computed: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				RequiredWith: []string{"ipam_scope_id"},
+				ValidateFunc: validation.StringInSlice(ec2.AddressFamily_Values(), false),
 			},
 			"allocation_default_netmask_length": {
 				Type:     schema.TypeInt,
@@ -49,6 +53,15 @@ func DataSourceIPAMPool() *schema.Resource {
 				Type:     schema.TypeBool,
 				Computed: true,
 			},
+			"available_cidr_count": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"available_cidr_netmask_length": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntBetween(0, 128),
+			},
 			"aws_service": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -67,8 +80,10 @@ func DataSourceIPAMPool() *schema.Resource {
 				Optional: true,
 			},
 			"ipam_scope_id": {
-				Type:     schema.TypeString,
-				Computed: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				RequiredWith: []string{"address_family"},
 			},
 			"ipam_scope_type": {
 				Type:     schema.TypeString,
@@ -110,6 +125,13 @@ func dataSourceIPAMPoolRead(ctx context.Context, d *schema.ResourceData, meta in
 		input.IpamPoolIds = aws.StringSlice([]string{v.(string)})
 	}
 
+	input.Filters = BuildAttributeFilterList(
+		map[string]string{
+			"address-family": d.Get("address_family").(string),
+			"ipam-scope-id":  d.Get("ipam_scope_id").(string),
+		},
+	)
+
 	input.Filters = append(input.Filters, BuildFiltersDataSource(
 		d.Get("filter").(*schema.Set),
 	)...)
@@ -126,10 +148,8 @@ func dataSourceIPAMPoolRead(ctx context.Context, d *schema.ResourceData, meta in
 
 	d.SetId(aws.StringValue(pool.IpamPoolId))
 	d.Set("address_family", pool.AddressFamily)
-	d.Set("allocation_default_netmask_length", pool.AllocationDefaultNetmaskLength)
-	d.Set("allocation_max_netmask_length", pool.AllocationMaxNetmaskLength)
-	d.Set("allocation_min_netmask_length", pool.AllocationMinNetmaskLength)
-	d.Set("allocation_resource_tags", KeyValueTags(tagsFromIPAMAllocationTags(pool.AllocationResourceTags)).Map())
+	setIPAMPoolAllocationNetmaskLengths(d, pool)
+	d.Set("allocation_resource_tags", flattenIPAMPoolAllocationResourceTags(pool.AllocationResourceTags, ignoreTagsConfig))
 	d.Set("arn", pool.IpamPoolArn)
 	d.Set("auto_import", pool.AutoImport)
 	d.Set("aws_service", pool.AwsService)
@@ -143,6 +163,29 @@ func dataSourceIPAMPoolRead(ctx context.Context, d *schema.ResourceData, meta in
 	d.Set("source_ipam_pool_id", pool.SourceIpamPoolId)
 	d.Set("state", pool.State)
 
+	if v, ok := d.GetOk("available_cidr_netmask_length"); ok {
+		netmaskLength := v.(int)
+		provisioned, allocated, err := findIPAMPoolProvisionedAndAllocatedCIDRs(ctx, conn, d.Id())
+
+		if err != nil {
+			return sdkdiag.AppendErrorf(diags, "reading IPAM Pool (%s) available CIDRs: %s", d.Id(), err)
+		}
+
+		count, err := ipamPoolCIDRBlockCount(provisioned, allocated, netmaskLength)
+
+		if err != nil {
+			return sdkdiag.AppendErrorf(diags, "reading IPAM Pool (%s) available CIDRs: %s", d.Id(), err)
+		}
+
+		if !count.IsInt64() {
+			return sdkdiag.AppendErrorf(diags, "reading IPAM Pool (%s) available CIDRs: %s /%d blocks available, too many to represent", d.Id(), count, netmaskLength)
+		}
+
+		d.Set("available_cidr_count", count.Int64())
+	} else {
+		d.Set("available_cidr_count", nil)
+	}
+
 	if err := d.Set("tags", KeyValueTags(pool.Tags).IgnoreAWS().IgnoreConfig(ignoreTagsConfig).Map()); err != nil {
 		return sdkdiag.AppendErrorf(diags, "setting tags: %s", err)
 	}
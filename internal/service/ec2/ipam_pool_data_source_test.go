@@ -1,6 +1,7 @@
 package ec2_test
 
 import (
+	"regexp"
 	"testing"
 
 	"github.com/aws/aws-sdk-go/service/ec2"
@@ -25,12 +26,14 @@ func TestAccIPAMPoolDataSource_basic(t *testing.T) {
 					resource.TestCheckResourceAttrPair(dataSourceName, "allocation_max_netmask_length", resourceName, "allocation_max_netmask_length"),
 					resource.TestCheckResourceAttrPair(dataSourceName, "allocation_min_netmask_length", resourceName, "allocation_min_netmask_length"),
 					resource.TestCheckResourceAttrPair(dataSourceName, "allocation_resource_tags.%", resourceName, "allocation_resource_tags.%"),
+					resource.TestCheckResourceAttr(dataSourceName, "allocation_resource_tags.test", "1"),
 					resource.TestCheckResourceAttrPair(dataSourceName, "arn", resourceName, "arn"),
 					resource.TestCheckResourceAttrPair(dataSourceName, "auto_import", resourceName, "auto_import"),
 					resource.TestCheckResourceAttrPair(dataSourceName, "aws_service", resourceName, "aws_service"),
 					resource.TestCheckResourceAttrPair(dataSourceName, "description", resourceName, "description"),
 					resource.TestCheckResourceAttrPair(dataSourceName, "ipam_scope_id", resourceName, "ipam_scope_id"),
 					resource.TestCheckResourceAttrPair(dataSourceName, "ipam_scope_type", resourceName, "ipam_scope_type"),
+					resource.TestCheckResourceAttr(dataSourceName, "ipam_scope_type", "private"),
 					resource.TestCheckResourceAttrPair(dataSourceName, "locale", resourceName, "locale"),
 					resource.TestCheckResourceAttrPair(dataSourceName, "pool_depth", resourceName, "pool_depth"),
 					resource.TestCheckResourceAttrPair(dataSourceName, "publicly_advertisable", resourceName, "publicly_advertisable"),
@@ -43,6 +46,85 @@ func TestAccIPAMPoolDataSource_basic(t *testing.T) {
 	})
 }
 
+func TestAccIPAMPoolDataSource_publicScope(t *testing.T) {
+	resourceName := "aws_vpc_ipam_pool.test"
+	dataSourceName := "data.aws_vpc_ipam_pool.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ErrorCheck:               acctest.ErrorCheck(t, ec2.EndpointsID),
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccIPAMPoolDataSourceConfig_publicScope,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair(dataSourceName, "id", resourceName, "id"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "ipam_scope_id", resourceName, "ipam_scope_id"),
+					resource.TestCheckResourceAttr(dataSourceName, "ipam_scope_type", "public"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccIPAMPoolDataSource_addressFamily(t *testing.T) {
+	ipv4ResourceName := "aws_vpc_ipam_pool.ipv4"
+	ipv6ResourceName := "aws_vpc_ipam_pool.ipv6"
+	ipv4DataSourceName := "data.aws_vpc_ipam_pool.ipv4"
+	ipv6DataSourceName := "data.aws_vpc_ipam_pool.ipv6"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ErrorCheck:               acctest.ErrorCheck(t, ec2.EndpointsID),
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccIPAMPoolDataSourceConfig_addressFamily,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair(ipv4DataSourceName, "id", ipv4ResourceName, "id"),
+					resource.TestCheckResourceAttr(ipv4DataSourceName, "address_family", "ipv4"),
+					resource.TestCheckResourceAttrPair(ipv4DataSourceName, "ipam_scope_id", ipv4ResourceName, "ipam_scope_id"),
+					resource.TestCheckResourceAttrPair(ipv6DataSourceName, "id", ipv6ResourceName, "id"),
+					resource.TestCheckResourceAttr(ipv6DataSourceName, "address_family", "ipv6"),
+					resource.TestCheckResourceAttrPair(ipv6DataSourceName, "ipam_scope_id", ipv6ResourceName, "ipam_scope_id"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccIPAMPoolDataSource_addressFamilyAmbiguous(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ErrorCheck:               acctest.ErrorCheck(t, ec2.EndpointsID),
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccIPAMPoolDataSourceConfig_addressFamilyAmbiguous,
+				ExpectError: regexp.MustCompile(`multiple IPAM Pools matched`),
+			},
+		},
+	})
+}
+
+func TestAccIPAMPoolDataSource_addressFamilyRequiresScope(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ErrorCheck:               acctest.ErrorCheck(t, ec2.EndpointsID),
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "aws_vpc_ipam_pool" "test" {
+  address_family = "ipv4"
+}
+`,
+				ExpectError: regexp.MustCompile(`all of .address_family,ipam_scope_id. must be specified`),
+			},
+		},
+	})
+}
+
 var testAccIPAMPoolDataSourceConfig_optionsBasic = acctest.ConfigCompose(testAccIPAMPoolConfig_base, `
 resource "aws_vpc_ipam_pool" "test" {
   address_family                    = "ipv4"
@@ -61,3 +143,55 @@ data "aws_vpc_ipam_pool" "test" {
   ipam_pool_id = aws_vpc_ipam_pool.test.id
 }
 `)
+
+var testAccIPAMPoolDataSourceConfig_publicScope = acctest.ConfigCompose(testAccIPAMPoolConfig_ipv6, `
+data "aws_vpc_ipam_pool" "test" {
+  ipam_pool_id = aws_vpc_ipam_pool.test.id
+}
+`)
+
+var testAccIPAMPoolDataSourceConfig_addressFamilyBase = acctest.ConfigCompose(testAccIPAMPoolConfig_base, `
+resource "aws_vpc_ipam_pool" "ipv4" {
+  address_family = "ipv4"
+  ipam_scope_id  = aws_vpc_ipam.test.public_default_scope_id
+  locale         = data.aws_region.current.name
+}
+
+resource "aws_vpc_ipam_pool" "ipv6" {
+  address_family        = "ipv6"
+  ipam_scope_id         = aws_vpc_ipam.test.public_default_scope_id
+  locale                = data.aws_region.current.name
+  publicly_advertisable = false
+}
+`)
+
+var testAccIPAMPoolDataSourceConfig_addressFamily = acctest.ConfigCompose(testAccIPAMPoolDataSourceConfig_addressFamilyBase, `
+data "aws_vpc_ipam_pool" "ipv4" {
+  address_family = "ipv4"
+  ipam_scope_id  = aws_vpc_ipam.test.public_default_scope_id
+
+  depends_on = [aws_vpc_ipam_pool.ipv4, aws_vpc_ipam_pool.ipv6]
+}
+
+data "aws_vpc_ipam_pool" "ipv6" {
+  address_family = "ipv6"
+  ipam_scope_id  = aws_vpc_ipam.test.public_default_scope_id
+
+  depends_on = [aws_vpc_ipam_pool.ipv4, aws_vpc_ipam_pool.ipv6]
+}
+`)
+
+var testAccIPAMPoolDataSourceConfig_addressFamilyAmbiguous = acctest.ConfigCompose(testAccIPAMPoolDataSourceConfig_addressFamilyBase, `
+resource "aws_vpc_ipam_pool" "ipv4_other" {
+  address_family = "ipv4"
+  ipam_scope_id  = aws_vpc_ipam.test.public_default_scope_id
+  locale         = data.aws_region.current.name
+}
+
+data "aws_vpc_ipam_pool" "test" {
+  address_family = "ipv4"
+  ipam_scope_id  = aws_vpc_ipam.test.public_default_scope_id
+
+  depends_on = [aws_vpc_ipam_pool.ipv4, aws_vpc_ipam_pool.ipv4_other]
+}
+`)
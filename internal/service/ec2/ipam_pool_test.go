@@ -3,21 +3,1352 @@ package ec2_test
 import (
 	"context"
 	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
 	"testing"
+	"time"
 
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/hashicorp/terraform-provider-aws/internal/acctest"
 	"github.com/hashicorp/terraform-provider-aws/internal/conns"
 	tfec2 "github.com/hashicorp/terraform-provider-aws/internal/service/ec2"
+	tftags "github.com/hashicorp/terraform-provider-aws/internal/tags"
 	"github.com/hashicorp/terraform-provider-aws/internal/tfresource"
 )
 
+type testIPAMPoolChanges map[string]bool
+
+func (c testIPAMPoolChanges) HasChange(k string) bool {
+	return c[k]
+}
+
+func TestIPAMAllocationResourceTagsRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	want := map[string]string{
+		"Environment": "test",
+		"Name":        "pool",
+		"empty":       "",
+		"team":        "networking",
+	}
+
+	requestTags := tfec2.IPAMResourceTags(tftags.New(want))
+
+	var keys []string
+	var responseTags []*ec2.IpamResourceTag
+	for _, tag := range requestTags {
+		keys = append(keys, aws.StringValue(tag.Key))
+		responseTags = append(responseTags, &ec2.IpamResourceTag{
+			Key:   tag.Key,
+			Value: tag.Value,
+		})
+	}
+
+	if want := []string{"Environment", "Name", "empty", "team"}; !reflect.DeepEqual(keys, want) {
+		t.Errorf("got request tag keys %v, expected %v", keys, want)
+	}
+
+	// Keys without a value are returned with a nil value and malformed entries are dropped.
+	responseTags = append(responseTags, nil, &ec2.IpamResourceTag{Value: aws.String("no-key")})
+	responseTags[2].Value = nil
+
+	if got := tfec2.KeyValueTags(tfec2.TagsFromIPAMAllocationTags(responseTags)).Map(); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, expected %v", got, want)
+	}
+}
+
+func TestIPAMPoolAllocationResourceTagsDefaultTags(t *testing.T) {
+	t.Parallel()
+
+	defaultTagsConfig := &tftags.DefaultConfig{
+		Tags: tftags.New(map[string]interface{}{
+			"default": "value",
+			"team":    "default",
+		}),
+	}
+
+	d := schema.TestResourceDataRaw(t, tfec2.ResourceIPAMPool().Schema, map[string]interface{}{
+		"allocation_resource_tags": map[string]interface{}{
+			"aws:reserved": "value",
+			"team":         "networking",
+		},
+		"tags": map[string]interface{}{
+			"Name": "pool",
+		},
+	})
+
+	// Default tags apply to the pool, not to the resources allocated from it.
+	if got, want := tfec2.KeyValueTags(tfec2.IPAMTagSpecifications(d, defaultTagsConfig, ec2.ResourceTypeIpamPool)[0].Tags).Map(), map[string]string{
+		"Name":    "pool",
+		"default": "value",
+		"team":    "default",
+	}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got pool tags %v, expected %v", got, want)
+	}
+
+	var got []string
+	for _, tag := range tfec2.ExpandIPAMPoolAllocationResourceTags(d) {
+		got = append(got, fmt.Sprintf("%s=%s", aws.StringValue(tag.Key), aws.StringValue(tag.Value)))
+	}
+
+	if want := []string{"team=networking"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got allocation resource tags %v, expected %v", got, want)
+	}
+
+	ignoreTagsConfig := &tftags.IgnoreConfig{
+		Keys:        tftags.New([]interface{}{"ignored"}),
+		KeyPrefixes: tftags.New([]interface{}{"ignored:"}),
+	}
+
+	apiObjects := []*ec2.IpamResourceTag{
+		{Key: aws.String("aws:reserved"), Value: aws.String("value")},
+		{Key: aws.String("default"), Value: aws.String("value")},
+		{Key: aws.String("ignored"), Value: aws.String("value")},
+		{Key: aws.String("ignored:prefix"), Value: aws.String("value")},
+		{Key: aws.String("team"), Value: aws.String("networking")},
+	}
+
+	// A required allocation tag matching a default tag is kept, as it was configured on the pool.
+	if got, want := tfec2.FlattenIPAMPoolAllocationResourceTags(apiObjects, ignoreTagsConfig), map[string]string{
+		"default": "value",
+		"team":    "networking",
+	}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got allocation resource tags %v, expected %v", got, want)
+	}
+}
+
+func TestCreateIPAMPool(t *testing.T) {
+	ctx := context.Background()
+	t.Parallel()
+
+	sess, err := session.NewSession(nil)
+	if err != nil {
+		t.Fatalf("Error creating AWS session: %s", err)
+	}
+
+	throttlingErr := awserr.New("RequestLimitExceeded", "Request limit exceeded.", nil)
+
+	testCases := []struct {
+		Name          string
+		Errs          []error
+		ExpectedCalls int
+		ExpectError   *regexp.Regexp
+	}{
+		{
+			Name:          "success",
+			ExpectedCalls: 1,
+		},
+		{
+			Name:          "throttled",
+			Errs:          []error{throttlingErr, throttlingErr},
+			ExpectedCalls: 3,
+		},
+		{
+			Name:          "other error",
+			Errs:          []error{awserr.New("InvalidParameterValue", "The allocation default netmask length is invalid.", nil)},
+			ExpectedCalls: 1,
+			ExpectError:   regexp.MustCompile(`netmask length is invalid`),
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.Name, func(t *testing.T) {
+			t.Parallel()
+
+			var calls int
+			tokens := make(map[string]bool)
+			conn := ec2.New(sess)
+			conn.Handlers.Clear()
+			conn.Handlers.Send.PushBack(func(r *request.Request) {
+				input := r.Params.(*ec2.CreateIpamPoolInput)
+				tokens[aws.StringValue(input.ClientToken)] = true
+				calls++
+
+				if calls <= len(testCase.Errs) {
+					r.Error = testCase.Errs[calls-1]
+					return
+				}
+
+				r.Data.(*ec2.CreateIpamPoolOutput).IpamPool = &ec2.IpamPool{
+					IpamPoolId: aws.String("ipam-pool-12345678"),
+				}
+			})
+
+			input := &ec2.CreateIpamPoolInput{
+				AddressFamily: aws.String(ec2.AddressFamilyIpv4),
+				ClientToken:   aws.String("token"),
+				IpamScopeId:   aws.String("ipam-scope-12345678"),
+			}
+
+			output, err := tfec2.CreateIPAMPool(ctx, conn, input, 1*time.Minute)
+
+			if calls != testCase.ExpectedCalls {
+				t.Errorf("got %d CreateIpamPool calls, expected %d", calls, testCase.ExpectedCalls)
+			}
+
+			if len(tokens) != 1 || !tokens["token"] {
+				t.Errorf("got client tokens %v, expected only %q", tokens, "token")
+			}
+
+			if testCase.ExpectError != nil {
+				if err == nil || !testCase.ExpectError.MatchString(err.Error()) {
+					t.Fatalf("got error %v, expected %s", err, testCase.ExpectError)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if got, want := aws.StringValue(output.IpamPool.IpamPoolId), "ipam-pool-12345678"; got != want {
+				t.Errorf("got IPAM Pool ID %q, expected %q", got, want)
+			}
+		})
+	}
+}
+
+func TestCreateIPAMPoolWithTagsFallback(t *testing.T) {
+	ctx := context.Background()
+	t.Parallel()
+
+	sess, err := session.NewSession(nil)
+	if err != nil {
+		t.Fatalf("Error creating AWS session: %s", err)
+	}
+
+	unauthorizedErr := awserr.New("UnauthorizedOperation", "You are not authorized to perform this operation. Encoded authorization failure message: abc123", nil)
+	tagSpecifications := []*ec2.TagSpecification{{
+		ResourceType: aws.String(ec2.ResourceTypeIpamPool),
+		Tags:         []*ec2.Tag{{Key: aws.String("Name"), Value: aws.String("test")}},
+	}}
+
+	testCases := []struct {
+		Name              string
+		TagSpecifications []*ec2.TagSpecification
+		Errs              []error
+		ExpectedTagged    []bool
+		ExpectedUntagged  bool
+		ExpectError       *regexp.Regexp
+	}{
+		{
+			Name:              "tagged",
+			TagSpecifications: tagSpecifications,
+			ExpectedTagged:    []bool{true},
+		},
+		{
+			Name:              "tagging unauthorized",
+			TagSpecifications: tagSpecifications,
+			Errs:              []error{unauthorizedErr},
+			ExpectedTagged:    []bool{true, false},
+			ExpectedUntagged:  true,
+		},
+		{
+			Name:              "create unauthorized",
+			TagSpecifications: tagSpecifications,
+			Errs:              []error{unauthorizedErr, unauthorizedErr},
+			ExpectedTagged:    []bool{true, false},
+			ExpectError:       regexp.MustCompile(`UnauthorizedOperation`),
+		},
+		{
+			Name:           "untagged create unauthorized",
+			Errs:           []error{unauthorizedErr},
+			ExpectedTagged: []bool{false},
+			ExpectError:    regexp.MustCompile(`UnauthorizedOperation`),
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.Name, func(t *testing.T) {
+			t.Parallel()
+
+			var tagged []bool
+			tokens := make(map[string]bool)
+			conn := ec2.New(sess)
+			conn.Handlers.Clear()
+			conn.Handlers.Send.PushBack(func(r *request.Request) {
+				input := r.Params.(*ec2.CreateIpamPoolInput)
+				tokens[aws.StringValue(input.ClientToken)] = true
+				tagged = append(tagged, len(input.TagSpecifications) > 0)
+
+				if len(tagged) <= len(testCase.Errs) {
+					r.Error = testCase.Errs[len(tagged)-1]
+					return
+				}
+
+				r.Data.(*ec2.CreateIpamPoolOutput).IpamPool = &ec2.IpamPool{
+					IpamPoolId: aws.String("ipam-pool-12345678"),
+				}
+			})
+
+			input := &ec2.CreateIpamPoolInput{
+				AddressFamily:     aws.String(ec2.AddressFamilyIpv4),
+				ClientToken:       aws.String("token"),
+				IpamScopeId:       aws.String("ipam-scope-12345678"),
+				TagSpecifications: testCase.TagSpecifications,
+			}
+
+			output, untagged, err := tfec2.CreateIPAMPoolWithTagsFallback(ctx, conn, input, 1*time.Minute)
+
+			if !reflect.DeepEqual(tagged, testCase.ExpectedTagged) {
+				t.Errorf("got CreateIpamPool calls with tags %v, expected %v", tagged, testCase.ExpectedTagged)
+			}
+
+			// A create retried without tags has different parameters, so it can't reuse the client token.
+			if len(tokens) != len(testCase.ExpectedTagged) {
+				t.Errorf("got %d client tokens for %d CreateIpamPool calls", len(tokens), len(testCase.ExpectedTagged))
+			}
+
+			if testCase.ExpectError != nil {
+				if err == nil || !testCase.ExpectError.MatchString(err.Error()) {
+					t.Fatalf("got error %v, expected %s", err, testCase.ExpectError)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if untagged != testCase.ExpectedUntagged {
+				t.Errorf("got untagged %t, expected %t", untagged, testCase.ExpectedUntagged)
+			}
+
+			if got, want := aws.StringValue(output.IpamPool.IpamPoolId), "ipam-pool-12345678"; got != want {
+				t.Errorf("got IPAM Pool ID %q, expected %q", got, want)
+			}
+		})
+	}
+}
+
+func TestSetIPAMPoolAllocationNetmaskLengths(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		Name     string
+		Pool     *ec2.IpamPool
+		Expected map[string]int
+	}{
+		{
+			Name: "configured",
+			Pool: &ec2.IpamPool{
+				AllocationDefaultNetmaskLength: aws.Int64(24),
+				AllocationMaxNetmaskLength:     aws.Int64(28),
+				AllocationMinNetmaskLength:     aws.Int64(16),
+			},
+			Expected: map[string]int{
+				"allocation_default_netmask_length": 24,
+				"allocation_max_netmask_length":     28,
+				"allocation_min_netmask_length":     16,
+			},
+		},
+		{
+			Name: "address family policy without default",
+			Pool: &ec2.IpamPool{
+				AllocationMaxNetmaskLength: aws.Int64(32),
+				AllocationMinNetmaskLength: aws.Int64(0),
+			},
+			Expected: map[string]int{
+				"allocation_default_netmask_length": 0,
+				"allocation_max_netmask_length":     32,
+				"allocation_min_netmask_length":     0,
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.Name, func(t *testing.T) {
+			t.Parallel()
+
+			for name, r := range map[string]*schema.Resource{
+				"resource":    tfec2.ResourceIPAMPool(),
+				"data source": tfec2.DataSourceIPAMPool(),
+			} {
+				d := r.TestResourceData()
+
+				tfec2.SetIPAMPoolAllocationNetmaskLengths(d, testCase.Pool)
+
+				for k, want := range testCase.Expected {
+					if got := d.Get(k).(int); got != want {
+						t.Errorf("%s: got %s %d, expected %d", name, k, got, want)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestIPAMPoolAllocationCount(t *testing.T) {
+	ctx := context.Background()
+	t.Parallel()
+
+	sess, err := session.NewSession(nil)
+	if err != nil {
+		t.Fatalf("Error creating AWS session: %s", err)
+	}
+
+	pages := map[string]map[string]*ec2.GetIpamPoolAllocationsOutput{
+		"ipam-pool-11111111": {
+			"": {},
+		},
+		"ipam-pool-22222222": {
+			"": {
+				IpamPoolAllocations: []*ec2.IpamPoolAllocation{
+					{IpamPoolAllocationId: aws.String("ipam-pool-alloc-1")},
+					{IpamPoolAllocationId: aws.String("ipam-pool-alloc-2")},
+				},
+				NextToken: aws.String("page2"),
+			},
+			"page2": {
+				IpamPoolAllocations: []*ec2.IpamPoolAllocation{
+					{IpamPoolAllocationId: aws.String("ipam-pool-alloc-3")},
+				},
+			},
+		},
+	}
+
+	testCases := []struct {
+		Name          string
+		PoolID        string
+		Expected      int
+		ExpectedCalls int
+	}{
+		{
+			Name:          "no allocations",
+			PoolID:        "ipam-pool-11111111",
+			ExpectedCalls: 1,
+		},
+		{
+			Name:          "several allocations",
+			PoolID:        "ipam-pool-22222222",
+			Expected:      3,
+			ExpectedCalls: 2,
+		},
+		{
+			Name:          "pool not found",
+			PoolID:        "ipam-pool-33333333",
+			ExpectedCalls: 1,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.Name, func(t *testing.T) {
+			t.Parallel()
+
+			var calls int
+			conn := ec2.New(sess)
+			conn.Handlers.Clear()
+			conn.Handlers.Send.PushBack(func(r *request.Request) {
+				input := r.Params.(*ec2.GetIpamPoolAllocationsInput)
+				calls++
+
+				if got, want := aws.Int64Value(input.MaxResults), int64(1000); got != want {
+					t.Errorf("got MaxResults %d, expected %d", got, want)
+				}
+
+				page, ok := pages[aws.StringValue(input.IpamPoolId)][aws.StringValue(input.NextToken)]
+				if !ok {
+					r.Error = awserr.New("InvalidIpamPoolId.NotFound", "The pool ID does not exist", nil)
+					return
+				}
+
+				*r.Data.(*ec2.GetIpamPoolAllocationsOutput) = *page
+			})
+
+			got, err := tfec2.IPAMPoolAllocationCount(ctx, conn, testCase.PoolID)
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if got != testCase.Expected {
+				t.Errorf("got %d allocations, expected %d", got, testCase.Expected)
+			}
+
+			if calls != testCase.ExpectedCalls {
+				t.Errorf("got %d GetIpamPoolAllocations calls, expected %d", calls, testCase.ExpectedCalls)
+			}
+		})
+	}
+}
+
+func TestModifyIPAMPool(t *testing.T) {
+	ctx := context.Background()
+	t.Parallel()
+
+	poolID := "ipam-pool-12345678"
+
+	sess, err := session.NewSession(nil)
+	if err != nil {
+		t.Fatalf("Error creating AWS session: %s", err)
+	}
+
+	d := schema.TestResourceDataRaw(t, tfec2.ResourceIPAMPool().Schema, map[string]interface{}{
+		"allocation_default_netmask_length": 24,
+		"allocation_max_netmask_length":     28,
+		"allocation_resource_tags": map[string]interface{}{
+			"team": "networking",
+		},
+		"auto_import": true,
+		"description": "updated",
+	})
+	d.SetId(poolID)
+
+	var inputs []*ec2.ModifyIpamPoolInput
+	conn := ec2.New(sess)
+	conn.Handlers.Clear()
+	conn.Handlers.Send.PushBack(func(r *request.Request) {
+		switch data := r.Data.(type) {
+		case *ec2.ModifyIpamPoolOutput:
+			inputs = append(inputs, r.Params.(*ec2.ModifyIpamPoolInput))
+		case *ec2.DescribeIpamPoolsOutput:
+			data.IpamPools = []*ec2.IpamPool{{
+				IpamPoolId: aws.String(poolID),
+				State:      aws.String(ec2.IpamPoolStateModifyComplete),
+			}}
+		}
+	})
+
+	if err := tfec2.ModifyIPAMPool(ctx, conn, tfec2.ExpandModifyIPAMPoolInput(d), 1*time.Minute); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// The scalar changes and the allocation resource tags are sent in the same call.
+	if got, want := len(inputs), 1; got != want {
+		t.Fatalf("got %d ModifyIpamPool calls, expected %d", got, want)
+	}
+
+	input := inputs[0]
+
+	if got, want := aws.StringValue(input.IpamPoolId), poolID; got != want {
+		t.Errorf("got IPAM Pool ID %q, expected %q", got, want)
+	}
+
+	if got, want := aws.Int64Value(input.AllocationDefaultNetmaskLength), int64(24); got != want {
+		t.Errorf("got allocation default netmask length %d, expected %d", got, want)
+	}
+
+	if got, want := aws.Int64Value(input.AllocationMaxNetmaskLength), int64(28); got != want {
+		t.Errorf("got allocation max netmask length %d, expected %d", got, want)
+	}
+
+	if got, want := aws.BoolValue(input.AutoImport), true; got != want {
+		t.Errorf("got auto import %t, expected %t", got, want)
+	}
+
+	if got, want := aws.StringValue(input.Description), "updated"; got != want {
+		t.Errorf("got description %q, expected %q", got, want)
+	}
+
+	if got, want := len(input.AddAllocationResourceTags), 1; got != want {
+		t.Fatalf("got %d allocation resource tags to add, expected %d", got, want)
+	}
+
+	if got, want := aws.StringValue(input.AddAllocationResourceTags[0].Key), "team"; got != want {
+		t.Errorf("got allocation resource tag key %q, expected %q", got, want)
+	}
+}
+
+func TestExpandModifyIPAMPoolInputAllocationDefaultNetmaskLength(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		Name          string
+		Old           string
+		New           interface{}
+		ExpectedValue *int64
+		ExpectedClear *bool
+	}{
+		{
+			Name:          "set",
+			New:           24,
+			ExpectedValue: aws.Int64(24),
+		},
+		{
+			Name:          "change",
+			Old:           "24",
+			New:           28,
+			ExpectedValue: aws.Int64(28),
+		},
+		{
+			Name:          "clear",
+			Old:           "24",
+			ExpectedClear: aws.Bool(true),
+		},
+		{
+			Name: "unchanged",
+			Old:  "24",
+			New:  24,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.Name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			sm := schema.InternalMap(tfec2.ResourceIPAMPool().Schema)
+
+			state := &terraform.InstanceState{
+				ID:         "ipam-pool-12345678",
+				Attributes: map[string]string{},
+			}
+			if testCase.Old != "" {
+				state.Attributes["allocation_default_netmask_length"] = testCase.Old
+			}
+
+			raw := map[string]interface{}{}
+			if testCase.New != nil {
+				raw["allocation_default_netmask_length"] = testCase.New
+			}
+
+			diff, err := sm.Diff(ctx, state, terraform.NewResourceConfigRaw(raw), nil, nil, true)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			d, err := sm.Data(state, diff)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			input := tfec2.ExpandModifyIPAMPoolInput(d)
+
+			if got, want := input.AllocationDefaultNetmaskLength, testCase.ExpectedValue; !reflect.DeepEqual(got, want) {
+				t.Errorf("got allocation default netmask length %v, expected %v", aws.Int64Value(got), aws.Int64Value(want))
+			}
+
+			if got, want := input.ClearAllocationDefaultNetmaskLength, testCase.ExpectedClear; !reflect.DeepEqual(got, want) {
+				t.Errorf("got clear allocation default netmask length %v, expected %v", aws.BoolValue(got), aws.BoolValue(want))
+			}
+		})
+	}
+}
+
+func TestWaitIPAMPoolStable(t *testing.T) {
+	ctx := context.Background()
+	t.Parallel()
+
+	poolID := "ipam-pool-12345678"
+
+	sess, err := session.NewSession(nil)
+	if err != nil {
+		t.Fatalf("Error creating AWS session: %s", err)
+	}
+
+	testCases := []struct {
+		Name          string
+		States        []string
+		ExpectedState string
+	}{
+		{
+			Name:          "modify completes",
+			States:        []string{ec2.IpamPoolStateModifyInProgress, ec2.IpamPoolStateModifyInProgress, ec2.IpamPoolStateModifyComplete},
+			ExpectedState: ec2.IpamPoolStateModifyComplete,
+		},
+		{
+			Name:          "modify fails",
+			States:        []string{ec2.IpamPoolStateModifyInProgress, ec2.IpamPoolStateModifyFailed},
+			ExpectedState: ec2.IpamPoolStateModifyFailed,
+		},
+		{
+			Name:          "create completes",
+			States:        []string{ec2.IpamPoolStateCreateInProgress, ec2.IpamPoolStateCreateComplete},
+			ExpectedState: ec2.IpamPoolStateCreateComplete,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.Name, func(t *testing.T) {
+			t.Parallel()
+
+			conn := ec2.New(sess)
+			conn.Handlers.Clear()
+
+			calls := 0
+			conn.Handlers.Send.PushBack(func(r *request.Request) {
+				data := r.Data.(*ec2.DescribeIpamPoolsOutput)
+				state := testCase.States[len(testCase.States)-1]
+				if calls < len(testCase.States) {
+					state = testCase.States[calls]
+				}
+				calls++
+
+				data.IpamPools = []*ec2.IpamPool{{
+					IpamPoolId: aws.String(poolID),
+					State:      aws.String(state),
+				}}
+			})
+
+			pool, err := tfec2.WaitIPAMPoolStable(ctx, conn, poolID, 1*time.Minute)
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if got, want := aws.StringValue(pool.State), testCase.ExpectedState; got != want {
+				t.Errorf("got state %q, expected %q", got, want)
+			}
+
+			if got, want := calls, len(testCase.States); got != want {
+				t.Errorf("got %d DescribeIpamPools calls, expected %d", got, want)
+			}
+		})
+	}
+}
+
+func TestValidateIPAMPoolLocale(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	ipamID, scopeID := "ipam-12345678", "ipam-scope-12345678"
+
+	sess, err := session.NewSession(nil)
+	if err != nil {
+		t.Fatalf("Error creating AWS session: %s", err)
+	}
+
+	conn := ec2.New(sess)
+	conn.Handlers.Clear()
+	conn.Handlers.Send.PushBack(func(r *request.Request) {
+		switch data := r.Data.(type) {
+		case *ec2.DescribeIpamScopesOutput:
+			data.IpamScopes = []*ec2.IpamScope{{
+				IpamArn:     aws.String("arn:aws:ec2::123456789012:ipam/" + ipamID), //lintignore:AWSAT005
+				IpamScopeId: aws.String(scopeID),
+			}}
+		case *ec2.DescribeIpamsOutput:
+			data.Ipams = []*ec2.Ipam{{
+				IpamId: aws.String(ipamID),
+				OperatingRegions: []*ec2.IpamOperatingRegion{
+					{RegionName: aws.String("us-west-2")}, //lintignore:AWSAT003
+					{RegionName: aws.String("us-east-1")}, //lintignore:AWSAT003
+				},
+			}}
+		}
+	})
+
+	testCases := []struct {
+		Locale      string
+		ExpectError bool
+	}{
+		{
+			Locale: "us-west-2", //lintignore:AWSAT003
+		},
+		{
+			Locale: "us-east-1", //lintignore:AWSAT003
+		},
+		{
+			Locale:      "eu-west-1", //lintignore:AWSAT003
+			ExpectError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.Locale, func(t *testing.T) {
+			t.Parallel()
+
+			err := tfec2.ValidateIPAMPoolLocale(ctx, conn, testCase.Locale, scopeID)
+
+			if err == nil && testCase.ExpectError {
+				t.Fatal("expected error, got none")
+			}
+
+			if err != nil && !testCase.ExpectError {
+				t.Fatalf("unexpected error: %s", err)
+			}
+		})
+	}
+}
+
+func TestValidateIPAMPoolPubliclyAdvertisable(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	sess, err := session.NewSession(nil)
+	if err != nil {
+		t.Fatalf("Error creating AWS session: %s", err)
+	}
+
+	scopeTypes := map[string]string{
+		"ipam-scope-11111111": ec2.IpamScopeTypePublic,
+		"ipam-scope-22222222": ec2.IpamScopeTypePrivate,
+	}
+
+	conn := ec2.New(sess)
+	conn.Handlers.Clear()
+	conn.Handlers.Send.PushBack(func(r *request.Request) {
+		input := r.Params.(*ec2.DescribeIpamScopesInput)
+		data := r.Data.(*ec2.DescribeIpamScopesOutput)
+
+		id := aws.StringValue(input.IpamScopeIds[0])
+		if scopeType, ok := scopeTypes[id]; ok {
+			data.IpamScopes = []*ec2.IpamScope{{
+				IpamScopeId:   aws.String(id),
+				IpamScopeType: aws.String(scopeType),
+			}}
+		}
+	})
+
+	testCases := []struct {
+		Name        string
+		ScopeID     string
+		Locale      string
+		ExpectError *regexp.Regexp
+	}{
+		{
+			Name:    "public",
+			ScopeID: "ipam-scope-11111111",
+			Locale:  "us-west-2", //lintignore:AWSAT003
+		},
+		{
+			Name:        "public without locale",
+			ScopeID:     "ipam-scope-11111111",
+			Locale:      "None",
+			ExpectError: regexp.MustCompile("`locale` must be set to a Region for publicly advertisable pools"),
+		},
+		{
+			Name:    "public with unknown locale",
+			ScopeID: "ipam-scope-11111111",
+		},
+		{
+			Name:        "private",
+			ScopeID:     "ipam-scope-22222222",
+			Locale:      "us-west-2", //lintignore:AWSAT003
+			ExpectError: regexp.MustCompile(`IPAM Scope \(ipam-scope-22222222\) is private`),
+		},
+		{
+			Name:        "not found",
+			ScopeID:     "ipam-scope-33333333",
+			Locale:      "us-west-2", //lintignore:AWSAT003
+			ExpectError: regexp.MustCompile(`reading IPAM Scope \(ipam-scope-33333333\)`),
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.Name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tfec2.ValidateIPAMPoolPubliclyAdvertisable(ctx, conn, testCase.ScopeID, testCase.Locale)
+
+			if testCase.ExpectError == nil {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+
+				return
+			}
+
+			if err == nil {
+				t.Fatal("expected error, got none")
+			}
+
+			if !testCase.ExpectError.MatchString(err.Error()) {
+				t.Errorf("got error %q, expected to match %q", err, testCase.ExpectError)
+			}
+		})
+	}
+}
+
+func TestDeprovisionIPAMPoolCIDRs(t *testing.T) {
+	ctx := context.Background()
+	t.Parallel()
+
+	poolID := "ipam-pool-12345678"
+
+	sess, err := session.NewSession(nil)
+	if err != nil {
+		t.Fatalf("Error creating AWS session: %s", err)
+	}
+
+	testCases := []struct {
+		Name                    string
+		States                  map[string]string
+		IncorrectState          map[string]bool
+		PoolNotFound            bool
+		ExpectedDeprovisionings []string
+	}{
+		{
+			Name: "multiple CIDRs",
+			States: map[string]string{
+				"10.0.0.0/24": ec2.IpamPoolCidrStateProvisioned,
+				"10.1.0.0/16": ec2.IpamPoolCidrStateProvisioned,
+				"10.2.0.0/16": ec2.IpamPoolCidrStateProvisioned,
+			},
+			ExpectedDeprovisionings: []string{"10.0.0.0/24", "10.1.0.0/16", "10.2.0.0/16"},
+		},
+		{
+			Name: "already deprovisioning",
+			States: map[string]string{
+				"10.0.0.0/24": ec2.IpamPoolCidrStatePendingDeprovision,
+				"10.1.0.0/16": ec2.IpamPoolCidrStateProvisioned,
+				"10.2.0.0/16": ec2.IpamPoolCidrStateDeprovisioned,
+			},
+			ExpectedDeprovisionings: []string{"10.1.0.0/16"},
+		},
+		{
+			Name: "deprovisioned concurrently",
+			States: map[string]string{
+				"10.0.0.0/24": ec2.IpamPoolCidrStateProvisioned,
+				"10.1.0.0/16": ec2.IpamPoolCidrStateProvisioned,
+			},
+			IncorrectState:          map[string]bool{"10.0.0.0/24": true},
+			ExpectedDeprovisionings: []string{"10.0.0.0/24", "10.1.0.0/16"},
+		},
+		{
+			Name:         "pool not found",
+			PoolNotFound: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.Name, func(t *testing.T) {
+			t.Parallel()
+
+			states := make(map[string]string)
+			for k, v := range testCase.States {
+				states[k] = v
+			}
+			var deprovisionings []string
+
+			conn := ec2.New(sess)
+			conn.Handlers.Clear()
+			conn.Handlers.Send.PushBack(func(r *request.Request) {
+				if testCase.PoolNotFound {
+					r.Error = awserr.New("InvalidIpamPoolId.NotFound", "not found", nil)
+					return
+				}
+
+				switch input := r.Params.(type) {
+				case *ec2.GetIpamPoolCidrsInput:
+					data := r.Data.(*ec2.GetIpamPoolCidrsOutput)
+
+					var cidrs []string
+					if len(input.Filters) > 0 {
+						cidrs = aws.StringValueSlice(input.Filters[0].Values)
+					} else {
+						for cidr := range states {
+							cidrs = append(cidrs, cidr)
+						}
+						sort.Strings(cidrs)
+					}
+
+					for _, cidr := range cidrs {
+						state := states[cidr]
+						data.IpamPoolCidrs = append(data.IpamPoolCidrs, &ec2.IpamPoolCidr{
+							Cidr:  aws.String(cidr),
+							State: aws.String(state),
+						})
+
+						// Each describe of a CIDR being deprovisioned moves it along.
+						if len(input.Filters) > 0 && state == ec2.IpamPoolCidrStatePendingDeprovision {
+							states[cidr] = ec2.IpamPoolCidrStateDeprovisioned
+						}
+					}
+				case *ec2.DeprovisionIpamPoolCidrInput:
+					cidr := aws.StringValue(input.Cidr)
+					deprovisionings = append(deprovisionings, cidr)
+
+					if testCase.IncorrectState[cidr] || states[cidr] != ec2.IpamPoolCidrStateProvisioned {
+						states[cidr] = ec2.IpamPoolCidrStateDeprovisioned
+						r.Error = awserr.New("IncorrectState", "The CIDR is not in a state that can be deprovisioned", nil)
+						return
+					}
+
+					states[cidr] = ec2.IpamPoolCidrStatePendingDeprovision
+				}
+			})
+
+			if err := tfec2.DeprovisionIPAMPoolCIDRs(ctx, conn, poolID, 1*time.Minute); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if got, want := deprovisionings, testCase.ExpectedDeprovisionings; !reflect.DeepEqual(got, want) {
+				t.Errorf("got deprovisionings %v, expected %v", got, want)
+			}
+
+			for cidr, state := range states {
+				if state != ec2.IpamPoolCidrStateDeprovisioned {
+					t.Errorf("CIDR %s: got state %q, expected %q", cidr, state, ec2.IpamPoolCidrStateDeprovisioned)
+				}
+			}
+		})
+	}
+}
+
 func TestAccIPAMPool_basic(t *testing.T) {
 	ctx := acctest.Context(t)
-	var pool ec2.IpamPool
-	resourceName := "aws_vpc_ipam_pool.test"
+	var pool ec2.IpamPool
+	resourceName := "aws_vpc_ipam_pool.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ErrorCheck:               acctest.ErrorCheck(t, ec2.EndpointsID),
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckIPAMPoolDestroy(ctx),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccIPAMPoolConfig_basic,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckIPAMPoolExists(ctx, resourceName, &pool),
+					resource.TestCheckResourceAttr(resourceName, "address_family", "ipv4"),
+					resource.TestCheckResourceAttr(resourceName, "allocation_count", "0"),
+					resource.TestCheckNoResourceAttr(resourceName, "allocation_default_netmask_length"),
+					resource.TestCheckResourceAttrSet(resourceName, "allocation_max_netmask_length"),
+					resource.TestCheckResourceAttrSet(resourceName, "allocation_min_netmask_length"),
+					resource.TestCheckResourceAttr(resourceName, "allocation_resource_tags.%", "0"),
+					resource.TestCheckResourceAttrSet(resourceName, "arn"),
+					resource.TestCheckResourceAttr(resourceName, "auto_import", "false"),
+					resource.TestCheckResourceAttr(resourceName, "aws_service", ""),
+					resource.TestCheckResourceAttr(resourceName, "description", ""),
+					acctest.MatchResourceAttrGlobalARN(resourceName, "ipam_scope_arn", "ec2", regexp.MustCompile(`ipam-scope/ipam-scope-[0-9a-f]+$`)),
+					resource.TestCheckResourceAttrSet(resourceName, "ipam_scope_type"),
+					resource.TestCheckResourceAttr(resourceName, "locale", "None"),
+					resource.TestCheckResourceAttrSet(resourceName, "pool_depth"),
+					resource.TestCheckResourceAttr(resourceName, "provisioned_cidrs.#", "0"),
+					resource.TestCheckResourceAttr(resourceName, "publicly_advertisable", "false"),
+					resource.TestCheckResourceAttr(resourceName, "state", "create-complete"),
+					resource.TestCheckResourceAttr(resourceName, "tags.%", "0"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccIPAMPoolConfig_updated,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckIPAMPoolExists(ctx, resourceName, &pool),
+					resource.TestCheckResourceAttr(resourceName, "address_family", "ipv4"),
+					resource.TestCheckResourceAttr(resourceName, "allocation_default_netmask_length", "32"),
+					resource.TestCheckResourceAttr(resourceName, "allocation_max_netmask_length", "32"),
+					resource.TestCheckResourceAttr(resourceName, "allocation_min_netmask_length", "32"),
+					resource.TestCheckResourceAttr(resourceName, "allocation_resource_tags.%", "1"),
+					resource.TestCheckResourceAttr(resourceName, "allocation_resource_tags.test", "1"),
+					resource.TestCheckResourceAttrSet(resourceName, "arn"),
+					resource.TestCheckResourceAttr(resourceName, "auto_import", "true"),
+					resource.TestCheckResourceAttr(resourceName, "aws_service", ""),
+					resource.TestCheckResourceAttr(resourceName, "description", "test"),
+					resource.TestCheckResourceAttrSet(resourceName, "ipam_scope_type"),
+					resource.TestCheckResourceAttr(resourceName, "locale", "None"),
+					resource.TestCheckResourceAttrSet(resourceName, "pool_depth"),
+					resource.TestCheckResourceAttr(resourceName, "publicly_advertisable", "false"),
+					resource.TestCheckResourceAttr(resourceName, "state", "modify-complete"),
+					resource.TestCheckResourceAttr(resourceName, "tags.%", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccIPAMPool_disappears(t *testing.T) {
+	ctx := acctest.Context(t)
+	var pool ec2.IpamPool
+	resourceName := "aws_vpc_ipam_pool.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ErrorCheck:               acctest.ErrorCheck(t, ec2.EndpointsID),
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckIPAMPoolDestroy(ctx),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccIPAMPoolConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIPAMPoolExists(ctx, resourceName, &pool),
+					acctest.CheckResourceDisappears(ctx, acctest.Provider, tfec2.ResourceIPAMPool(), resourceName),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestAccIPAMPool_ipv6Basic(t *testing.T) {
+	ctx := acctest.Context(t)
+	var pool ec2.IpamPool
+	resourceName := "aws_vpc_ipam_pool.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ErrorCheck:               acctest.ErrorCheck(t, ec2.EndpointsID),
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckIPAMPoolDestroy(ctx),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccIPAMPoolConfig_ipv6,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIPAMPoolExists(ctx, resourceName, &pool),
+					resource.TestCheckResourceAttr(resourceName, "address_family", "ipv6"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccIPAMPool_publicIPSource(t *testing.T) {
+	ctx := acctest.Context(t)
+	var pool1, pool2 ec2.IpamPool
+	resourceName := "aws_vpc_ipam_pool.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ErrorCheck:               acctest.ErrorCheck(t, ec2.EndpointsID),
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckIPAMPoolDestroy(ctx),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccIPAMPoolConfig_publicIPSource(ec2.IpamPoolPublicIpSourceByoip),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIPAMPoolExists(ctx, resourceName, &pool1),
+					resource.TestCheckResourceAttr(resourceName, "public_ip_source", "byoip"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccIPAMPoolConfig_publicIPSource(ec2.IpamPoolPublicIpSourceAmazon),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIPAMPoolExists(ctx, resourceName, &pool2),
+					testAccCheckIPAMPoolRecreated(&pool1, &pool2),
+					resource.TestCheckResourceAttr(resourceName, "public_ip_source", "amazon"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccIPAMPool_tags(t *testing.T) {
+	ctx := acctest.Context(t)
+	var pool ec2.IpamPool
+	resourceName := "aws_vpc_ipam_pool.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ErrorCheck:               acctest.ErrorCheck(t, ec2.EndpointsID),
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckIPAMPoolDestroy(ctx),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccIPAMPoolConfig_tags("key1", "value1"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIPAMPoolExists(ctx, resourceName, &pool),
+					resource.TestCheckResourceAttr(resourceName, "tags.%", "1"),
+					resource.TestCheckResourceAttr(resourceName, "tags.key1", "value1"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccIPAMPoolConfig_tags2("key1", "value1updated", "key2", "value2"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIPAMPoolExists(ctx, resourceName, &pool),
+					resource.TestCheckResourceAttr(resourceName, "tags.%", "2"),
+					resource.TestCheckResourceAttr(resourceName, "tags.key1", "value1updated"),
+					resource.TestCheckResourceAttr(resourceName, "tags.key2", "value2"),
+				),
+			},
+			{
+				Config: testAccIPAMPoolConfig_tags("key2", "value2"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIPAMPoolExists(ctx, resourceName, &pool),
+					resource.TestCheckResourceAttr(resourceName, "tags.%", "1"),
+					resource.TestCheckResourceAttr(resourceName, "tags.key2", "value2"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccIPAMPool_allocationDefaultNetmaskLength(t *testing.T) {
+	ctx := acctest.Context(t)
+	var pool ec2.IpamPool
+	resourceName := "aws_vpc_ipam_pool.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ErrorCheck:               acctest.ErrorCheck(t, ec2.EndpointsID),
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckIPAMPoolDestroy(ctx),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccIPAMPoolConfig_allocationDefaultNetmaskLength(24),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIPAMPoolExists(ctx, resourceName, &pool),
+					resource.TestCheckResourceAttr(resourceName, "allocation_default_netmask_length", "24"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccIPAMPoolConfig_allocationDefaultNetmaskLength(28),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIPAMPoolExists(ctx, resourceName, &pool),
+					resource.TestCheckResourceAttr(resourceName, "allocation_default_netmask_length", "28"),
+				),
+			},
+			{
+				Config: testAccIPAMPoolConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIPAMPoolExists(ctx, resourceName, &pool),
+					resource.TestCheckNoResourceAttr(resourceName, "allocation_default_netmask_length"),
+				),
+			},
+			{
+				Config:   testAccIPAMPoolConfig_basic,
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestAccIPAMPool_allocationResourceTagsImport(t *testing.T) {
+	ctx := acctest.Context(t)
+	var pool ec2.IpamPool
+	resourceName := "aws_vpc_ipam_pool.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ErrorCheck:               acctest.ErrorCheck(t, ec2.EndpointsID),
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckIPAMPoolDestroy(ctx),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccIPAMPoolConfig_allocationResourceTags,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckIPAMPoolExists(ctx, resourceName, &pool),
+					resource.TestCheckResourceAttr(resourceName, "allocation_resource_tags.%", "4"),
+					resource.TestCheckResourceAttr(resourceName, "allocation_resource_tags.Environment", "test"),
+					resource.TestCheckResourceAttr(resourceName, "allocation_resource_tags.Name", "pool"),
+					resource.TestCheckResourceAttr(resourceName, "allocation_resource_tags.empty", ""),
+					resource.TestCheckResourceAttr(resourceName, "allocation_resource_tags.team", "networking"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config:   testAccIPAMPoolConfig_allocationResourceTags,
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestAccIPAMPool_autoImport(t *testing.T) {
+	ctx := acctest.Context(t)
+	var pool ec2.IpamPool
+	resourceName := "aws_vpc_ipam_pool.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ErrorCheck:               acctest.ErrorCheck(t, ec2.EndpointsID),
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckIPAMPoolDestroy(ctx),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccIPAMPoolConfig_autoImport(true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIPAMPoolExists(ctx, resourceName, &pool),
+					resource.TestCheckResourceAttr(resourceName, "auto_import", "true"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccIPAMPoolConfig_autoImport(false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIPAMPoolExists(ctx, resourceName, &pool),
+					resource.TestCheckResourceAttr(resourceName, "auto_import", "false"),
+				),
+			},
+			{
+				Config: testAccIPAMPoolConfig_autoImport(true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIPAMPoolExists(ctx, resourceName, &pool),
+					resource.TestCheckResourceAttr(resourceName, "auto_import", "true"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccIPAMPool_sourcePoolLocaleIncompatible(t *testing.T) {
+	ctx := acctest.Context(t)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t); acctest.PreCheckMultipleRegion(t, 2) },
+		ErrorCheck:               acctest.ErrorCheck(t, ec2.EndpointsID),
+		ProtoV5ProviderFactories: acctest.ProtoV5FactoriesMultipleRegions(t, 2),
+		CheckDestroy:             testAccCheckIPAMPoolDestroy(ctx),
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccIPAMPoolConfig_sourcePoolLocale(),
+				ExpectError: regexp.MustCompile(`is incompatible with source IPAM Pool`),
+			},
+		},
+	})
+}
+
+func TestAccIPAMPool_localeNotOperatingRegion(t *testing.T) {
+	ctx := acctest.Context(t)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t); acctest.PreCheckMultipleRegion(t, 2) },
+		ErrorCheck:               acctest.ErrorCheck(t, ec2.EndpointsID),
+		ProtoV5ProviderFactories: acctest.ProtoV5FactoriesMultipleRegions(t, 2),
+		CheckDestroy:             testAccCheckIPAMPoolDestroy(ctx),
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccIPAMPoolConfig_localeNotOperatingRegion(),
+				ExpectError: regexp.MustCompile(`is not an operating region of IPAM`),
+			},
+		},
+	})
+}
+
+func TestAccIPAMPool_publiclyAdvertisableScope(t *testing.T) {
+	ctx := acctest.Context(t)
 
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:                 func() { acctest.PreCheck(t) },
@@ -26,61 +1357,24 @@ func TestAccIPAMPool_basic(t *testing.T) {
 		CheckDestroy:             testAccCheckIPAMPoolDestroy(ctx),
 		Steps: []resource.TestStep{
 			{
-				Config: testAccIPAMPoolConfig_basic,
-				Check: resource.ComposeAggregateTestCheckFunc(
-					testAccCheckIPAMPoolExists(ctx, resourceName, &pool),
-					resource.TestCheckResourceAttr(resourceName, "address_family", "ipv4"),
-					resource.TestCheckNoResourceAttr(resourceName, "allocation_default_netmask_length"),
-					resource.TestCheckNoResourceAttr(resourceName, "allocation_max_netmask_length"),
-					resource.TestCheckNoResourceAttr(resourceName, "allocation_min_netmask_length"),
-					resource.TestCheckResourceAttr(resourceName, "allocation_resource_tags.%", "0"),
-					resource.TestCheckResourceAttrSet(resourceName, "arn"),
-					resource.TestCheckResourceAttr(resourceName, "auto_import", "false"),
-					resource.TestCheckResourceAttr(resourceName, "aws_service", ""),
-					resource.TestCheckResourceAttr(resourceName, "description", ""),
-					resource.TestCheckResourceAttrSet(resourceName, "ipam_scope_type"),
-					resource.TestCheckResourceAttr(resourceName, "locale", "None"),
-					resource.TestCheckResourceAttrSet(resourceName, "pool_depth"),
-					resource.TestCheckResourceAttr(resourceName, "publicly_advertisable", "false"),
-					resource.TestCheckResourceAttr(resourceName, "state", "create-complete"),
-					resource.TestCheckResourceAttr(resourceName, "tags.%", "0"),
-				),
+				Config: testAccIPAMPoolConfig_base,
 			},
 			{
-				ResourceName:      resourceName,
-				ImportState:       true,
-				ImportStateVerify: true,
+				Config:      testAccIPAMPoolConfig_publiclyAdvertisable("private_default_scope_id", "data.aws_region.current.name"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("`publicly_advertisable` can only be set for pools in a public IPAM Scope"),
 			},
 			{
-				Config: testAccIPAMPoolConfig_updated,
-				Check: resource.ComposeAggregateTestCheckFunc(
-					testAccCheckIPAMPoolExists(ctx, resourceName, &pool),
-					resource.TestCheckResourceAttr(resourceName, "address_family", "ipv4"),
-					resource.TestCheckResourceAttr(resourceName, "allocation_default_netmask_length", "32"),
-					resource.TestCheckResourceAttr(resourceName, "allocation_max_netmask_length", "32"),
-					resource.TestCheckResourceAttr(resourceName, "allocation_min_netmask_length", "32"),
-					resource.TestCheckResourceAttr(resourceName, "allocation_resource_tags.%", "1"),
-					resource.TestCheckResourceAttr(resourceName, "allocation_resource_tags.test", "1"),
-					resource.TestCheckResourceAttrSet(resourceName, "arn"),
-					resource.TestCheckResourceAttr(resourceName, "auto_import", "true"),
-					resource.TestCheckResourceAttr(resourceName, "aws_service", ""),
-					resource.TestCheckResourceAttr(resourceName, "description", "test"),
-					resource.TestCheckResourceAttrSet(resourceName, "ipam_scope_type"),
-					resource.TestCheckResourceAttr(resourceName, "locale", "None"),
-					resource.TestCheckResourceAttrSet(resourceName, "pool_depth"),
-					resource.TestCheckResourceAttr(resourceName, "publicly_advertisable", "false"),
-					resource.TestCheckResourceAttr(resourceName, "state", "modify-complete"),
-					resource.TestCheckResourceAttr(resourceName, "tags.%", "0"),
-				),
+				Config:             testAccIPAMPoolConfig_publiclyAdvertisable("public_default_scope_id", "data.aws_region.current.name"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
 			},
 		},
 	})
 }
 
-func TestAccIPAMPool_disappears(t *testing.T) {
+func TestAccIPAMPool_publiclyAdvertisableLocale(t *testing.T) {
 	ctx := acctest.Context(t)
-	var pool ec2.IpamPool
-	resourceName := "aws_vpc_ipam_pool.test"
 
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:                 func() { acctest.PreCheck(t) },
@@ -89,20 +1383,20 @@ func TestAccIPAMPool_disappears(t *testing.T) {
 		CheckDestroy:             testAccCheckIPAMPoolDestroy(ctx),
 		Steps: []resource.TestStep{
 			{
-				Config: testAccIPAMPoolConfig_basic,
-				Check: resource.ComposeTestCheckFunc(
-					testAccCheckIPAMPoolExists(ctx, resourceName, &pool),
-					acctest.CheckResourceDisappears(ctx, acctest.Provider, tfec2.ResourceIPAMPool(), resourceName),
-				),
-				ExpectNonEmptyPlan: true,
+				Config: testAccIPAMPoolConfig_base,
+			},
+			{
+				Config:      testAccIPAMPoolConfig_publiclyAdvertisable("public_default_scope_id", `"None"`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("`locale` must be set to a Region for publicly advertisable pools"),
 			},
 		},
 	})
 }
 
-func TestAccIPAMPool_ipv6Basic(t *testing.T) {
+func TestAccIPAMPool_sourceIPAMPoolID(t *testing.T) {
 	ctx := acctest.Context(t)
-	var pool ec2.IpamPool
+	var pool1, pool2, pool3, pool4 ec2.IpamPool
 	resourceName := "aws_vpc_ipam_pool.test"
 
 	resource.ParallelTest(t, resource.TestCase{
@@ -112,22 +1406,44 @@ func TestAccIPAMPool_ipv6Basic(t *testing.T) {
 		CheckDestroy:             testAccCheckIPAMPoolDestroy(ctx),
 		Steps: []resource.TestStep{
 			{
-				Config: testAccIPAMPoolConfig_ipv6,
+				Config: testAccIPAMPoolConfig_sourceIPAMPoolID("null"),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckIPAMPoolExists(ctx, resourceName, &pool),
-					resource.TestCheckResourceAttr(resourceName, "address_family", "ipv6"),
+					testAccCheckIPAMPoolExists(ctx, resourceName, &pool1),
+					resource.TestCheckResourceAttr(resourceName, "source_ipam_pool_id", ""),
 				),
 			},
 			{
-				ResourceName:      resourceName,
-				ImportState:       true,
-				ImportStateVerify: true,
+				// Add.
+				Config: testAccIPAMPoolConfig_sourceIPAMPoolID("aws_vpc_ipam_pool.parent1.id"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIPAMPoolExists(ctx, resourceName, &pool2),
+					testAccCheckIPAMPoolRecreated(&pool1, &pool2),
+					resource.TestCheckResourceAttrPair(resourceName, "source_ipam_pool_id", "aws_vpc_ipam_pool.parent1", "id"),
+				),
+			},
+			{
+				// Change.
+				Config: testAccIPAMPoolConfig_sourceIPAMPoolID("aws_vpc_ipam_pool.parent2.id"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIPAMPoolExists(ctx, resourceName, &pool3),
+					testAccCheckIPAMPoolRecreated(&pool2, &pool3),
+					resource.TestCheckResourceAttrPair(resourceName, "source_ipam_pool_id", "aws_vpc_ipam_pool.parent2", "id"),
+				),
+			},
+			{
+				// Remove.
+				Config: testAccIPAMPoolConfig_sourceIPAMPoolID("null"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIPAMPoolExists(ctx, resourceName, &pool4),
+					testAccCheckIPAMPoolRecreated(&pool3, &pool4),
+					resource.TestCheckResourceAttr(resourceName, "source_ipam_pool_id", ""),
+				),
 			},
 		},
 	})
 }
 
-func TestAccIPAMPool_tags(t *testing.T) {
+func TestAccIPAMPool_provisionedCIDRs(t *testing.T) {
 	ctx := acctest.Context(t)
 	var pool ec2.IpamPool
 	resourceName := "aws_vpc_ipam_pool.test"
@@ -139,11 +1455,24 @@ func TestAccIPAMPool_tags(t *testing.T) {
 		CheckDestroy:             testAccCheckIPAMPoolDestroy(ctx),
 		Steps: []resource.TestStep{
 			{
-				Config: testAccIPAMPoolConfig_tags("key1", "value1"),
+				Config: testAccIPAMPoolConfig_provisionedCIDRs,
+			},
+			{
+				// Refresh the pool now that its CIDRs are provisioned.
+				Config: testAccIPAMPoolConfig_provisionedCIDRs,
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckIPAMPoolExists(ctx, resourceName, &pool),
-					resource.TestCheckResourceAttr(resourceName, "tags.%", "1"),
-					resource.TestCheckResourceAttr(resourceName, "tags.key1", "value1"),
+					resource.TestCheckResourceAttr(resourceName, "provisioned_cidrs.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "provisioned_cidrs.*", map[string]string{
+						"cidr":           "10.0.0.0/24",
+						"netmask_length": "24",
+						"state":          "provisioned",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "provisioned_cidrs.*", map[string]string{
+						"cidr":           "10.1.0.0/16",
+						"netmask_length": "16",
+						"state":          "provisioned",
+					}),
 				),
 			},
 			{
@@ -151,27 +1480,50 @@ func TestAccIPAMPool_tags(t *testing.T) {
 				ImportState:       true,
 				ImportStateVerify: true,
 			},
+		},
+	})
+}
+
+func TestAccIPAMPool_forceDestroy(t *testing.T) {
+	ctx := acctest.Context(t)
+	var pool ec2.IpamPool
+	resourceName := "aws_vpc_ipam_pool.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ErrorCheck:               acctest.ErrorCheck(t, ec2.EndpointsID),
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckIPAMPoolDestroy(ctx),
+		Steps: []resource.TestStep{
 			{
-				Config: testAccIPAMPoolConfig_tags2("key1", "value1updated", "key2", "value2"),
+				Config: testAccIPAMPoolConfig_forceDestroy,
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckIPAMPoolExists(ctx, resourceName, &pool),
-					resource.TestCheckResourceAttr(resourceName, "tags.%", "2"),
-					resource.TestCheckResourceAttr(resourceName, "tags.key1", "value1updated"),
-					resource.TestCheckResourceAttr(resourceName, "tags.key2", "value2"),
+					resource.TestCheckResourceAttr(resourceName, "force_destroy", "true"),
+					// Provision CIDRs outside of Terraform so that only force_destroy can remove them.
+					testAccCheckIPAMPoolProvisionCIDRs(ctx, &pool, "10.0.0.0/24", "10.1.0.0/16", "10.2.0.0/16"),
 				),
 			},
 			{
-				Config: testAccIPAMPoolConfig_tags("key2", "value2"),
-				Check: resource.ComposeTestCheckFunc(
-					testAccCheckIPAMPoolExists(ctx, resourceName, &pool),
-					resource.TestCheckResourceAttr(resourceName, "tags.%", "1"),
-					resource.TestCheckResourceAttr(resourceName, "tags.key2", "value2"),
-				),
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"force_destroy", "provisioned_cidrs"},
 			},
 		},
 	})
 }
 
+func testAccCheckIPAMPoolRecreated(before, after *ec2.IpamPool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if before, after := aws.StringValue(before.IpamPoolId), aws.StringValue(after.IpamPoolId); before == after {
+			return fmt.Errorf("IPAM Pool (%s) not recreated", before)
+		}
+
+		return nil
+	}
+}
+
 func testAccCheckIPAMPoolExists(ctx context.Context, n string, v *ec2.IpamPool) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[n]
@@ -197,6 +1549,32 @@ func testAccCheckIPAMPoolExists(ctx context.Context, n string, v *ec2.IpamPool)
 	}
 }
 
+func testAccCheckIPAMPoolProvisionCIDRs(ctx context.Context, v *ec2.IpamPool, cidrs ...string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		conn := acctest.Provider.Meta().(*conns.AWSClient).EC2Conn()
+		poolID := aws.StringValue(v.IpamPoolId)
+
+		for _, cidr := range cidrs {
+			_, err := conn.ProvisionIpamPoolCidrWithContext(ctx, &ec2.ProvisionIpamPoolCidrInput{
+				Cidr:       aws.String(cidr),
+				IpamPoolId: aws.String(poolID),
+			})
+
+			if err != nil {
+				return fmt.Errorf("provisioning IPAM Pool (%s) CIDR (%s): %w", poolID, cidr, err)
+			}
+		}
+
+		for _, cidr := range cidrs {
+			if _, err := tfec2.WaitIPAMPoolCIDRCreated(ctx, conn, cidr, poolID, 10*time.Minute); err != nil {
+				return fmt.Errorf("waiting for IPAM Pool (%s) CIDR (%s) create: %w", poolID, cidr, err)
+			}
+		}
+
+		return nil
+	}
+}
+
 func testAccCheckIPAMPoolDestroy(ctx context.Context) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		conn := acctest.Provider.Meta().(*conns.AWSClient).EC2Conn()
@@ -255,6 +1633,45 @@ resource "aws_vpc_ipam_pool" "test" {
 }
 `)
 
+var testAccIPAMPoolConfig_allocationResourceTags = acctest.ConfigCompose(testAccIPAMPoolConfig_base, `
+resource "aws_vpc_ipam_pool" "test" {
+  address_family = "ipv4"
+  ipam_scope_id  = aws_vpc_ipam.test.private_default_scope_id
+
+  allocation_resource_tags = {
+    team        = "networking"
+    Name        = "pool"
+    empty       = ""
+    Environment = "test"
+  }
+}
+`)
+
+var testAccIPAMPoolConfig_provisionedCIDRs = acctest.ConfigCompose(testAccIPAMPoolConfig_base, `
+resource "aws_vpc_ipam_pool" "test" {
+  address_family = "ipv4"
+  ipam_scope_id  = aws_vpc_ipam.test.private_default_scope_id
+}
+
+resource "aws_vpc_ipam_pool_cidr" "test1" {
+  ipam_pool_id = aws_vpc_ipam_pool.test.id
+  cidr         = "10.0.0.0/24"
+}
+
+resource "aws_vpc_ipam_pool_cidr" "test2" {
+  ipam_pool_id = aws_vpc_ipam_pool.test.id
+  cidr         = "10.1.0.0/16"
+}
+`)
+
+var testAccIPAMPoolConfig_forceDestroy = acctest.ConfigCompose(testAccIPAMPoolConfig_base, `
+resource "aws_vpc_ipam_pool" "test" {
+  address_family = "ipv4"
+  ipam_scope_id  = aws_vpc_ipam.test.private_default_scope_id
+  force_destroy  = true
+}
+`)
+
 var testAccIPAMPoolConfig_ipv6 = acctest.ConfigCompose(testAccIPAMPoolConfig_base, `
 resource "aws_vpc_ipam_pool" "test" {
   address_family        = "ipv6"
@@ -264,6 +1681,117 @@ resource "aws_vpc_ipam_pool" "test" {
 }
 `)
 
+func testAccIPAMPoolConfig_publicIPSource(publicIPSource string) string {
+	return acctest.ConfigCompose(testAccIPAMPoolConfig_base, fmt.Sprintf(`
+resource "aws_vpc_ipam_pool" "test" {
+  address_family        = "ipv6"
+  ipam_scope_id         = aws_vpc_ipam.test.public_default_scope_id
+  locale                = data.aws_region.current.name
+  public_ip_source      = %[1]q
+  publicly_advertisable = false
+}
+`, publicIPSource))
+}
+
+func testAccIPAMPoolConfig_autoImport(autoImport bool) string {
+	return acctest.ConfigCompose(testAccIPAMPoolConfig_base, fmt.Sprintf(`
+resource "aws_vpc_ipam_pool" "test" {
+  address_family = "ipv4"
+  ipam_scope_id  = aws_vpc_ipam.test.private_default_scope_id
+  auto_import    = %[1]t
+}
+`, autoImport))
+}
+
+func testAccIPAMPoolConfig_sourceIPAMPoolID(sourceIPAMPoolID string) string {
+	return acctest.ConfigCompose(testAccIPAMPoolConfig_base, fmt.Sprintf(`
+resource "aws_vpc_ipam_pool" "parent1" {
+  address_family = "ipv4"
+  ipam_scope_id  = aws_vpc_ipam.test.private_default_scope_id
+}
+
+resource "aws_vpc_ipam_pool" "parent2" {
+  address_family = "ipv4"
+  ipam_scope_id  = aws_vpc_ipam.test.private_default_scope_id
+}
+
+resource "aws_vpc_ipam_pool" "test" {
+  address_family      = "ipv4"
+  ipam_scope_id       = aws_vpc_ipam.test.private_default_scope_id
+  source_ipam_pool_id = %[1]s
+}
+`, sourceIPAMPoolID))
+}
+
+func testAccIPAMPoolConfig_sourcePoolLocale() string {
+	return acctest.ConfigCompose(acctest.ConfigMultipleRegionProvider(2), `
+data "aws_region" "current" {}
+
+data "aws_region" "alternate" {
+  provider = awsalternate
+}
+
+resource "aws_vpc_ipam" "test" {
+  operating_regions {
+    region_name = data.aws_region.current.name
+  }
+
+  operating_regions {
+    region_name = data.aws_region.alternate.name
+  }
+}
+
+resource "aws_vpc_ipam_pool" "parent" {
+  address_family = "ipv4"
+  ipam_scope_id  = aws_vpc_ipam.test.private_default_scope_id
+  locale         = data.aws_region.current.name
+}
+
+resource "aws_vpc_ipam_pool" "test" {
+  address_family      = "ipv4"
+  ipam_scope_id       = aws_vpc_ipam.test.private_default_scope_id
+  locale              = data.aws_region.alternate.name
+  source_ipam_pool_id = aws_vpc_ipam_pool.parent.id
+}
+`)
+}
+
+func testAccIPAMPoolConfig_localeNotOperatingRegion() string {
+	return acctest.ConfigCompose(acctest.ConfigMultipleRegionProvider(2), testAccIPAMPoolConfig_base, `
+data "aws_region" "alternate" {
+  provider = awsalternate
+}
+
+resource "aws_vpc_ipam_pool" "test" {
+  address_family = "ipv4"
+  ipam_scope_id  = aws_vpc_ipam.test.private_default_scope_id
+  locale         = data.aws_region.alternate.name
+}
+`)
+}
+
+func testAccIPAMPoolConfig_publiclyAdvertisable(scopeAttribute, locale string) string {
+	return acctest.ConfigCompose(testAccIPAMPoolConfig_base, fmt.Sprintf(`
+resource "aws_vpc_ipam_pool" "test" {
+  address_family        = "ipv6"
+  aws_service           = "ec2"
+  ipam_scope_id         = aws_vpc_ipam.test.%[1]s
+  locale                = %[2]s
+  publicly_advertisable = true
+}
+`, scopeAttribute, locale))
+}
+
+func testAccIPAMPoolConfig_allocationDefaultNetmaskLength(netmaskLength int) string {
+	return acctest.ConfigCompose(testAccIPAMPoolConfig_base, fmt.Sprintf(`
+resource "aws_vpc_ipam_pool" "test" {
+  address_family                    = "ipv4"
+  ipam_scope_id                     = aws_vpc_ipam.test.private_default_scope_id
+  allocation_default_netmask_length = %[1]d
+}
+`, netmaskLength))
+}
+
 func testAccIPAMPoolConfig_tags(tagKey1, tagValue1 string) string {
 	return acctest.ConfigCompose(testAccIPAMPoolConfig_base, fmt.Sprintf(`
 resource "aws_vpc_ipam_pool" "test" {
@@ -1,13 +1,70 @@
 package ec2_test
 
 import (
+	"reflect"
 	"testing"
 
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-provider-aws/internal/acctest"
+	tfec2 "github.com/hashicorp/terraform-provider-aws/internal/service/ec2"
 )
 
+func TestFlattenIPAMPoolAllocationResourceTags(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		Name     string
+		Tags     []*ec2.IpamResourceTag
+		Expected map[string]string
+	}{
+		{
+			Name:     "none",
+			Expected: map[string]string{},
+		},
+		{
+			Name: "multiple",
+			Tags: []*ec2.IpamResourceTag{
+				{Key: aws.String("environment"), Value: aws.String("production")},
+				{Key: aws.String("team"), Value: aws.String("network")},
+			},
+			Expected: map[string]string{
+				"environment": "production",
+				"team":        "network",
+			},
+		},
+		{
+			Name: "empty value",
+			Tags: []*ec2.IpamResourceTag{
+				{Key: aws.String("required"), Value: aws.String("")},
+			},
+			Expected: map[string]string{
+				"required": "",
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.Name, func(t *testing.T) {
+			t.Parallel()
+
+			pool := &ec2.IpamPool{
+				AllocationResourceTags: testCase.Tags,
+				IpamPoolId:             aws.String("ipam-pool-12345678"),
+				IpamScopeArn:           aws.String("arn:aws:ec2::123456789012:ipam-scope/ipam-scope-12345678"), //lintignore:AWSAT005
+			}
+
+			got := tfec2.FlattenIPAMPool(pool, nil)["allocation_resource_tags"]
+
+			if !reflect.DeepEqual(got, testCase.Expected) {
+				t.Errorf("got %v, expected %v", got, testCase.Expected)
+			}
+		})
+	}
+}
+
 func TestAccIPAMPoolsDataSource_basic(t *testing.T) {
 	dataSourceName := "data.aws_vpc_ipam_pools.test"
 	dataSourceNameTwo := "data.aws_vpc_ipam_pools.testtwo"
@@ -37,6 +94,7 @@ func TestAccIPAMPoolsDataSource_basic(t *testing.T) {
 					resource.TestCheckResourceAttrPair(dataSourceNameTwo, "ipam_pools.0.allocation_max_netmask_length", resourceName, "allocation_max_netmask_length"),
 					resource.TestCheckResourceAttrPair(dataSourceNameTwo, "ipam_pools.0.allocation_min_netmask_length", resourceName, "allocation_min_netmask_length"),
 					resource.TestCheckResourceAttrPair(dataSourceNameTwo, "ipam_pools.0.allocation_resource_tags.%", resourceName, "allocation_resource_tags.%"),
+					resource.TestCheckResourceAttr(dataSourceNameTwo, "ipam_pools.0.allocation_resource_tags.test", "3"),
 					resource.TestCheckResourceAttrPair(dataSourceNameTwo, "ipam_pools.0.arn", resourceName, "arn"),
 					resource.TestCheckResourceAttrPair(dataSourceNameTwo, "ipam_pools.0.auto_import", resourceName, "auto_import"),
 					resource.TestCheckResourceAttrPair(dataSourceNameTwo, "ipam_pools.0.description", resourceName, "description"),
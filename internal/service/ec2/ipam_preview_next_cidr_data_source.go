@@ -2,6 +2,7 @@ package ec2
 
 import (
 	"context"
+	"sort"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -66,19 +67,8 @@ func dataSourceIPAMPreviewNextCIDRRead(ctx context.Context, d *schema.ResourceDa
 	conn := meta.(*conns.AWSClient).EC2Conn()
 	poolId := d.Get("ipam_pool_id").(string)
 
-	input := &ec2.AllocateIpamPoolCidrInput{
-		ClientToken:     aws.String(resource.UniqueId()),
-		IpamPoolId:      aws.String(poolId),
-		PreviewNextCidr: aws.Bool(true),
-	}
-
-	if v, ok := d.GetOk("disallowed_cidrs"); ok && v.(*schema.Set).Len() > 0 {
-		input.DisallowedCidrs = flex.ExpandStringSet(v.(*schema.Set))
-	}
-
-	if v, ok := d.GetOk("netmask_length"); ok {
-		input.NetmaskLength = aws.Int64(int64(v.(int)))
-	}
+	input := expandIPAMPreviewNextCIDRInput(d)
+	input.ClientToken = aws.String(resource.UniqueId())
 
 	output, err := conn.AllocateIpamPoolCidrWithContext(ctx, input)
 
@@ -97,3 +87,24 @@ func dataSourceIPAMPreviewNextCIDRRead(ctx context.Context, d *schema.ResourceDa
 
 	return diags
 }
+
+// expandIPAMPreviewNextCIDRInput returns the AllocateIpamPoolCidr input previewing the next CIDR for the data source.
+// Disallowed CIDRs are sorted so that the same configuration always results in the same request.
+func expandIPAMPreviewNextCIDRInput(d *schema.ResourceData) *ec2.AllocateIpamPoolCidrInput {
+	input := &ec2.AllocateIpamPoolCidrInput{
+		IpamPoolId:      aws.String(d.Get("ipam_pool_id").(string)),
+		PreviewNextCidr: aws.Bool(true),
+	}
+
+	if v, ok := d.GetOk("disallowed_cidrs"); ok && v.(*schema.Set).Len() > 0 {
+		cidrs := flex.ExpandStringValueSet(v.(*schema.Set))
+		sort.Strings(cidrs)
+		input.DisallowedCidrs = aws.StringSlice(cidrs)
+	}
+
+	if v, ok := d.GetOk("netmask_length"); ok {
+		input.NetmaskLength = aws.Int64(int64(v.(int)))
+	}
+
+	return input
+}
@@ -2,13 +2,53 @@ package ec2_test
 
 import (
 	"fmt"
+	"reflect"
 	"testing"
 
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-provider-aws/internal/acctest"
+	tfec2 "github.com/hashicorp/terraform-provider-aws/internal/service/ec2"
 )
 
+func TestExpandIPAMPreviewNextCIDRInput(t *testing.T) {
+	t.Parallel()
+
+	raw := map[string]interface{}{
+		"disallowed_cidrs": []interface{}{"172.2.0.32/28", "172.2.0.0/27", "172.2.0.16/28"},
+		"ipam_pool_id":     "ipam-pool-12345678",
+		"netmask_length":   28,
+	}
+	d := schema.TestResourceDataRaw(t, tfec2.DataSourceIPAMPreviewNextCIDR().Schema, raw)
+
+	input := tfec2.ExpandIPAMPreviewNextCIDRInput(d)
+
+	if got, want := aws.StringValue(input.IpamPoolId), "ipam-pool-12345678"; got != want {
+		t.Errorf("got IpamPoolId %q, expected %q", got, want)
+	}
+
+	if !aws.BoolValue(input.PreviewNextCidr) {
+		t.Error("got PreviewNextCidr false, expected true")
+	}
+
+	if got, want := aws.Int64Value(input.NetmaskLength), int64(28); got != want {
+		t.Errorf("got NetmaskLength %d, expected %d", got, want)
+	}
+
+	if got, want := aws.StringValueSlice(input.DisallowedCidrs), []string{"172.2.0.0/27", "172.2.0.16/28", "172.2.0.32/28"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got DisallowedCidrs %v, expected %v", got, want)
+	}
+
+	// Repeated previews of the same configuration send the same request.
+	for i := 0; i < 10; i++ {
+		if got := tfec2.ExpandIPAMPreviewNextCIDRInput(d); !reflect.DeepEqual(got, input) {
+			t.Fatalf("got %s, expected %s", got, input)
+		}
+	}
+}
+
 func TestAccIPAMPreviewNextCIDRDataSource_ipv4Basic(t *testing.T) {
 	datasourceName := "data.aws_vpc_ipam_preview_next_cidr.test"
 	netmaskLength := "28"
@@ -89,6 +129,35 @@ func TestAccIPAMPreviewNextCIDRDataSource_ipv4DisallowedCIDR(t *testing.T) {
 	})
 }
 
+func TestAccIPAMPreviewNextCIDRDataSource_ipv4DisallowedCIDRsOverlapping(t *testing.T) {
+	datasourceName1 := "data.aws_vpc_ipam_preview_next_cidr.test1"
+	datasourceName2 := "data.aws_vpc_ipam_preview_next_cidr.test2"
+	expectedCidr := "172.2.0.32/28"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ErrorCheck:               acctest.ErrorCheck(t, ec2.EndpointsID),
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories,
+		CheckDestroy:             nil,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccIPAMPreviewNextCIDRDataSourceConfig_ipv4DisallowedOverlapping,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(datasourceName1, "cidr", expectedCidr),
+					resource.TestCheckResourceAttr(datasourceName1, "disallowed_cidrs.#", "2"),
+					resource.TestCheckResourceAttr(datasourceName2, "cidr", expectedCidr),
+					resource.TestCheckResourceAttr(datasourceName2, "disallowed_cidrs.#", "2"),
+				),
+			},
+			{
+				// Previews don't reserve, so refreshing returns the same CIDRs.
+				Config:   testAccIPAMPreviewNextCIDRDataSourceConfig_ipv4DisallowedOverlapping,
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
 const testAccIPAMPreviewNextCIDRDataSourceConfig_base = `
 data "aws_region" "current" {}
 
@@ -166,3 +235,33 @@ data "aws_vpc_ipam_preview_next_cidr" "test" {
 }
 `, netmaskLength, disallowedCidr)
 }
+
+var testAccIPAMPreviewNextCIDRDataSourceConfig_ipv4DisallowedOverlapping = acctest.ConfigCompose(testAccIPAMPreviewNextCIDRDataSourceConfig_base, `
+data "aws_vpc_ipam_preview_next_cidr" "test1" {
+  ipam_pool_id   = aws_vpc_ipam_pool.test.id
+  netmask_length = 28
+
+  disallowed_cidrs = [
+    "172.2.0.16/28",
+    "172.2.0.0/28",
+  ]
+
+  depends_on = [
+    aws_vpc_ipam_pool_cidr.test
+  ]
+}
+
+data "aws_vpc_ipam_preview_next_cidr" "test2" {
+  ipam_pool_id   = aws_vpc_ipam_pool.test.id
+  netmask_length = 28
+
+  disallowed_cidrs = [
+    "172.2.0.0/27",
+    "172.2.0.16/28",
+  ]
+
+  depends_on = [
+    aws_vpc_ipam_pool_cidr.test
+  ]
+}
+`)
@@ -0,0 +1,40 @@
+package ec2_test
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-provider-aws/internal/acctest"
+)
+
+func TestAccIPAMResourceDiscoveryDataSource_basic(t *testing.T) {
+	dataSourceName := "data.aws_vpc_ipam_resource_discovery.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ErrorCheck:               acctest.ErrorCheck(t, ec2.EndpointsID),
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccIPAMResourceDiscoveryDataSourceConfig_basic,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet(dataSourceName, "arn"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "id", "aws_vpc_ipam.test", "default_resource_discovery_id"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "ipam_resource_discovery_region", "data.aws_region.current", "name"),
+					resource.TestCheckResourceAttr(dataSourceName, "is_default", "true"),
+					resource.TestCheckResourceAttr(dataSourceName, "operating_regions.#", "1"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "operating_regions.0.region_name", "data.aws_region.current", "name"),
+					acctest.CheckResourceAttrAccountID(dataSourceName, "owner_id"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "state"),
+				),
+			},
+		},
+	})
+}
+
+var testAccIPAMResourceDiscoveryDataSourceConfig_basic = acctest.ConfigCompose(testAccIPAMPoolConfig_base, `
+data "aws_vpc_ipam_resource_discovery" "test" {
+  id = aws_vpc_ipam.test.default_resource_discovery_id
+}
+`)
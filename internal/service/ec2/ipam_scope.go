@@ -2,11 +2,13 @@ package ec2
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/hashicorp/aws-sdk-go-base/v2/awsv1shim/v2/tfawserr"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -27,7 +29,7 @@ func ResourceIPAMScope() *schema.Resource {
 		DeleteWithoutTimeout: ResourceIPAMScopeDelete,
 
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: resourceIPAMScopeImport,
 		},
 
 		Timeouts: &schema.ResourceTimeout{
@@ -76,20 +78,18 @@ func ResourceIPAMScope() *schema.Resource {
 func ResourceIPAMScopeCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 	conn := meta.(*conns.AWSClient).EC2Conn()
-	defaultTagsConfig := meta.(*conns.AWSClient).DefaultTagsConfig
-	tags := defaultTagsConfig.MergeTags(tftags.New(d.Get("tags").(map[string]interface{})))
 
 	input := &ec2.CreateIpamScopeInput{
 		ClientToken:       aws.String(resource.UniqueId()),
 		IpamId:            aws.String(d.Get("ipam_id").(string)),
-		TagSpecifications: tagSpecificationsFromKeyValueTags(tags, ec2.ResourceTypeIpamScope),
+		TagSpecifications: ipamTagSpecifications(d, meta.(*conns.AWSClient).DefaultTagsConfig, ec2.ResourceTypeIpamScope),
 	}
 
 	if v, ok := d.GetOk("description"); ok {
 		input.Description = aws.String(v.(string))
 	}
 
-	output, err := conn.CreateIpamScopeWithContext(ctx, input)
+	output, untagged, err := createIPAMScopeWithTagsFallback(ctx, conn, input, d.Timeout(schema.TimeoutCreate))
 
 	if err != nil {
 		return sdkdiag.AppendErrorf(diags, "creating IPAM Scope: %s", err)
@@ -101,16 +101,64 @@ func ResourceIPAMScopeCreate(ctx context.Context, d *schema.ResourceData, meta i
 		return sdkdiag.AppendErrorf(diags, "waiting for IPAM Scope (%s) create: %s", d.Id(), err)
 	}
 
+	if untagged {
+		diags = sdkdiag.AppendWarningf(diags, "IPAM Scope (%s) was created without tags because tagging on create is not authorized; tagging it after create instead", d.Id())
+
+		tags := meta.(*conns.AWSClient).DefaultTagsConfig.MergeTags(tftags.New(d.Get("tags").(map[string]interface{})))
+
+		if err := UpdateTags(ctx, conn, d.Id(), nil, tags); err != nil {
+			return sdkdiag.AppendErrorf(diags, "adding IPAM Scope (%s) tags: %s", d.Id(), err)
+		}
+	}
+
 	return append(diags, ResourceIPAMScopeRead(ctx, d, meta)...)
 }
 
+// createIPAMScopeWithTagsFallback creates an IPAM Scope. If tagging on create is not authorized, the scope is created
+// again without tags, with a new client token, and the returned bool is true so that the caller can tag the scope afterwards.
+func createIPAMScopeWithTagsFallback(ctx context.Context, conn *ec2.EC2, input *ec2.CreateIpamScopeInput, timeout time.Duration) (*ec2.CreateIpamScopeOutput, bool, error) {
+	output, err := createIPAMScope(ctx, conn, input, timeout)
+
+	if !ipamTagOnCreateUnauthorized(err, input.TagSpecifications) {
+		return output, false, err
+	}
+
+	log.Printf("[WARN] Creating IPAM Scope with tags not authorized, creating without tags: %s", err)
+	input.ClientToken = aws.String(resource.UniqueId())
+	input.TagSpecifications = nil
+
+	output, err = createIPAMScope(ctx, conn, input, timeout)
+
+	if err != nil {
+		return nil, false, err
+	}
+
+	return output, true, nil
+}
+
+// createIPAMScope creates an IPAM Scope, retrying while the request is throttled.
+// Every attempt sends the input's client token, so that an attempt that AWS processed despite failing can't create a duplicate scope.
+func createIPAMScope(ctx context.Context, conn *ec2.EC2, input *ec2.CreateIpamScopeInput, timeout time.Duration) (*ec2.CreateIpamScopeOutput, error) {
+	outputRaw, err := tfresource.RetryWhenAWSErrCodeEquals(ctx, timeout, func() (interface{}, error) {
+		return conn.CreateIpamScopeWithContext(ctx, input)
+	}, errCodeRequestLimitExceeded)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return outputRaw.(*ec2.CreateIpamScopeOutput), nil
+}
+
 func ResourceIPAMScopeRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 	conn := meta.(*conns.AWSClient).EC2Conn()
 	defaultTagsConfig := meta.(*conns.AWSClient).DefaultTagsConfig
 	ignoreTagsConfig := meta.(*conns.AWSClient).IgnoreTagsConfig
 
-	scope, err := FindIPAMScopeByID(ctx, conn, d.Id())
+	outputRaw, err := tfresource.RetryWhenNewResourceNotFound(ctx, propagationTimeout, func() (interface{}, error) {
+		return FindIPAMScopeByID(ctx, conn, d.Id())
+	}, d.IsNewResource())
 
 	if !d.IsNewResource() && tfresource.NotFound(err) {
 		log.Printf("[WARN] IPAM Scope (%s) not found, removing from state", d.Id())
@@ -122,7 +170,14 @@ func ResourceIPAMScopeRead(ctx context.Context, d *schema.ResourceData, meta int
 		return sdkdiag.AppendErrorf(diags, "reading IPAM Scope (%s): %s", d.Id(), err)
 	}
 
-	ipamID := strings.Split(aws.StringValue(scope.IpamArn), "/")[1]
+	scope := outputRaw.(*ec2.IpamScope)
+
+	ipamID, err := IPAMResourceARNToID(aws.StringValue(scope.IpamArn))
+
+	if err != nil {
+		return sdkdiag.AppendErrorf(diags, "reading IPAM Scope (%s): %s", d.Id(), err)
+	}
+
 	d.Set("arn", scope.IpamScopeArn)
 	d.Set("description", scope.Description)
 	d.Set("ipam_arn", scope.IpamArn)
@@ -154,9 +209,8 @@ func ResourceIPAMScopeUpdate(ctx context.Context, d *schema.ResourceData, meta i
 			IpamScopeId: aws.String(d.Id()),
 		}
 
-		if v, ok := d.GetOk("description"); ok {
-			input.Description = aws.String(v.(string))
-		}
+		// An empty description clears it.
+		input.Description = aws.String(d.Get("description").(string))
 
 		_, err := conn.ModifyIpamScopeWithContext(ctx, input)
 
@@ -193,7 +247,7 @@ func ResourceIPAMScopeDelete(ctx context.Context, d *schema.ResourceData, meta i
 		return diags
 	}
 
-	if err != nil {
+	if err != nil && !ipamResourceAlreadyDeleting(err, StatusIPAMScopeState(ctx, conn, d.Id()), ec2.IpamScopeStateDeleteInProgress) {
 		return sdkdiag.AppendErrorf(diags, "deleting IPAM Scope: (%s): %s", d.Id(), err)
 	}
 
@@ -203,3 +257,35 @@ func ResourceIPAMScopeDelete(ctx context.Context, d *schema.ResourceData, meta i
 
 	return diags
 }
+
+func resourceIPAMScopeImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	id, err := ipamScopeImportID(d.Id())
+
+	if err != nil {
+		return nil, err
+	}
+
+	// ipam_id is set from the scope's IPAM ARN on read.
+	d.SetId(id)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// ipamScopeImportID returns the IPAM Scope ID from an import ID that is either the ID or the ARN of the scope.
+func ipamScopeImportID(id string) (string, error) {
+	if !arn.IsARN(id) {
+		return id, nil
+	}
+
+	parsedARN, err := arn.Parse(id)
+
+	if err != nil {
+		return "", fmt.Errorf("parsing IPAM Scope ARN (%s): %w", id, err)
+	}
+
+	if !strings.HasPrefix(parsedARN.Resource, ec2.ResourceTypeIpamScope+ARNSeparator) {
+		return "", fmt.Errorf("unexpected format for import ID (%s), expected IPAM-SCOPE-ID or the ARN of an IPAM Scope", id)
+	}
+
+	return IPAMResourceARNToID(id)
+}
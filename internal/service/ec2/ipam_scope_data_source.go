@@ -0,0 +1,107 @@
+package ec2
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/hashicorp/terraform-provider-aws/internal/conns"
+	"github.com/hashicorp/terraform-provider-aws/internal/errs/sdkdiag"
+	tftags "github.com/hashicorp/terraform-provider-aws/internal/tags"
+	"github.com/hashicorp/terraform-provider-aws/internal/tfresource"
+)
+
+func DataSourceIPAMScope() *schema.Resource {
+	return &schema.Resource{
+		ReadWithoutTimeout: dataSourceIPAMScopeRead,
+
+		Schema: map[string]*schema.Schema{
+			"arn": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"ipam_arn": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"ipam_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"ipam_id", "ipam_scope_id"},
+				RequiredWith: []string{"ipam_scope_type"},
+			},
+			"ipam_scope_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"ipam_id", "ipam_scope_id"},
+			},
+			"ipam_scope_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				RequiredWith: []string{"ipam_id"},
+				ValidateFunc: validation.StringInSlice(ec2.IpamScopeType_Values(), false),
+			},
+			"is_default": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"pool_count": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"tags": tftags.TagsSchemaComputed(),
+		},
+	}
+}
+
+func dataSourceIPAMScopeRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	conn := meta.(*conns.AWSClient).EC2Conn()
+	ignoreTagsConfig := meta.(*conns.AWSClient).IgnoreTagsConfig
+
+	var scope *ec2.IpamScope
+	var err error
+
+	if v, ok := d.GetOk("ipam_scope_id"); ok {
+		scope, err = FindIPAMScopeByID(ctx, conn, v.(string))
+	} else {
+		// The default scopes are created with the IPAM, so they are typically referenced by type rather than by ID.
+		scope, err = FindDefaultIPAMScope(ctx, conn, d.Get("ipam_id").(string), d.Get("ipam_scope_type").(string))
+	}
+
+	if err != nil {
+		return sdkdiag.AppendFromErr(diags, tfresource.SingularDataSourceFindError("IPAM Scope", err))
+	}
+
+	ipamID, err := IPAMResourceARNToID(aws.StringValue(scope.IpamArn))
+
+	if err != nil {
+		return sdkdiag.AppendErrorf(diags, "reading IPAM Scope (%s): %s", aws.StringValue(scope.IpamScopeId), err)
+	}
+
+	d.SetId(aws.StringValue(scope.IpamScopeId))
+	d.Set("arn", scope.IpamScopeArn)
+	d.Set("description", scope.Description)
+	d.Set("ipam_arn", scope.IpamArn)
+	d.Set("ipam_id", ipamID)
+	d.Set("ipam_scope_id", scope.IpamScopeId)
+	d.Set("ipam_scope_type", scope.IpamScopeType)
+	d.Set("is_default", scope.IsDefault)
+	d.Set("pool_count", scope.PoolCount)
+
+	if err := d.Set("tags", KeyValueTags(scope.Tags).IgnoreAWS().IgnoreConfig(ignoreTagsConfig).Map()); err != nil {
+		return sdkdiag.AppendErrorf(diags, "setting tags: %s", err)
+	}
+
+	return diags
+}
@@ -0,0 +1,100 @@
+package ec2_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-provider-aws/internal/acctest"
+)
+
+func TestAccIPAMScopeDataSource_basic(t *testing.T) {
+	resourceName := "aws_vpc_ipam_scope.test"
+	dataSourceName := "data.aws_vpc_ipam_scope.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ErrorCheck:               acctest.ErrorCheck(t, ec2.EndpointsID),
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccIPAMScopeDataSourceConfig_basic,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair(dataSourceName, "arn", resourceName, "arn"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "description", resourceName, "description"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "ipam_arn", resourceName, "ipam_arn"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "ipam_id", resourceName, "ipam_id"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "ipam_scope_id", resourceName, "id"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "ipam_scope_type", resourceName, "ipam_scope_type"),
+					resource.TestCheckResourceAttr(dataSourceName, "is_default", "false"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "tags.%", resourceName, "tags.%"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccIPAMScopeDataSource_default(t *testing.T) {
+	resourceName := "aws_vpc_ipam.test"
+	privateDataSourceName := "data.aws_vpc_ipam_scope.private"
+	publicDataSourceName := "data.aws_vpc_ipam_scope.public"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ErrorCheck:               acctest.ErrorCheck(t, ec2.EndpointsID),
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccIPAMScopeDataSourceConfig_default,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair(privateDataSourceName, "ipam_scope_id", resourceName, "private_default_scope_id"),
+					resource.TestCheckResourceAttr(privateDataSourceName, "ipam_scope_type", "private"),
+					resource.TestCheckResourceAttr(privateDataSourceName, "is_default", "true"),
+					resource.TestCheckResourceAttrPair(publicDataSourceName, "ipam_scope_id", resourceName, "public_default_scope_id"),
+					resource.TestCheckResourceAttr(publicDataSourceName, "ipam_scope_type", "public"),
+					resource.TestCheckResourceAttr(publicDataSourceName, "is_default", "true"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccIPAMScopeDataSource_typeRequiresIPAM(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ErrorCheck:               acctest.ErrorCheck(t, ec2.EndpointsID),
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccIPAMScopeDataSourceConfig_typeRequiresIPAM,
+				ExpectError: regexp.MustCompile(`all of .ipam_id,ipam_scope_type. must be specified`),
+			},
+		},
+	})
+}
+
+var testAccIPAMScopeDataSourceConfig_basic = acctest.ConfigCompose(testAccIPAMScopeConfig_basic("test"), `
+data "aws_vpc_ipam_scope" "test" {
+  ipam_scope_id = aws_vpc_ipam_scope.test.id
+}
+`)
+
+var testAccIPAMScopeDataSourceConfig_default = acctest.ConfigCompose(testAccIPAMConfig_basic, `
+data "aws_vpc_ipam_scope" "private" {
+  ipam_id         = aws_vpc_ipam.test.id
+  ipam_scope_type = "private"
+}
+
+data "aws_vpc_ipam_scope" "public" {
+  ipam_id         = aws_vpc_ipam.test.id
+  ipam_scope_type = "public"
+}
+`)
+
+const testAccIPAMScopeDataSourceConfig_typeRequiresIPAM = `
+data "aws_vpc_ipam_scope" "test" {
+  ipam_scope_id   = "ipam-scope-12345678"
+  ipam_scope_type = "private"
+}
+`
@@ -3,8 +3,15 @@ package ec2_test
 import (
 	"context"
 	"fmt"
+	"reflect"
+	"regexp"
 	"testing"
+	"time"
 
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
@@ -14,6 +21,361 @@ import (
 	"github.com/hashicorp/terraform-provider-aws/internal/tfresource"
 )
 
+func TestCreateIPAMScope(t *testing.T) {
+	ctx := context.Background()
+	t.Parallel()
+
+	sess, err := session.NewSession(nil)
+	if err != nil {
+		t.Fatalf("Error creating AWS session: %s", err)
+	}
+
+	var calls int
+	tokens := make(map[string]bool)
+	conn := ec2.New(sess)
+	conn.Handlers.Clear()
+	conn.Handlers.Send.PushBack(func(r *request.Request) {
+		tokens[aws.StringValue(r.Params.(*ec2.CreateIpamScopeInput).ClientToken)] = true
+		calls++
+
+		if calls <= 2 {
+			r.Error = awserr.New("RequestLimitExceeded", "Request limit exceeded.", nil)
+			return
+		}
+
+		r.Data.(*ec2.CreateIpamScopeOutput).IpamScope = &ec2.IpamScope{
+			IpamScopeId: aws.String("ipam-scope-12345678"),
+		}
+	})
+
+	output, err := tfec2.CreateIPAMScope(ctx, conn, &ec2.CreateIpamScopeInput{
+		ClientToken: aws.String("token"),
+		IpamId:      aws.String("ipam-12345678"),
+	}, 1*time.Minute)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if calls != 3 {
+		t.Errorf("got %d CreateIpamScope calls, expected 3", calls)
+	}
+
+	if len(tokens) != 1 || !tokens["token"] {
+		t.Errorf("got client tokens %v, expected only %q", tokens, "token")
+	}
+
+	if got, want := aws.StringValue(output.IpamScope.IpamScopeId), "ipam-scope-12345678"; got != want {
+		t.Errorf("got IPAM Scope ID %q, expected %q", got, want)
+	}
+}
+
+func TestCreateIPAMScopeWithTagsFallback(t *testing.T) {
+	ctx := context.Background()
+	t.Parallel()
+
+	sess, err := session.NewSession(nil)
+	if err != nil {
+		t.Fatalf("Error creating AWS session: %s", err)
+	}
+
+	scopeID := "ipam-scope-12345678"
+	var createTagged []bool
+	var createTagsInput *ec2.CreateTagsInput
+	tokens := make(map[string]bool)
+	conn := ec2.New(sess)
+	conn.Handlers.Clear()
+	conn.Handlers.Send.PushBack(func(r *request.Request) {
+		switch data := r.Data.(type) {
+		case *ec2.CreateIpamScopeOutput:
+			input := r.Params.(*ec2.CreateIpamScopeInput)
+			tokens[aws.StringValue(input.ClientToken)] = true
+			createTagged = append(createTagged, len(input.TagSpecifications) > 0)
+
+			if len(input.TagSpecifications) > 0 {
+				r.Error = awserr.New("UnauthorizedOperation", "You are not authorized to perform this operation. Encoded authorization failure message: abc123", nil)
+				return
+			}
+
+			data.IpamScope = &ec2.IpamScope{
+				IpamScopeId: aws.String(scopeID),
+			}
+		case *ec2.CreateTagsOutput:
+			createTagsInput = r.Params.(*ec2.CreateTagsInput)
+		}
+	})
+
+	output, untagged, err := tfec2.CreateIPAMScopeWithTagsFallback(ctx, conn, &ec2.CreateIpamScopeInput{
+		ClientToken: aws.String("token"),
+		IpamId:      aws.String("ipam-12345678"),
+		TagSpecifications: []*ec2.TagSpecification{{
+			ResourceType: aws.String(ec2.ResourceTypeIpamScope),
+			Tags:         []*ec2.Tag{{Key: aws.String("Name"), Value: aws.String("test")}},
+		}},
+	}, 1*time.Minute)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if !untagged {
+		t.Errorf("got untagged false, expected true")
+	}
+
+	if expected := []bool{true, false}; !reflect.DeepEqual(createTagged, expected) {
+		t.Errorf("got CreateIpamScope calls with tags %v, expected %v", createTagged, expected)
+	}
+
+	if len(tokens) != 2 {
+		t.Errorf("got client tokens %v, expected a new one for the create without tags", tokens)
+	}
+
+	if got := aws.StringValue(output.IpamScope.IpamScopeId); got != scopeID {
+		t.Fatalf("got IPAM Scope ID %q, expected %q", got, scopeID)
+	}
+
+	// The resource's create then applies the tags to the untagged scope.
+	if err := tfec2.UpdateTags(ctx, conn, scopeID, nil, map[string]string{"Name": "test"}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if createTagsInput == nil {
+		t.Fatal("expected a CreateTags call")
+	}
+
+	if got := aws.StringValueSlice(createTagsInput.Resources); !reflect.DeepEqual(got, []string{scopeID}) {
+		t.Errorf("got CreateTags resources %v, expected %v", got, []string{scopeID})
+	}
+
+	if got := tfec2.KeyValueTags(createTagsInput.Tags).Map(); !reflect.DeepEqual(got, map[string]string{"Name": "test"}) {
+		t.Errorf("got CreateTags tags %v, expected %v", got, map[string]string{"Name": "test"})
+	}
+}
+
+func TestFindIPAMScopeByID_newResourceRetry(t *testing.T) {
+	ctx := context.Background()
+	scopeID := "ipam-scope-12345678"
+
+	sess, err := session.NewSession(nil)
+	if err != nil {
+		t.Fatalf("Error creating AWS session: %s", err)
+	}
+	conn := ec2.New(sess)
+	conn.Handlers.Clear()
+
+	// The scope isn't visible until the second DescribeIpamScopes call.
+	calls := 0
+	conn.Handlers.Send.PushBack(func(r *request.Request) {
+		calls++
+		data := r.Data.(*ec2.DescribeIpamScopesOutput)
+		if calls == 1 {
+			return
+		}
+		data.IpamScopes = []*ec2.IpamScope{{
+			IpamArn:     aws.String("arn:aws:ec2::123456789012:ipam/ipam-12345678"), //lintignore:AWSAT005
+			IpamScopeId: aws.String(scopeID),
+			State:       aws.String(ec2.IpamScopeStateCreateComplete),
+		}}
+	})
+
+	outputRaw, err := tfresource.RetryWhenNewResourceNotFound(ctx, 1*time.Minute, func() (interface{}, error) {
+		return tfec2.FindIPAMScopeByID(ctx, conn, scopeID)
+	}, true)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if got, expected := aws.StringValue(outputRaw.(*ec2.IpamScope).IpamScopeId), scopeID; got != expected {
+		t.Errorf("got %s, expected %s", got, expected)
+	}
+
+	if calls != 2 {
+		t.Errorf("got %d DescribeIpamScopes calls, expected 2", calls)
+	}
+}
+
+func TestFindDefaultIPAMScope(t *testing.T) {
+	ctx := context.Background()
+	t.Parallel()
+
+	sess, err := session.NewSession(nil)
+	if err != nil {
+		t.Fatalf("Error creating AWS session: %s", err)
+	}
+
+	scope := func(id, ipamID, scopeType string, isDefault bool) *ec2.IpamScope {
+		return &ec2.IpamScope{
+			IpamArn:       aws.String(fmt.Sprintf("arn:aws:ec2::123456789012:ipam/%s", ipamID)),
+			IpamScopeId:   aws.String(id),
+			IpamScopeType: aws.String(scopeType),
+			IsDefault:     aws.Bool(isDefault),
+		}
+	}
+
+	scopes := []*ec2.IpamScope{
+		scope("ipam-scope-private", "ipam-12345678", ec2.IpamScopeTypePrivate, true),
+		scope("ipam-scope-public", "ipam-12345678", ec2.IpamScopeTypePublic, true),
+		scope("ipam-scope-custom", "ipam-12345678", ec2.IpamScopeTypePrivate, false),
+		scope("ipam-scope-other", "ipam-87654321", ec2.IpamScopeTypePrivate, true),
+	}
+
+	testCases := []struct {
+		Name      string
+		IPAMID    string
+		ScopeType string
+		Expected  string
+	}{
+		{
+			Name:      "private",
+			IPAMID:    "ipam-12345678",
+			ScopeType: ec2.IpamScopeTypePrivate,
+			Expected:  "ipam-scope-private",
+		},
+		{
+			Name:      "public",
+			IPAMID:    "ipam-12345678",
+			ScopeType: ec2.IpamScopeTypePublic,
+			Expected:  "ipam-scope-public",
+		},
+		{
+			Name:      "other IPAM",
+			IPAMID:    "ipam-87654321",
+			ScopeType: ec2.IpamScopeTypePrivate,
+			Expected:  "ipam-scope-other",
+		},
+		{
+			Name:      "not found",
+			IPAMID:    "ipam-87654321",
+			ScopeType: ec2.IpamScopeTypePublic,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.Name, func(t *testing.T) {
+			t.Parallel()
+
+			conn := ec2.New(sess)
+			conn.Handlers.Clear()
+			conn.Handlers.Send.PushBack(func(r *request.Request) {
+				input := r.Params.(*ec2.DescribeIpamScopesInput)
+				filters := make(map[string]string)
+				for _, v := range input.Filters {
+					filters[aws.StringValue(v.Name)] = aws.StringValue(v.Values[0])
+				}
+
+				if want := map[string]string{"ipam-scope-type": testCase.ScopeType, "is-default": "true"}; !reflect.DeepEqual(filters, want) {
+					t.Errorf("got filters %v, expected %v", filters, want)
+				}
+
+				// Return every default scope of the type, whatever its IPAM.
+				output := r.Data.(*ec2.DescribeIpamScopesOutput)
+				for _, v := range scopes {
+					if aws.BoolValue(v.IsDefault) && aws.StringValue(v.IpamScopeType) == testCase.ScopeType {
+						output.IpamScopes = append(output.IpamScopes, v)
+					}
+				}
+			})
+
+			output, err := tfec2.FindDefaultIPAMScope(ctx, conn, testCase.IPAMID, testCase.ScopeType)
+
+			if testCase.Expected == "" {
+				if !tfresource.NotFound(err) {
+					t.Fatalf("got error %v, expected not found", err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if got := aws.StringValue(output.IpamScopeId); got != testCase.Expected {
+				t.Errorf("got IPAM Scope %q, expected %q", got, testCase.Expected)
+			}
+		})
+	}
+}
+
+func TestIPAMScopeImportID(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		TestName      string
+		ImportID      string
+		ExpectedError *regexp.Regexp
+		ExpectedID    string
+	}{
+		{
+			TestName:   "ID",
+			ImportID:   "ipam-scope-12345678",
+			ExpectedID: "ipam-scope-12345678",
+		},
+		{
+			TestName:   "ARN",
+			ImportID:   "arn:aws:ec2::123456789012:ipam-scope/ipam-scope-12345678", //lintignore:AWSAT005
+			ExpectedID: "ipam-scope-12345678",
+		},
+		{
+			TestName:   "GovCloud ARN",
+			ImportID:   "arn:aws-us-gov:ec2::123456789012:ipam-scope/ipam-scope-12345678", //lintignore:AWSAT005
+			ExpectedID: "ipam-scope-12345678",
+		},
+		{
+			TestName:   "China ARN",
+			ImportID:   "arn:aws-cn:ec2::123456789012:ipam-scope/ipam-scope-12345678", //lintignore:AWSAT005
+			ExpectedID: "ipam-scope-12345678",
+		},
+		{
+			TestName:      "IPAM Pool ARN",
+			ImportID:      "arn:aws:ec2::123456789012:ipam-pool/ipam-pool-12345678", //lintignore:AWSAT005
+			ExpectedError: regexp.MustCompile(`expected IPAM-SCOPE-ID or the ARN of an IPAM Scope`),
+		},
+		{
+			TestName:      "IPAM ARN",
+			ImportID:      "arn:aws:ec2::123456789012:ipam/ipam-12345678", //lintignore:AWSAT005
+			ExpectedError: regexp.MustCompile(`expected IPAM-SCOPE-ID or the ARN of an IPAM Scope`),
+		},
+		{
+			TestName:      "ARN without ID",
+			ImportID:      "arn:aws:ec2::123456789012:ipam-scope/", //lintignore:AWSAT005
+			ExpectedError: regexp.MustCompile(`expected 2 resource parts`),
+		},
+		{
+			TestName:      "ARN of another service",
+			ImportID:      "arn:aws:iam::123456789012:ipam-scope/ipam-scope-12345678", //lintignore:AWSAT005
+			ExpectedError: regexp.MustCompile(`expected service ec2`),
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.TestName, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := tfec2.IPAMScopeImportID(testCase.ImportID)
+
+			if err == nil && testCase.ExpectedError != nil {
+				t.Fatalf("expected error %s, got no error", testCase.ExpectedError.String())
+			}
+
+			if err != nil && testCase.ExpectedError == nil {
+				t.Fatalf("got unexpected error: %s", err)
+			}
+
+			if err != nil && !testCase.ExpectedError.MatchString(err.Error()) {
+				t.Fatalf("expected error %s, got: %s", testCase.ExpectedError.String(), err)
+			}
+
+			if got != testCase.ExpectedID {
+				t.Errorf("got %s, expected %s", got, testCase.ExpectedID)
+			}
+		})
+	}
+}
+
 func TestAccIPAMScope_basic(t *testing.T) {
 	ctx := acctest.Context(t)
 	var scope ec2.IpamScope
@@ -43,6 +405,12 @@ func TestAccIPAMScope_basic(t *testing.T) {
 				ImportState:       true,
 				ImportStateVerify: true,
 			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateIdFunc: testAccIPAMScopeARNImportStateIdFunc(resourceName),
+				ImportStateVerify: true,
+			},
 			{
 				Config: testAccIPAMScopeConfig_basic("test2"),
 				Check: resource.ComposeTestCheckFunc(
@@ -54,6 +422,39 @@ func TestAccIPAMScope_basic(t *testing.T) {
 	})
 }
 
+func TestAccIPAMScope_descriptionClear(t *testing.T) {
+	ctx := acctest.Context(t)
+	var scope ec2.IpamScope
+	resourceName := "aws_vpc_ipam_scope.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ErrorCheck:               acctest.ErrorCheck(t, ec2.EndpointsID),
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckIPAMScopeDestroy(ctx),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccIPAMScopeConfig_basic("test"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIPAMScopeExists(ctx, resourceName, &scope),
+					resource.TestCheckResourceAttr(resourceName, "description", "test"),
+				),
+			},
+			{
+				Config: testAccIPAMScopeConfig_noDescription,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIPAMScopeExists(ctx, resourceName, &scope),
+					resource.TestCheckResourceAttr(resourceName, "description", ""),
+				),
+			},
+			{
+				Config:   testAccIPAMScopeConfig_noDescription,
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
 func TestAccIPAMScope_disappears(t *testing.T) {
 	ctx := acctest.Context(t)
 	var scope ec2.IpamScope
@@ -147,6 +548,17 @@ func testAccCheckIPAMScopeExists(ctx context.Context, n string, v *ec2.IpamScope
 	}
 }
 
+func testAccIPAMScopeARNImportStateIdFunc(n string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return "", fmt.Errorf("Not found: %s", n)
+		}
+
+		return rs.Primary.Attributes["arn"], nil
+	}
+}
+
 func testAccCheckIPAMScopeDestroy(ctx context.Context) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		conn := acctest.Provider.Meta().(*conns.AWSClient).EC2Conn()
@@ -192,6 +604,12 @@ resource "aws_vpc_ipam_scope" "test" {
 `, description))
 }
 
+var testAccIPAMScopeConfig_noDescription = acctest.ConfigCompose(testAccIPAMScopeConfig_base, `
+resource "aws_vpc_ipam_scope" "test" {
+  ipam_id = aws_vpc_ipam.test.id
+}
+`)
+
 func testAccIPAMScopeConfig_tags(tagKey1, tagValue1 string) string {
 	return acctest.ConfigCompose(testAccIPAMScopeConfig_base, fmt.Sprintf(`
 resource "aws_vpc_ipam_scope" "test" {
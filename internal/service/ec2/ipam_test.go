@@ -3,18 +3,486 @@ package ec2_test
 import (
 	"context"
 	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/hashicorp/terraform-provider-aws/internal/acctest"
 	"github.com/hashicorp/terraform-provider-aws/internal/conns"
 	tfec2 "github.com/hashicorp/terraform-provider-aws/internal/service/ec2"
+	tftags "github.com/hashicorp/terraform-provider-aws/internal/tags"
 	"github.com/hashicorp/terraform-provider-aws/internal/tfresource"
 )
 
+func TestIPAMResourceAlreadyDeleting(t *testing.T) {
+	ctx := context.Background()
+	t.Parallel()
+
+	sess, err := session.NewSession(nil)
+	if err != nil {
+		t.Fatalf("Error creating AWS session: %s", err)
+	}
+
+	// A concurrent process has already started deleting ipam-deleting.
+	states := map[string]string{
+		"ipam-created":  ec2.IpamStateCreateComplete,
+		"ipam-deleting": ec2.IpamStateDeleteInProgress,
+	}
+
+	conn := ec2.New(sess)
+	conn.Handlers.Clear()
+	conn.Handlers.Send.PushBack(func(r *request.Request) {
+		input := r.Params.(*ec2.DescribeIpamsInput)
+		data := r.Data.(*ec2.DescribeIpamsOutput)
+
+		id := aws.StringValue(input.IpamIds[0])
+		if state, ok := states[id]; ok {
+			data.Ipams = []*ec2.Ipam{{
+				IpamId: aws.String(id),
+				State:  aws.String(state),
+			}}
+		}
+	})
+
+	incorrectStateErr := awserr.New("IncorrectState", "The IPAM is in an incorrect state", nil)
+
+	testCases := []struct {
+		Name     string
+		ID       string
+		Err      error
+		Expected bool
+	}{
+		{
+			Name:     "delete in progress",
+			ID:       "ipam-deleting",
+			Err:      incorrectStateErr,
+			Expected: true,
+		},
+		{
+			Name:     "deleted",
+			ID:       "ipam-deleted",
+			Err:      incorrectStateErr,
+			Expected: true,
+		},
+		{
+			Name: "not deleting",
+			ID:   "ipam-created",
+			Err:  incorrectStateErr,
+		},
+		{
+			Name: "other error",
+			ID:   "ipam-deleting",
+			Err:  awserr.New("UnauthorizedOperation", "You are not authorized to perform this operation", nil),
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.Name, func(t *testing.T) {
+			t.Parallel()
+
+			got := tfec2.IPAMResourceAlreadyDeleting(testCase.Err, tfec2.StatusIPAMState(ctx, conn, testCase.ID), ec2.IpamStateDeleteInProgress)
+
+			if got != testCase.Expected {
+				t.Errorf("got %t, expected %t", got, testCase.Expected)
+			}
+		})
+	}
+}
+
+func TestCreateIPAM(t *testing.T) {
+	ctx := context.Background()
+	t.Parallel()
+
+	sess, err := session.NewSession(nil)
+	if err != nil {
+		t.Fatalf("Error creating AWS session: %s", err)
+	}
+
+	var calls int
+	tokens := make(map[string]bool)
+	conn := ec2.New(sess)
+	conn.Handlers.Clear()
+	conn.Handlers.Send.PushBack(func(r *request.Request) {
+		tokens[aws.StringValue(r.Params.(*ec2.CreateIpamInput).ClientToken)] = true
+		calls++
+
+		if calls <= 2 {
+			r.Error = awserr.New("RequestLimitExceeded", "Request limit exceeded.", nil)
+			return
+		}
+
+		r.Data.(*ec2.CreateIpamOutput).Ipam = &ec2.Ipam{
+			IpamId:                aws.String("ipam-12345678"),
+			PrivateDefaultScopeId: aws.String("ipam-scope-11111111"),
+			PublicDefaultScopeId:  aws.String("ipam-scope-22222222"),
+		}
+	})
+
+	output, err := tfec2.CreateIPAM(ctx, conn, &ec2.CreateIpamInput{
+		ClientToken: aws.String("token"),
+	}, 1*time.Minute)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if calls != 3 {
+		t.Errorf("got %d CreateIpam calls, expected 3", calls)
+	}
+
+	if len(tokens) != 1 || !tokens["token"] {
+		t.Errorf("got client tokens %v, expected only %q", tokens, "token")
+	}
+
+	if got, want := aws.StringValue(output.Ipam.IpamId), "ipam-12345678"; got != want {
+		t.Errorf("got IPAM ID %q, expected %q", got, want)
+	}
+
+	// The default scope IDs in the create response are set without waiting for a read.
+	d := schema.TestResourceDataRaw(t, tfec2.ResourceIPAM().Schema, map[string]interface{}{})
+	tfec2.SetIPAMDefaultScopeIDs(d, output.Ipam)
+
+	if got, want := d.Get("private_default_scope_id").(string), "ipam-scope-11111111"; got != want {
+		t.Errorf("got private_default_scope_id %q, expected %q", got, want)
+	}
+
+	if got, want := d.Get("public_default_scope_id").(string), "ipam-scope-22222222"; got != want {
+		t.Errorf("got public_default_scope_id %q, expected %q", got, want)
+	}
+}
+
+func TestIPAMTagSpecifications(t *testing.T) {
+	t.Parallel()
+
+	defaultTagsConfig := &tftags.DefaultConfig{
+		Tags: tftags.New(map[string]interface{}{
+			"default": "value",
+			"shared":  "default",
+		}),
+	}
+
+	testCases := []struct {
+		Name         string
+		Resource     *schema.Resource
+		ResourceType string
+	}{
+		{
+			Name:         "ipam",
+			Resource:     tfec2.ResourceIPAM(),
+			ResourceType: ec2.ResourceTypeIpam,
+		},
+		{
+			Name:         "ipam-scope",
+			Resource:     tfec2.ResourceIPAMScope(),
+			ResourceType: ec2.ResourceTypeIpamScope,
+		},
+		{
+			Name:         "ipam-pool",
+			Resource:     tfec2.ResourceIPAMPool(),
+			ResourceType: ec2.ResourceTypeIpamPool,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.Name, func(t *testing.T) {
+			t.Parallel()
+
+			d := schema.TestResourceDataRaw(t, testCase.Resource.Schema, map[string]interface{}{
+				"tags": map[string]interface{}{
+					"Name":   "test",
+					"shared": "resource",
+				},
+			})
+
+			got := tfec2.IPAMTagSpecifications(d, defaultTagsConfig, testCase.ResourceType)
+
+			if len(got) != 1 {
+				t.Fatalf("got %d tag specifications, expected 1", len(got))
+			}
+
+			if got, want := aws.StringValue(got[0].ResourceType), testCase.ResourceType; got != want {
+				t.Errorf("got resource type %q, expected %q", got, want)
+			}
+
+			want := map[string]string{
+				"Name":    "test",
+				"default": "value",
+				"shared":  "resource",
+			}
+			if got := tfec2.KeyValueTags(got[0].Tags).Map(); !reflect.DeepEqual(got, want) {
+				t.Errorf("got tags %v, expected %v", got, want)
+			}
+
+			d = schema.TestResourceDataRaw(t, testCase.Resource.Schema, map[string]interface{}{})
+
+			if got := tfec2.IPAMTagSpecifications(d, nil, testCase.ResourceType); got != nil {
+				t.Errorf("got %v, expected no tag specifications", got)
+			}
+		})
+	}
+}
+
+func TestIPAMOperatingRegionsUpdate(t *testing.T) {
+	t.Parallel()
+
+	regions := func(names ...string) []interface{} {
+		var tfList []interface{}
+		for _, v := range names {
+			tfList = append(tfList, map[string]interface{}{"region_name": v})
+		}
+		return tfList
+	}
+
+	testCases := []struct {
+		Name           string
+		Old            []interface{}
+		New            []interface{}
+		ExpectedAdd    []string
+		ExpectedRemove []string
+	}{
+		{
+			Name: "reordered",
+			Old:  regions("us-west-2", "us-east-1", "eu-west-1"),
+			New:  regions("eu-west-1", "us-west-2", "us-east-1"),
+		},
+		{
+			Name: "duplicate home region",
+			Old:  regions("us-west-2", "eu-west-1"),
+			New:  regions("eu-west-1", "us-west-2", "us-west-2"),
+		},
+		{
+			Name: "home region omitted",
+			Old:  regions("us-west-2", "eu-west-1"),
+			New:  regions("eu-west-1"),
+		},
+		{
+			Name: "case and whitespace",
+			Old:  regions("us-west-2", "eu-west-1"),
+			New:  regions(" EU-WEST-1", "us-west-2"),
+		},
+		{
+			Name:           "added and removed",
+			Old:            regions("us-west-2", "eu-west-1"),
+			New:            regions("us-west-2", "us-east-1", "ap-southeast-2"),
+			ExpectedAdd:    []string{"ap-southeast-2", "us-east-1"},
+			ExpectedRemove: []string{"eu-west-1"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.Name, func(t *testing.T) {
+			t.Parallel()
+
+			add, remove := tfec2.IPAMOperatingRegionsUpdate(testCase.Old, testCase.New, "us-west-2")
+
+			var gotAdd []string
+			for _, v := range add {
+				gotAdd = append(gotAdd, aws.StringValue(v.RegionName))
+			}
+
+			var gotRemove []string
+			for _, v := range remove {
+				gotRemove = append(gotRemove, aws.StringValue(v.RegionName))
+			}
+
+			// Without regions to add or remove, no ModifyIpam call is made for the operating regions.
+			if !reflect.DeepEqual(gotAdd, testCase.ExpectedAdd) {
+				t.Errorf("got regions to add %v, expected %v", gotAdd, testCase.ExpectedAdd)
+			}
+
+			if !reflect.DeepEqual(gotRemove, testCase.ExpectedRemove) {
+				t.Errorf("got regions to remove %v, expected %v", gotRemove, testCase.ExpectedRemove)
+			}
+		})
+	}
+}
+
+func TestValidateIPAMOperatingRegionsHomeRegion(t *testing.T) {
+	t.Parallel()
+
+	regions := func(names ...string) []interface{} {
+		var tfList []interface{}
+		for _, v := range names {
+			tfList = append(tfList, map[string]interface{}{"region_name": v})
+		}
+		return tfList
+	}
+
+	testCases := []struct {
+		Name        string
+		Regions     []interface{}
+		ExpectError bool
+	}{
+		{
+			Name:    "home region only",
+			Regions: regions("us-west-2"),
+		},
+		{
+			Name:    "home region and others",
+			Regions: regions("eu-west-1", "us-west-2"),
+		},
+		{
+			Name:    "case and whitespace",
+			Regions: regions("eu-west-1", " US-WEST-2"),
+		},
+		{
+			Name:        "home region omitted",
+			Regions:     regions("eu-west-1", "us-east-1"),
+			ExpectError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.Name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tfec2.ValidateIPAMOperatingRegionsHomeRegion(testCase.Regions, "us-west-2")
+
+			if testCase.ExpectError && err == nil {
+				t.Fatal("expected error, got none")
+			}
+
+			if !testCase.ExpectError && err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+		})
+	}
+}
+
+func TestValidateIPAMOperatingRegionsRemoval(t *testing.T) {
+	ctx := context.Background()
+	t.Parallel()
+
+	ipamARN := "arn:aws:ec2::123456789012:ipam/ipam-12345678" //lintignore:AWSAT005
+
+	sess, err := session.NewSession(nil)
+	if err != nil {
+		t.Fatalf("Error creating AWS session: %s", err)
+	}
+
+	pools := []*ec2.IpamPool{
+		{
+			IpamArn:    aws.String(ipamARN),
+			IpamPoolId: aws.String("ipam-pool-11111111"),
+			Locale:     aws.String("us-west-2"), //lintignore:AWSAT003
+		},
+		{
+			IpamArn:    aws.String("arn:aws:ec2::123456789012:ipam/ipam-87654321"), //lintignore:AWSAT005
+			IpamPoolId: aws.String("ipam-pool-22222222"),
+			Locale:     aws.String("eu-west-1"), //lintignore:AWSAT003
+		},
+		{
+			IpamArn:    aws.String(ipamARN),
+			IpamPoolId: aws.String("ipam-pool-33333333"),
+			Locale:     aws.String("us-east-1"), //lintignore:AWSAT003
+		},
+	}
+
+	conn := ec2.New(sess)
+	conn.Handlers.Clear()
+	conn.Handlers.Send.PushBack(func(r *request.Request) {
+		input := r.Params.(*ec2.DescribeIpamPoolsInput)
+		data := r.Data.(*ec2.DescribeIpamPoolsOutput)
+
+		locales := make(map[string]bool)
+		for _, v := range input.Filters[0].Values {
+			locales[aws.StringValue(v)] = true
+		}
+
+		for _, v := range pools {
+			if locales[aws.StringValue(v.Locale)] {
+				data.IpamPools = append(data.IpamPools, v)
+			}
+		}
+	})
+
+	testCases := []struct {
+		Name        string
+		Regions     []string
+		ExpectError *regexp.Regexp
+	}{
+		{
+			Name:    "no pools",
+			Regions: []string{"ap-southeast-2"}, //lintignore:AWSAT003
+		},
+		{
+			Name:    "other IPAM pool",
+			Regions: []string{"eu-west-1"}, //lintignore:AWSAT003
+		},
+		{
+			Name:        "pool",
+			Regions:     []string{"us-west-2"}, //lintignore:AWSAT003
+			ExpectError: regexp.MustCompile(`ipam-pool-11111111 \(us-west-2\)$`),
+		},
+		{
+			Name:        "pools",
+			Regions:     []string{"us-west-2", "us-east-1", "eu-west-1"}, //lintignore:AWSAT003
+			ExpectError: regexp.MustCompile(`ipam-pool-11111111 \(us-west-2\), ipam-pool-33333333 \(us-east-1\)$`),
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.Name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tfec2.ValidateIPAMOperatingRegionsRemoval(ctx, conn, ipamARN, testCase.Regions)
+
+			if testCase.ExpectError == nil {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+
+				return
+			}
+
+			if err == nil {
+				t.Fatal("expected error, got none")
+			}
+
+			if !testCase.ExpectError.MatchString(err.Error()) {
+				t.Errorf("got error %q, expected to match %q", err, testCase.ExpectError)
+			}
+		})
+	}
+}
+
+func TestIPAMDeleteDeletionProtection(t *testing.T) {
+	ctx := context.Background()
+	t.Parallel()
+
+	d := schema.TestResourceDataRaw(t, tfec2.ResourceIPAM().Schema, map[string]interface{}{
+		"deletion_protection": true,
+	})
+	d.SetId("ipam-12345678")
+
+	// The IPAM is protected before any AWS API is called, so the client has no connections.
+	diags := tfec2.ResourceIPAM().DeleteWithoutTimeout(ctx, d, &conns.AWSClient{})
+
+	if !diags.HasError() {
+		t.Fatal("expected error, got none")
+	}
+
+	if got, want := diags[0].Summary, "deletion_protection is enabled"; !strings.Contains(got, want) {
+		t.Errorf("got error %q, expected it to contain %q", got, want)
+	}
+}
+
 func TestAccIPAM_basic(t *testing.T) {
 	ctx := acctest.Context(t)
 	var ipam ec2.Ipam
@@ -31,9 +499,12 @@ func TestAccIPAM_basic(t *testing.T) {
 				Check: resource.ComposeAggregateTestCheckFunc(
 					testAccCheckIPAMExists(ctx, resourceName, &ipam),
 					resource.TestCheckResourceAttrSet(resourceName, "arn"),
+					resource.TestMatchResourceAttr(resourceName, "default_resource_discovery_association_id", regexp.MustCompile(`^ipam-res-disco-assoc-[\da-f]+$`)),
+					resource.TestMatchResourceAttr(resourceName, "default_resource_discovery_id", regexp.MustCompile(`^ipam-res-disco-[\da-f]+$`)),
 					resource.TestCheckResourceAttr(resourceName, "description", ""),
 					resource.TestCheckResourceAttr(resourceName, "operating_regions.#", "1"),
 					resource.TestCheckResourceAttr(resourceName, "scope_count", "2"),
+					resource.TestCheckResourceAttr(resourceName, "state", "create-complete"),
 					resource.TestCheckResourceAttr(resourceName, "tags.%", "0"),
 				),
 			},
@@ -46,6 +517,40 @@ func TestAccIPAM_basic(t *testing.T) {
 	})
 }
 
+func TestAccIPAM_scopeCountStable(t *testing.T) {
+	ctx := acctest.Context(t)
+	var ipam ec2.Ipam
+	resourceName := "aws_vpc_ipam.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ErrorCheck:               acctest.ErrorCheck(t, ec2.EndpointsID),
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckIPAMDestroy(ctx),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccIPAMConfig_basic,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckIPAMExists(ctx, resourceName, &ipam),
+					resource.TestCheckResourceAttr(resourceName, "scope_count", "2"),
+					resource.TestCheckResourceAttrSet(resourceName, "private_default_scope_id"),
+					resource.TestCheckResourceAttrSet(resourceName, "public_default_scope_id"),
+				),
+			},
+			{
+				RefreshState: true,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "scope_count", "2"),
+				),
+			},
+			{
+				Config:   testAccIPAMConfig_basic,
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
 func TestAccIPAM_disappears(t *testing.T) {
 	ctx := acctest.Context(t)
 	var ipam ec2.Ipam
@@ -144,6 +649,59 @@ func TestAccIPAM_operatingRegions(t *testing.T) {
 	})
 }
 
+func TestAccIPAM_operatingRegionsRemoveWithPool(t *testing.T) {
+	ctx := acctest.Context(t)
+	var ipam ec2.Ipam
+	resourceName := "aws_vpc_ipam.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t); acctest.PreCheckMultipleRegion(t, 2) },
+		ErrorCheck:               acctest.ErrorCheck(t, ec2.EndpointsID),
+		ProtoV5ProviderFactories: acctest.ProtoV5FactoriesMultipleRegions(t, 2),
+		CheckDestroy:             testAccCheckIPAMDestroy(ctx),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccIPAMConfig_operatingRegionsPool(true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIPAMExists(ctx, resourceName, &ipam),
+					resource.TestCheckResourceAttr(resourceName, "operating_regions.#", "2"),
+				),
+			},
+			{
+				Config:      testAccIPAMConfig_operatingRegionsPool(false),
+				ExpectError: regexp.MustCompile(`can't be removed while IPAM Pools are localized to them: ipam-pool-`),
+			},
+		},
+	})
+}
+
+func TestAccIPAM_operatingRegionsHomeRegionRemoved(t *testing.T) {
+	ctx := acctest.Context(t)
+	var ipam ec2.Ipam
+	resourceName := "aws_vpc_ipam.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t); acctest.PreCheckMultipleRegion(t, 2) },
+		ErrorCheck:               acctest.ErrorCheck(t, ec2.EndpointsID),
+		ProtoV5ProviderFactories: acctest.ProtoV5FactoriesMultipleRegions(t, 2),
+		CheckDestroy:             testAccCheckIPAMDestroy(ctx),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccIPAMConfig_twoOperatingRegions(),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIPAMExists(ctx, resourceName, &ipam),
+					resource.TestCheckResourceAttr(resourceName, "operating_regions.#", "2"),
+				),
+			},
+			{
+				Config:      testAccIPAMConfig_alternateOperatingRegion(),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("`operating_regions` must include"),
+			},
+		},
+	})
+}
+
 func TestAccIPAM_cascade(t *testing.T) {
 	ctx := acctest.Context(t)
 	var ipam ec2.Ipam
@@ -172,6 +730,64 @@ func TestAccIPAM_cascade(t *testing.T) {
 	})
 }
 
+func TestAccIPAM_deletionProtection(t *testing.T) {
+	ctx := acctest.Context(t)
+	var ipam1, ipam2 ec2.Ipam
+	resourceName := "aws_vpc_ipam.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ErrorCheck:               acctest.ErrorCheck(t, ec2.EndpointsID),
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckIPAMDestroy(ctx),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccIPAMConfig_deletionProtection(true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIPAMExists(ctx, resourceName, &ipam1),
+					resource.TestCheckResourceAttr(resourceName, "deletion_protection", "true"),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"deletion_protection"},
+			},
+			{
+				Config:      testAccIPAMConfig_deletionProtection(true),
+				Destroy:     true,
+				ExpectError: regexp.MustCompile(`deletion_protection is enabled`),
+			},
+			{
+				Config: testAccIPAMConfig_deletionProtection(false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIPAMExists(ctx, resourceName, &ipam2),
+					testAccCheckIPAMNotRecreated(&ipam1, &ipam2),
+					resource.TestCheckResourceAttr(resourceName, "deletion_protection", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccIPAM_operatingRegionsEmpty(t *testing.T) {
+	ctx := acctest.Context(t)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ErrorCheck:               acctest.ErrorCheck(t, ec2.EndpointsID),
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckIPAMDestroy(ctx),
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccIPAMConfig_operatingRegionsEmpty,
+				ExpectError: regexp.MustCompile(`Insufficient operating_regions blocks`),
+			},
+		},
+	})
+}
+
 func TestAccIPAM_tags(t *testing.T) {
 	ctx := acctest.Context(t)
 	var ipam ec2.Ipam
@@ -268,6 +884,16 @@ func testAccCheckIPAMDestroy(ctx context.Context) resource.TestCheckFunc {
 	}
 }
 
+func testAccCheckIPAMNotRecreated(i, j *ec2.Ipam) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if aws.StringValue(i.IpamId) != aws.StringValue(j.IpamId) {
+			return fmt.Errorf("IPAM was recreated")
+		}
+
+		return nil
+	}
+}
+
 func testAccCheckIPAMScopeCreate(ctx context.Context, ipam *ec2.Ipam) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		conn := acctest.Provider.Meta().(*conns.AWSClient).EC2Conn()
@@ -302,6 +928,31 @@ resource "aws_vpc_ipam" "test" {
 }
 `
 
+func testAccIPAMConfig_deletionProtection(deletionProtection bool) string {
+	return fmt.Sprintf(`
+data "aws_region" "current" {}
+
+resource "aws_vpc_ipam" "test" {
+  operating_regions {
+    region_name = data.aws_region.current.name
+  }
+  deletion_protection = %[1]t
+}
+`, deletionProtection)
+}
+
+const testAccIPAMConfig_operatingRegionsEmpty = `
+resource "aws_vpc_ipam" "test" {
+  dynamic "operating_regions" {
+    for_each = []
+
+    content {
+      region_name = operating_regions.value
+    }
+  }
+}
+`
+
 func testAccIPAMConfig_description(description string) string {
 	return fmt.Sprintf(`
 data "aws_region" "current" {}
@@ -336,6 +987,50 @@ resource "aws_vpc_ipam" "test" {
 `)
 }
 
+func testAccIPAMConfig_alternateOperatingRegion() string {
+	return acctest.ConfigCompose(acctest.ConfigMultipleRegionProvider(2), `
+data "aws_region" "alternate" {
+  provider = awsalternate
+}
+
+resource "aws_vpc_ipam" "test" {
+  operating_regions {
+    region_name = data.aws_region.alternate.name
+  }
+}
+`)
+}
+
+func testAccIPAMConfig_operatingRegionsPool(alternate bool) string {
+	return acctest.ConfigCompose(acctest.ConfigMultipleRegionProvider(2), fmt.Sprintf(`
+data "aws_region" "current" {}
+
+data "aws_region" "alternate" {
+  provider = awsalternate
+}
+
+resource "aws_vpc_ipam" "test" {
+  operating_regions {
+    region_name = data.aws_region.current.name
+  }
+
+  dynamic "operating_regions" {
+    for_each = %[1]t ? [data.aws_region.alternate.name] : []
+
+    content {
+      region_name = operating_regions.value
+    }
+  }
+}
+
+resource "aws_vpc_ipam_pool" "test" {
+  address_family = "ipv4"
+  ipam_scope_id  = aws_vpc_ipam.test.private_default_scope_id
+  locale         = data.aws_region.alternate.name
+}
+`, alternate))
+}
+
 func testAccIPAMConfig_tags(tagKey1, tagValue1 string) string {
 	return fmt.Sprintf(`
 data "aws_region" "current" {}
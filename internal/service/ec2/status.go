@@ -1333,6 +1333,20 @@ func StatusIPAMPoolCIDRState(ctx context.Context, conn *ec2.EC2, cidrBlock, pool
 	}
 }
 
+// StatusIPAMPoolCIDRDeprovisionState reports a deprovisioned pool CIDR as not found, as StatusIPAMPoolCIDRState does.
+// A CIDR that failed to be provisioned or imported takes no space in the pool, so it is reported as not found too.
+func StatusIPAMPoolCIDRDeprovisionState(ctx context.Context, conn *ec2.EC2, cidrBlock, poolID string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		output, state, err := StatusIPAMPoolCIDRState(ctx, conn, cidrBlock, poolID)()
+
+		if state == ec2.IpamPoolCidrStateFailedProvision || state == ec2.IpamPoolCidrStateFailedImport {
+			return nil, "", nil
+		}
+
+		return output, state, err
+	}
+}
+
 const (
 	// naming mapes to the SDK constants that exist for IPAM
 	IpamPoolCIDRAllocationCreateComplete = "create-complete" // nosemgrep:ci.caps2-in-const-name, ci.caps2-in-var-name, ci.caps5-in-const-name, ci.caps5-in-var-name
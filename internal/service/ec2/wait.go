@@ -2816,13 +2816,17 @@ func WaitIPAMUpdated(ctx context.Context, conn *ec2.EC2, id string, timeout time
 	return nil, err
 }
 
+const (
+	ipamPoolStateDelay = 5 * time.Second
+)
+
 func WaitIPAMPoolCreated(ctx context.Context, conn *ec2.EC2, id string, timeout time.Duration) (*ec2.IpamPool, error) {
 	stateConf := &resource.StateChangeConf{
 		Pending: []string{ec2.IpamPoolStateCreateInProgress},
 		Target:  []string{ec2.IpamPoolStateCreateComplete},
 		Refresh: StatusIPAMPoolState(ctx, conn, id),
 		Timeout: timeout,
-		Delay:   5 * time.Second,
+		Delay:   ipamPoolStateDelay,
 	}
 
 	outputRaw, err := stateConf.WaitForStateContext(ctx)
@@ -2844,7 +2848,7 @@ func WaitIPAMPoolDeleted(ctx context.Context, conn *ec2.EC2, id string, timeout
 		Target:  []string{},
 		Refresh: StatusIPAMPoolState(ctx, conn, id),
 		Timeout: timeout,
-		Delay:   5 * time.Second,
+		Delay:   ipamPoolStateDelay,
 	}
 
 	outputRaw, err := stateConf.WaitForStateContext(ctx)
@@ -2866,7 +2870,7 @@ func WaitIPAMPoolUpdated(ctx context.Context, conn *ec2.EC2, id string, timeout
 		Target:  []string{ec2.IpamPoolStateModifyComplete},
 		Refresh: StatusIPAMPoolState(ctx, conn, id),
 		Timeout: timeout,
-		Delay:   5 * time.Second,
+		Delay:   ipamPoolStateDelay,
 	}
 
 	outputRaw, err := stateConf.WaitForStateContext(ctx)
@@ -2882,13 +2886,34 @@ func WaitIPAMPoolUpdated(ctx context.Context, conn *ec2.EC2, id string, timeout
 	return nil, err
 }
 
+// WaitIPAMPoolStable waits for an in-progress create or modify of an IPAM pool to finish, successfully or not.
+func WaitIPAMPoolStable(ctx context.Context, conn *ec2.EC2, id string, timeout time.Duration) (*ec2.IpamPool, error) {
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{ec2.IpamPoolStateCreateInProgress, ec2.IpamPoolStateModifyInProgress},
+		Target:  []string{ec2.IpamPoolStateCreateComplete, ec2.IpamPoolStateCreateFailed, ec2.IpamPoolStateModifyComplete, ec2.IpamPoolStateModifyFailed},
+		Refresh: StatusIPAMPoolState(ctx, conn, id),
+		Timeout: timeout,
+		Delay:   ipamPoolStateDelay,
+	}
+
+	outputRaw, err := stateConf.WaitForStateContext(ctx)
+
+	if output, ok := outputRaw.(*ec2.IpamPool); ok {
+		return output, err
+	}
+
+	return nil, err
+}
+
+// WaitIPAMPoolCIDRCreated waits for a pool CIDR to move from pending-provision to provisioned.
+// It fails as soon as the CIDR moves to failed-provision, with the failure reason as the last error.
 func WaitIPAMPoolCIDRCreated(ctx context.Context, conn *ec2.EC2, cidrBlock, poolID string, timeout time.Duration) (*ec2.IpamPoolCidr, error) {
 	stateConf := &resource.StateChangeConf{
 		Pending: []string{ec2.IpamPoolCidrStatePendingProvision},
 		Target:  []string{ec2.IpamPoolCidrStateProvisioned},
 		Refresh: StatusIPAMPoolCIDRState(ctx, conn, cidrBlock, poolID),
 		Timeout: timeout,
-		Delay:   5 * time.Second,
+		Delay:   ipamPoolStateDelay,
 	}
 
 	outputRaw, err := stateConf.WaitForStateContext(ctx)
@@ -2904,13 +2929,15 @@ func WaitIPAMPoolCIDRCreated(ctx context.Context, conn *ec2.EC2, cidrBlock, pool
 	return nil, err
 }
 
+// WaitIPAMPoolCIDRDeleted waits for a pool CIDR to move through pending-deprovision to deprovisioned.
+// It fails as soon as the CIDR moves to failed-deprovision, with the failure reason as the last error.
 func WaitIPAMPoolCIDRDeleted(ctx context.Context, conn *ec2.EC2, cidrBlock, poolID string, timeout time.Duration) (*ec2.IpamPoolCidr, error) {
 	stateConf := &resource.StateChangeConf{
 		Pending: []string{ec2.IpamPoolCidrStatePendingDeprovision, ec2.IpamPoolCidrStateProvisioned},
 		Target:  []string{},
-		Refresh: StatusIPAMPoolCIDRState(ctx, conn, cidrBlock, poolID),
+		Refresh: StatusIPAMPoolCIDRDeprovisionState(ctx, conn, cidrBlock, poolID),
 		Timeout: timeout,
-		Delay:   5 * time.Second,
+		Delay:   ipamPoolStateDelay,
 	}
 
 	outputRaw, err := stateConf.WaitForStateContext(ctx)
@@ -2944,6 +2971,24 @@ func WaitIPAMPoolCIDRAllocationCreated(ctx context.Context, conn *ec2.EC2, alloc
 	return nil, err
 }
 
+func WaitIPAMPoolCIDRAllocationDeleted(ctx context.Context, conn *ec2.EC2, allocationID, poolID string, timeout time.Duration) (*ec2.IpamPoolAllocation, error) {
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{IpamPoolCIDRAllocationCreateComplete},
+		Target:  []string{},
+		Refresh: StatusIPAMPoolCIDRAllocationState(ctx, conn, allocationID, poolID),
+		Timeout: timeout,
+		Delay:   ipamPoolStateDelay,
+	}
+
+	outputRaw, err := stateConf.WaitForStateContext(ctx)
+
+	if output, ok := outputRaw.(*ec2.IpamPoolAllocation); ok {
+		return output, err
+	}
+
+	return nil, err
+}
+
 func WaitIPAMScopeCreated(ctx context.Context, conn *ec2.EC2, id string, timeout time.Duration) (*ec2.IpamScope, error) {
 	stateConf := &resource.StateChangeConf{
 		Pending: []string{ec2.IpamScopeStateCreateInProgress},
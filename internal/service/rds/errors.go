@@ -4,4 +4,6 @@ const (
 	errCodeInvalidParameterValue       = "InvalidParameterValue"
 	errCodeValidationError             = "ValidationError"
 	errCodeInvalidParameterCombination = "InvalidParameterCombination"
+	errCodeRequestLimitExceeded        = "RequestLimitExceeded"
+	errCodeThrottling                  = "Throttling"
 )
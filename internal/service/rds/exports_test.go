@@ -1,3 +1,30 @@
 package rds
 
-var FindDBInstanceByID = findDBInstanceByIDSDKv1
+// Exports for use in tests only.
+var (
+	CheckParameterGroupImmediateApply     = checkParameterGroupImmediateApply
+	ChangedParametersFromValues           = changedParameters
+	CopyParameterGroup                    = copyParameterGroup
+	ConfiguredParameterValues             = configuredParameterValues
+	ConfiguredStaticParameterApplyMethods = configuredStaticParameterApplyMethods
+	EstimatedParameterModifyCalls         = estimatedParameterModifyCalls
+	FindDBInstanceByID                    = findDBInstanceByIDSDKv1
+	FindParameterGroupOrderedParameters   = findParameterGroupOrderedParameters
+	FindParameterGroupParameters          = findParameterGroupParameters
+	FlattenParameterGroupAllParameters    = flattenParameterGroupAllParameters
+	FlattenParameterGroupDiff             = flattenParameterGroupDiff
+	FlattenParameterGroupParameters       = flattenParameterGroupParameters
+	ModifyParameterGroupParameters        = modifyParameterGroupParameters
+	ModifyParameterGroupParametersInOrder = modifyParameterGroupParametersInOrder
+	OrderedParametersToModify             = orderedParametersToModify
+	ParameterCountLimit                   = parameterCountLimit
+	ParameterModifyPrioritiesForFamily    = parameterModifyPriorities
+	ParameterValueDiffSuppress            = parameterValueDiffSuppress
+	ParameterGroupImportID                = parameterGroupImportID
+	ParametersHaveImmediateApplyMethod    = parametersHaveImmediateApplyMethod
+	ParametersRequireReboot               = parametersRequireReboot
+	ReservedParameters                    = reservedParameters
+	ResetParameterGroupParameters         = resetParameterGroupParameters
+	RollbackParameterGroupParameters      = rollbackParameterGroupParameters
+	StaticParametersPendingReboot         = staticParametersPendingReboot
+)
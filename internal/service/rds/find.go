@@ -2,6 +2,7 @@ package rds
 
 import (
 	"context"
+	"strings"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/rds"
@@ -216,6 +217,103 @@ func FindDBSnapshotByID(ctx context.Context, conn *rds.RDS, id string) (*rds.DBS
 	return dbSnapshot, nil
 }
 
+func FindDBParameterGroupByName(ctx context.Context, conn *rds.RDS, name string) (*rds.DBParameterGroup, error) {
+	input := &rds.DescribeDBParameterGroupsInput{
+		DBParameterGroupName: aws.String(name),
+	}
+
+	output, err := conn.DescribeDBParameterGroupsWithContext(ctx, input)
+
+	if tfawserr.ErrCodeEquals(err, rds.ErrCodeDBParameterGroupNotFoundFault) {
+		return nil, &resource.NotFoundError{
+			LastError:   err,
+			LastRequest: input,
+		}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if output == nil || len(output.DBParameterGroups) == 0 || output.DBParameterGroups[0] == nil {
+		return nil, tfresource.NewEmptyResultError(input)
+	}
+
+	dbParameterGroup := output.DBParameterGroups[0]
+
+	// Eventual consistency check.
+	if aws.StringValue(dbParameterGroup.DBParameterGroupName) != name {
+		return nil, &resource.NotFoundError{
+			LastRequest: input,
+		}
+	}
+
+	return dbParameterGroup, nil
+}
+
+// FindDBParameterByTwoPartKey returns the named parameter of the named DB parameter group, whatever its source.
+func FindDBParameterByTwoPartKey(ctx context.Context, conn *rds.RDS, parameterGroupName, name string) (*rds.Parameter, error) {
+	input := &rds.DescribeDBParametersInput{
+		DBParameterGroupName: aws.String(parameterGroupName),
+	}
+
+	parameters, err := findDBParameters(ctx, conn, input)
+
+	if tfawserr.ErrCodeEquals(err, rds.ErrCodeDBParameterGroupNotFoundFault) {
+		return nil, &resource.NotFoundError{
+			LastError:   err,
+			LastRequest: input,
+		}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, v := range parameters {
+		if v != nil && strings.EqualFold(aws.StringValue(v.ParameterName), name) {
+			return v, nil
+		}
+	}
+
+	return nil, &resource.NotFoundError{
+		LastRequest: input,
+	}
+}
+
+// findDBInstancesByParameterGroupName returns the DB instances that the DB parameter group is attached to.
+// DescribeDBInstances has no filter for DB parameter groups, so all DB instances are described.
+func findDBInstancesByParameterGroupName(ctx context.Context, conn *rds.RDS, name string) ([]*rds.DBInstance, error) {
+	var output []*rds.DBInstance
+
+	err := conn.DescribeDBInstancesPagesWithContext(ctx, &rds.DescribeDBInstancesInput{}, func(page *rds.DescribeDBInstancesOutput, lastPage bool) bool {
+		if page == nil {
+			return !lastPage
+		}
+
+		for _, v := range page.DBInstances {
+			if v == nil {
+				continue
+			}
+
+			for _, group := range v.DBParameterGroups {
+				if aws.StringValue(group.DBParameterGroupName) == name {
+					output = append(output, v)
+					break
+				}
+			}
+		}
+
+		return !lastPage
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return output, nil
+}
+
 func FindDBSubnetGroupByName(ctx context.Context, conn *rds.RDS, name string) (*rds.DBSubnetGroup, error) {
 	input := &rds.DescribeDBSubnetGroupsInput{
 		DBSubnetGroupName: aws.String(name),
@@ -1,6 +1,7 @@
 package rds
 
 import (
+	"sort"
 	"strings"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -294,6 +295,26 @@ func expandParameters(configured []interface{}) []*rds.Parameter {
 	return parameters
 }
 
+func expandParameterGroupConstraints(tfList []interface{}) [][]string {
+	var constraints [][]string
+
+	for _, tfMapRaw := range tfList {
+		tfMap, ok := tfMapRaw.(map[string]interface{})
+
+		if !ok {
+			continue
+		}
+
+		if v, ok := tfMap["names"].(*schema.Set); ok && v.Len() > 0 {
+			names := flex.ExpandStringValueSet(v)
+			sort.Strings(names)
+			constraints = append(constraints, names)
+		}
+	}
+
+	return constraints
+}
+
 // Flattens an array of Parameters into a []map[string]interface{}
 func flattenParameters(list []*rds.Parameter) []map[string]interface{} {
 	result := make([]map[string]interface{}, 0, len(list))
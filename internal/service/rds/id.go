@@ -31,3 +31,22 @@ func ClusterRoleAssociationParseResourceID(id string) (string, string, error) {
 
 	return "", "", fmt.Errorf("unexpected format for ID (%[1]s), expected DBCLUSTERID%[2]sROLEARN", id, clusterRoleAssociationResourceIDSeparator)
 }
+
+const parameterResourceIDSeparator = ","
+
+func ParameterCreateResourceID(parameterGroupName, name string) string {
+	parts := []string{parameterGroupName, name}
+	id := strings.Join(parts, parameterResourceIDSeparator)
+
+	return id
+}
+
+func ParameterParseResourceID(id string) (string, string, error) {
+	parts := strings.Split(id, parameterResourceIDSeparator)
+
+	if len(parts) == 2 && parts[0] != "" && parts[1] != "" {
+		return parts[0], parts[1], nil
+	}
+
+	return "", "", fmt.Errorf("unexpected format for ID (%[1]s), expected PARAMETERGROUPNAME%[2]sNAME", id, parameterResourceIDSeparator)
+}
@@ -0,0 +1,186 @@
+package rds
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/rds"
+	"github.com/hashicorp/aws-sdk-go-base/v2/awsv1shim/v2/tfawserr"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/hashicorp/terraform-provider-aws/internal/conns"
+	"github.com/hashicorp/terraform-provider-aws/internal/errs/sdkdiag"
+	"github.com/hashicorp/terraform-provider-aws/internal/tfresource"
+)
+
+func ResourceParameter() *schema.Resource {
+	return &schema.Resource{
+		CreateWithoutTimeout: resourceParameterCreate,
+		ReadWithoutTimeout:   resourceParameterRead,
+		UpdateWithoutTimeout: resourceParameterUpdate,
+		DeleteWithoutTimeout: resourceParameterDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"apply_method": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      rds.ApplyMethodImmediate,
+				ValidateFunc: validation.StringInSlice(rds.ApplyMethod_Values(), false),
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"parameter_group_name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"value": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+		},
+	}
+}
+
+func resourceParameterCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	conn := meta.(*conns.AWSClient).RDSConn()
+
+	parameterGroupName, name := d.Get("parameter_group_name").(string), d.Get("name").(string)
+	id := ParameterCreateResourceID(parameterGroupName, name)
+
+	if err := modifyParameter(ctx, conn, parameterGroupName, expandParameter(d)); err != nil {
+		return sdkdiag.AppendErrorf(diags, "creating RDS DB Parameter (%s): %s", id, err)
+	}
+
+	d.SetId(id)
+
+	return append(diags, resourceParameterRead(ctx, d, meta)...)
+}
+
+func resourceParameterRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	conn := meta.(*conns.AWSClient).RDSConn()
+
+	parameterGroupName, name, err := ParameterParseResourceID(d.Id())
+
+	if err != nil {
+		return sdkdiag.AppendErrorf(diags, "reading RDS DB Parameter (%s): %s", d.Id(), err)
+	}
+
+	parameter, err := FindDBParameterByTwoPartKey(ctx, conn, parameterGroupName, name)
+
+	// A parameter that was reset outside of Terraform has its default value again, so it is no longer managed.
+	if err == nil && aws.StringValue(parameter.Source) != "user" {
+		err = &resource.NotFoundError{
+			Message: fmt.Sprintf("parameter source is %s", aws.StringValue(parameter.Source)),
+		}
+	}
+
+	if !d.IsNewResource() && tfresource.NotFound(err) {
+		log.Printf("[WARN] RDS DB Parameter (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return diags
+	}
+
+	if err != nil {
+		return sdkdiag.AppendErrorf(diags, "reading RDS DB Parameter (%s): %s", d.Id(), err)
+	}
+
+	// A static parameter configured with the immediate apply method is applied with pending-reboot.
+	parameter = configuredStaticParameterApplyMethods([]*rds.Parameter{parameter}, []*rds.Parameter{expandParameter(d)})[0]
+
+	d.Set("apply_method", strings.ToLower(aws.StringValue(parameter.ApplyMethod)))
+	d.Set("name", name)
+	d.Set("parameter_group_name", parameterGroupName)
+	d.Set("value", parameter.ParameterValue)
+
+	return diags
+}
+
+func resourceParameterUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	conn := meta.(*conns.AWSClient).RDSConn()
+
+	if d.HasChanges("apply_method", "value") {
+		if err := modifyParameter(ctx, conn, d.Get("parameter_group_name").(string), expandParameter(d)); err != nil {
+			return sdkdiag.AppendErrorf(diags, "updating RDS DB Parameter (%s): %s", d.Id(), err)
+		}
+	}
+
+	return append(diags, resourceParameterRead(ctx, d, meta)...)
+}
+
+func resourceParameterDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	conn := meta.(*conns.AWSClient).RDSConn()
+
+	parameterGroupName := d.Get("parameter_group_name").(string)
+
+	mutexKey := parameterGroupMutexKey(parameterGroupName)
+	conns.GlobalMutexKV.Lock(mutexKey)
+	defer conns.GlobalMutexKV.Unlock(mutexKey)
+
+	log.Printf("[DEBUG] Deleting RDS DB Parameter: %s", d.Id())
+	_, err := resetParameterGroupParameters(ctx, conn, parameterGroupName, []*rds.Parameter{expandParameter(d)}, false)
+
+	if tfawserr.ErrCodeEquals(err, rds.ErrCodeDBParameterGroupNotFoundFault) {
+		return diags
+	}
+
+	if err != nil {
+		return sdkdiag.AppendErrorf(diags, "deleting RDS DB Parameter (%s): %s", d.Id(), err)
+	}
+
+	return diags
+}
+
+func expandParameter(d *schema.ResourceData) *rds.Parameter {
+	return &rds.Parameter{
+		ApplyMethod:    aws.String(d.Get("apply_method").(string)),
+		ParameterName:  aws.String(d.Get("name").(string)),
+		ParameterValue: aws.String(d.Get("value").(string)),
+	}
+}
+
+// modifyParameter applies a single parameter to the named DB parameter group, with the pending-reboot apply method in
+// place of immediate if the parameter is static. The group is locked against other parameter changes made by the provider.
+func modifyParameter(ctx context.Context, conn *rds.RDS, parameterGroupName string, parameter *rds.Parameter) error {
+	mutexKey := parameterGroupMutexKey(parameterGroupName)
+	conns.GlobalMutexKV.Lock(mutexKey)
+	defer conns.GlobalMutexKV.Unlock(mutexKey)
+
+	name := aws.StringValue(parameter.ParameterName)
+	current, err := FindDBParameterByTwoPartKey(ctx, conn, parameterGroupName, name)
+
+	if err != nil {
+		return fmt.Errorf("reading parameter: %w", err)
+	}
+
+	parameters, upgraded := staticParametersPendingReboot([]*rds.Parameter{parameter}, map[string]*rds.Parameter{strings.ToLower(name): current})
+
+	if len(upgraded) > 0 {
+		log.Printf("[WARN] RDS DB Parameter Group (%s) parameter %q is static, applying it with the pending-reboot apply method instead of immediate", parameterGroupName, name)
+	}
+
+	input := &rds.ModifyDBParameterGroupInput{
+		DBParameterGroupName: aws.String(parameterGroupName),
+		Parameters:           parameters,
+	}
+
+	_, err = conn.ModifyDBParameterGroupWithContext(ctx, input)
+
+	return err
+}
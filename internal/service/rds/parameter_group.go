@@ -3,17 +3,23 @@ package rds
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"log"
+	"regexp"
+	"sort"
 	"strings"
 	"time"
+	"unicode"
 
 	rds_sdkv2 "github.com/aws/aws-sdk-go-v2/service/rds"
 	"github.com/aws/aws-sdk-go-v2/service/rds/types"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/rds"
 	"github.com/hashicorp/aws-sdk-go-base/v2/awsv1shim/v2/tfawserr"
+	"github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-provider-aws/internal/conns"
@@ -32,10 +38,30 @@ func ResourceParameterGroup() *schema.Resource {
 		UpdateWithoutTimeout: resourceParameterGroupUpdate,
 		DeleteWithoutTimeout: resourceParameterGroupDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: resourceParameterGroupImport,
 		},
 
 		Schema: map[string]*schema.Schema{
+			"all_parameters": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"source": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"value": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
 			"arn": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -56,6 +82,19 @@ func ResourceParameterGroup() *schema.Resource {
 				ConflictsWith: []string{"name"},
 				ValidateFunc:  validParamGroupNamePrefix,
 			},
+			"engine": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"estimated_modify_calls": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"fail_on_immediate_apply": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
 			"family": {
 				Type:     schema.TypeString,
 				Required: true,
@@ -67,9 +106,10 @@ func ResourceParameterGroup() *schema.Resource {
 				ForceNew: true,
 				Default:  "Managed by Terraform",
 			},
-			"parameter": {
-				Type:     schema.TypeSet,
-				Optional: true,
+			"ordered_parameter": {
+				Type:          schema.TypeList,
+				Optional:      true,
+				ConflictsWith: []string{"parameter"},
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"apply_method": {
@@ -77,25 +117,335 @@ func ResourceParameterGroup() *schema.Resource {
 							Optional: true,
 							Default:  "immediate",
 						},
+						"description": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"is_modifiable": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"is_static": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"minimum_engine_version": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
 						"name": {
 							Type:     schema.TypeString,
 							Required: true,
 						},
 						"value": {
+							Type:             schema.TypeString,
+							Required:         true,
+							DiffSuppressFunc: parameterValueDiffSuppress,
+						},
+					},
+				},
+			},
+			"parameter": {
+				Type:          schema.TypeSet,
+				Optional:      true,
+				ConflictsWith: []string{"ordered_parameter"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"apply_method": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Default:  "immediate",
+						},
+						"description": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"is_modifiable": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"is_static": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"minimum_engine_version": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
 							Type:     schema.TypeString,
 							Required: true,
 						},
+						"value": {
+							Type:             schema.TypeString,
+							Required:         true,
+							DiffSuppressFunc: parameterValueDiffSuppress,
+						},
 					},
 				},
 				Set: resourceParameterHash,
 			},
+			"parameter_group_constraint": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"names": {
+							Type:     schema.TypeSet,
+							Required: true,
+							MinItems: 2,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+			"prune_default_value_parameters": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"requires_reboot": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"reset_all_parameters_on_clear": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"rollback_on_failure": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"skip_unchanged_parameters": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"snapshot_all_parameters": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"trim_parameter_value_whitespace": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
 
 			"tags":     tftags.TagsSchema(),
 			"tags_all": tftags.TagsSchemaComputed(),
 		},
 
-		CustomizeDiff: verify.SetTagsDiff,
+		CustomizeDiff: customdiff.Sequence(
+			verify.SetTagsDiff,
+			resourceParameterGroupReservedParametersCustomizeDiff,
+			resourceParameterGroupImmediateApplyCustomizeDiff,
+			resourceParameterGroupEstimatedModifyCallsCustomizeDiff,
+			customdiff.ComputedIf("all_parameters", func(_ context.Context, diff *schema.ResourceDiff, _ interface{}) bool {
+				return diff.HasChange("snapshot_all_parameters") || (diff.Get("snapshot_all_parameters").(bool) && diff.HasChanges("ordered_parameter", "parameter"))
+			}),
+			customdiff.ComputedIf("requires_reboot", func(_ context.Context, diff *schema.ResourceDiff, _ interface{}) bool {
+				return diff.HasChanges("ordered_parameter", "parameter")
+			}),
+		),
+	}
+}
+
+// resourceParameterGroupReservedParametersCustomizeDiff rejects parameters that AWS manages for the family, e.g. when a
+// feature is enabled on the DB instance, as AWS may reset them outside of Terraform.
+func resourceParameterGroupReservedParametersCustomizeDiff(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	var names []string
+	for _, v := range append(diff.Get("ordered_parameter").([]interface{}), diff.Get("parameter").(*schema.Set).List()...) {
+		if tfMap, ok := v.(map[string]interface{}); ok {
+			names = append(names, tfMap["name"].(string))
+		}
+	}
+
+	family := diff.Get("family").(string)
+	if reserved := reservedParameters(family, diff.Get("engine").(string), names); len(reserved) > 0 {
+		return fmt.Errorf("parameters %s are managed by AWS for family %s and can't be declared", strings.Join(reserved, ", "), family)
+	}
+
+	return nil
+}
+
+// resourceParameterGroupImmediateApplyCustomizeDiff fails the plan when fail_on_immediate_apply is set and changed
+// parameters use the immediate apply method while the DB Parameter Group is attached to available DB instances, as the
+// change would affect those databases right away.
+func resourceParameterGroupImmediateApplyCustomizeDiff(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if diff.Id() == "" || !diff.Get("fail_on_immediate_apply").(bool) || !diff.HasChanges("ordered_parameter", "parameter") {
+		return nil
+	}
+
+	conn := meta.(*conns.AWSClient).RDSConn()
+
+	os, ns := diff.GetChange("parameter")
+	ol, nl := diff.GetChange("ordered_parameter")
+	o := expandParameters(append(ol.([]interface{}), os.(*schema.Set).List()...))
+	n := expandParameters(append(nl.([]interface{}), ns.(*schema.Set).List()...))
+
+	return checkParameterGroupImmediateApply(ctx, conn, diff.Id(), o, n)
+}
+
+// checkParameterGroupImmediateApply returns an error naming the changed parameters that use the immediate apply method
+// and the available DB instances that the DB Parameter Group is attached to, or nil if there are none of either.
+func checkParameterGroupImmediateApply(ctx context.Context, conn *rds.RDS, name string, o, n []*rds.Parameter) error {
+	parameterNames := immediateApplyParameterNames(o, n)
+
+	if len(parameterNames) == 0 {
+		return nil
+	}
+
+	instances, err := findDBInstancesByParameterGroupName(ctx, conn, name)
+
+	if err != nil {
+		return fmt.Errorf("reading DB instances using DB Parameter Group (%s): %w", name, err)
+	}
+
+	var instanceIDs []string
+	for _, v := range instances {
+		if aws.StringValue(v.DBInstanceStatus) == InstanceStatusAvailable {
+			instanceIDs = append(instanceIDs, aws.StringValue(v.DBInstanceIdentifier))
+		}
+	}
+
+	if len(instanceIDs) == 0 {
+		return nil
+	}
+
+	sort.Strings(instanceIDs)
+
+	return fmt.Errorf("DB Parameter Group (%s) parameters %s use the immediate apply method and would affect the live "+
+		"databases of DB instances %s right away", name, strings.Join(parameterNames, ", "), strings.Join(instanceIDs, ", "))
+}
+
+// immediateApplyParameterNames returns the sorted names of the new parameters that use the immediate apply method and
+// whose value changes from the old parameters.
+func immediateApplyParameterNames(o, n []*rds.Parameter) []string {
+	old := make(map[string]string, len(o))
+	for _, v := range o {
+		old[aws.StringValue(v.ParameterName)] = aws.StringValue(v.ParameterValue)
+	}
+
+	var names []string
+	for _, v := range n {
+		if aws.StringValue(v.ApplyMethod) != rds.ApplyMethodImmediate {
+			continue
+		}
+
+		name := aws.StringValue(v.ParameterName)
+		if value, ok := old[name]; ok && value == aws.StringValue(v.ParameterValue) {
+			continue
+		}
+
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// resourceParameterGroupEstimatedModifyCallsCustomizeDiff plans the number of ModifyDBParameterGroup calls that the
+// parameter changes will make, so that the apply duration and API usage of large groups can be anticipated.
+func resourceParameterGroupEstimatedModifyCallsCustomizeDiff(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	if !diff.HasChanges("ordered_parameter", "parameter") {
+		return nil
+	}
+
+	os, ns := diff.GetChange("parameter")
+	ol, nl := diff.GetChange("ordered_parameter")
+	constraints := expandParameterGroupConstraints(diff.Get("parameter_group_constraint").([]interface{}))
+
+	calls, err := estimatedParameterModifyCalls(os.(*schema.Set), ns.(*schema.Set), ol.([]interface{}), nl.([]interface{}), diff.Get("family").(string), diff.Get("engine").(string), constraints)
+
+	// A constraint that can't be met fails the apply, which reports it.
+	if err != nil {
+		return diff.SetNewComputed("estimated_modify_calls")
+	}
+
+	return diff.SetNew("estimated_modify_calls", calls)
+}
+
+// resourceParameterGroupImport imports a DB Parameter Group by name, optionally restricting the imported parameters to
+// a comma-separated list of parameter names, e.g. "<group_name>:<param1>,<param2>". A "/all_parameters" suffix also
+// snapshots every parameter of the group into all_parameters, e.g. for an audit of the imported group.
+func resourceParameterGroupImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	name, parameterNames, allParameters, err := parameterGroupImportID(d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	// The parameter name list is kept in the ID until the read following the import, which restricts the imported
+	// parameters to the named ones.
+	if len(parameterNames) > 0 {
+		d.SetId(name + ":" + strings.Join(parameterNames, ","))
+	} else {
+		d.SetId(name)
+	}
+	d.Set("fail_on_immediate_apply", false)
+	d.Set("prune_default_value_parameters", false)
+	d.Set("requires_reboot", false)
+	d.Set("reset_all_parameters_on_clear", false)
+	d.Set("rollback_on_failure", false)
+	d.Set("skip_unchanged_parameters", false)
+	d.Set("snapshot_all_parameters", allParameters)
+	d.Set("trim_parameter_value_whitespace", false)
+
+	if len(parameterNames) > 0 {
+		// The values are read from AWS on the read following the import.
+		parameters := make([]interface{}, 0, len(parameterNames))
+		for _, v := range parameterNames {
+			parameters = append(parameters, map[string]interface{}{
+				"apply_method": "immediate",
+				"name":         v,
+				"value":        "",
+			})
+		}
+
+		if err := d.Set("parameter", parameters); err != nil {
+			return nil, fmt.Errorf("setting parameter: %w", err)
+		}
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+const parameterGroupImportIDAllParametersSuffix = "/all_parameters"
+
+// parameterGroupImportID parses an import ID of the form "<group_name>" or "<group_name>:<param1>,<param2>", either
+// optionally followed by "/all_parameters". The parameter names are lower-cased, like those read from AWS.
+func parameterGroupImportID(id string) (string, []string, bool, error) {
+	allParameters := strings.HasSuffix(id, parameterGroupImportIDAllParametersSuffix)
+	trimmed := strings.TrimSuffix(id, parameterGroupImportIDAllParametersSuffix)
+
+	name, names, found := strings.Cut(trimmed, ":")
+	if !found {
+		if name == "" {
+			return "", nil, false, fmt.Errorf("unexpected format for ID (%[1]s), expected <group_name> or <group_name>:<param1>,<param2>", id)
+		}
+
+		return name, nil, allParameters, nil
+	}
+
+	var parameterNames []string
+	for _, v := range strings.Split(names, ",") {
+		v = strings.ToLower(strings.TrimSpace(v))
+		if v == "" {
+			parameterNames = nil
+			break
+		}
+
+		parameterNames = append(parameterNames, v)
+	}
+
+	if name == "" || len(parameterNames) == 0 {
+		return "", nil, false, fmt.Errorf("unexpected format for ID (%[1]s), expected <group_name> or <group_name>:<param1>,<param2>", id)
 	}
+
+	return name, parameterNames, allParameters, nil
 }
 
 func resourceParameterGroupCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -123,6 +473,10 @@ func resourceParameterGroupCreate(ctx context.Context, d *schema.ResourceData, m
 
 	log.Printf("[DEBUG] Create DB Parameter Group: %#v", createOpts)
 	resp, err := conn.CreateDBParameterGroupWithContext(ctx, &createOpts)
+	if tfawserr.ErrCodeEquals(err, rds.ErrCodeDBParameterGroupAlreadyExistsFault) {
+		return sdkdiag.AppendErrorf(diags, "creating DB Parameter Group (%[1]s): a DB Parameter Group named %[1]q already exists. "+
+			"To manage it with Terraform, import it (e.g. terraform import aws_db_parameter_group.example %[1]s): %[2]s", groupName, err)
+	}
 	if err != nil {
 		return sdkdiag.AppendErrorf(diags, "creating DB Parameter Group: %s", err)
 	}
@@ -140,6 +494,10 @@ func resourceParameterGroupRead(ctx context.Context, d *schema.ResourceData, met
 	defaultTagsConfig := meta.(*conns.AWSClient).DefaultTagsConfig
 	ignoreTagsConfig := meta.(*conns.AWSClient).IgnoreTagsConfig
 
+	// A DB Parameter Group imported with a parameter name list has the list in its ID on the read following the import.
+	name, _, scopedImport := strings.Cut(d.Id(), ":")
+	d.SetId(name)
+
 	describeOpts := rds.DescribeDBParameterGroupsInput{
 		DBParameterGroupName: aws.String(d.Id()),
 	}
@@ -163,76 +521,51 @@ func resourceParameterGroupRead(ctx context.Context, d *schema.ResourceData, met
 	d.Set("family", describeResp.DBParameterGroups[0].DBParameterGroupFamily)
 	d.Set("description", describeResp.DBParameterGroups[0].Description)
 
-	configParams := d.Get("parameter").(*schema.Set)
-	describeParametersOpts := rds.DescribeDBParametersInput{
-		DBParameterGroupName: aws.String(d.Id()),
-	}
-	if configParams.Len() < 1 {
-		// if we don't have any params in the ResourceData already, two possibilities
-		// first, we don't have a config available to us. Second, we do, but it has
-		// no parameters. We're going to assume the first, to be safe. In this case,
-		// we're only going to ask for the user-modified values, because any defaults
-		// the user may have _also_ set are indistinguishable from the hundreds of
-		// defaults AWS sets. If the user hasn't set any parameters, this will return
-		// an empty list anyways, so we just make some unnecessary requests. But in
-		// the more common case (I assume) of an import, this will make fewer requests
-		// and "do the right thing".
-		describeParametersOpts.Source = aws.String("user")
-	}
-
-	var parameters []*rds.Parameter
-	err = conn.DescribeDBParametersPagesWithContext(ctx, &describeParametersOpts,
-		func(describeParametersResp *rds.DescribeDBParametersOutput, lastPage bool) bool {
-			parameters = append(parameters, describeParametersResp.Parameters...)
-			return !lastPage
-		})
-	if err != nil {
-		return sdkdiag.AppendErrorf(diags, "reading RDS DB Parameter Group (%s): %s", d.Id(), err)
-	}
+	if v := d.Get("ordered_parameter").([]interface{}); len(v) > 0 {
+		configured := expandParameters(v)
+		orderedParams, err := findParameterGroupOrderedParameters(ctx, conn, d.Id(), configured)
+		if err != nil {
+			return sdkdiag.AppendErrorf(diags, "reading RDS DB Parameter Group (%s): %s", d.Id(), err)
+		}
+		orderedParams = configuredStaticParameterApplyMethods(orderedParams, configured)
+		if d.Get("trim_parameter_value_whitespace").(bool) {
+			orderedParams = configuredParameterValues(orderedParams, configured)
+		}
 
-	var userParams []*rds.Parameter
-	if configParams.Len() < 1 {
-		// if we have no config/no parameters in config, we've already asked for only
-		// user-modified values, so we can just use the entire response.
-		userParams = parameters
+		if err := d.Set("ordered_parameter", flattenParameterGroupParameters(orderedParams)); err != nil {
+			return sdkdiag.AppendErrorf(diags, "setting ordered_parameter: %s", err)
+		}
 	} else {
-		// if we have a config available to us, we have two possible classes of value
-		// in the config. On the one hand, the user could have specified a parameter
-		// that _actually_ changed things, in which case its Source would be set to
-		// user. On the other, they may have specified a parameter that coincides with
-		// the default value. In that case, the Source will be set to "system" or
-		// "engine-default". We need to set the union of all "user" Source parameters
-		// _and_ the "system"/"engine-default" Source parameters _that appear in the
-		// config_ in the state, or the user gets a perpetual diff. See
-		// terraform-providers/terraform-provider-aws#593 for more context and details.
-		confParams := expandParameters(configParams.List())
-		for _, param := range parameters {
-			if param.Source == nil || param.ParameterName == nil {
-				continue
-			}
-			if aws.StringValue(param.Source) == "user" {
-				userParams = append(userParams, param)
-				continue
-			}
-			var paramFound bool
-			for _, cp := range confParams {
-				if cp.ParameterName == nil {
-					continue
-				}
-				if aws.StringValue(cp.ParameterName) == aws.StringValue(param.ParameterName) {
-					userParams = append(userParams, param)
-					break
-				}
-			}
-			if !paramFound {
-				log.Printf("[DEBUG] Not persisting %s to state, as its source is %q and it isn't in the config", aws.StringValue(param.ParameterName), aws.StringValue(param.Source))
-			}
+		userParams, err := findParameterGroupParameters(ctx, conn, d, scopedImport)
+		if err != nil {
+			return sdkdiag.AppendErrorf(diags, "reading RDS DB Parameter Group (%s): %s", d.Id(), err)
+		}
+		configured := expandParameters(d.Get("parameter").(*schema.Set).List())
+		userParams = configuredStaticParameterApplyMethods(userParams, configured)
+		if d.Get("trim_parameter_value_whitespace").(bool) {
+			userParams = configuredParameterValues(userParams, configured)
+		}
+
+		err = d.Set("parameter", flattenParameterGroupParameters(userParams))
+		if err != nil {
+			return sdkdiag.AppendErrorf(diags, "setting 'parameter' in state: %s", err)
 		}
 	}
 
-	err = d.Set("parameter", flattenParameters(userParams))
-	if err != nil {
-		return sdkdiag.AppendErrorf(diags, "setting 'parameter' in state: %s", err)
+	// Every parameter of a group numbers in the hundreds, so the snapshot is only kept when requested.
+	if d.Get("snapshot_all_parameters").(bool) {
+		allParams, err := findDBParameters(ctx, conn, &rds.DescribeDBParametersInput{
+			DBParameterGroupName: aws.String(d.Id()),
+		})
+		if err != nil {
+			return sdkdiag.AppendErrorf(diags, "reading RDS DB Parameter Group (%s) parameters: %s", d.Id(), err)
+		}
+
+		if err := d.Set("all_parameters", flattenParameterGroupAllParameters(allParams)); err != nil {
+			return sdkdiag.AppendErrorf(diags, "setting all_parameters: %s", err)
+		}
+	} else {
+		d.Set("all_parameters", nil)
 	}
 
 	arn := aws.StringValue(describeResp.DBParameterGroups[0].DBParameterGroupArn)
@@ -260,55 +593,307 @@ func resourceParameterGroupRead(ctx context.Context, d *schema.ResourceData, met
 
 const maxParamModifyChunk = 20
 
-func resourceParameterGroupUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	var diags diag.Diagnostics
-	conn := meta.(*conns.AWSClient).RDSConn()
+// parameterCountLimit caps the number of parameters managed in a single DB Parameter Group, guarding against
+// runaway generated configurations issuing thousands of sequential API calls.
+const parameterCountLimit = 10000
 
-	if d.HasChange("parameter") {
-		o, n := d.GetChange("parameter")
-		if o == nil {
-			o = new(schema.Set)
-		}
-		if n == nil {
-			n = new(schema.Set)
-		}
+// CheckParameterCount returns an error if count exceeds parameterCountLimit.
+func CheckParameterCount(count int) error {
+	if count > parameterCountLimit {
+		return fmt.Errorf("%d parameters exceeds the limit of %d parameters per DB Parameter Group", count, parameterCountLimit)
+	}
 
-		os := o.(*schema.Set)
-		ns := n.(*schema.Set)
+	return nil
+}
 
-		// Expand the "parameter" set to aws-sdk-go compat []rds.Parameter
-		parameters := expandParameters(ns.Difference(os).List())
+var immutableParameterRegexp = regexp.MustCompile(`parameter (\S+) cannot be modified`)
 
-		if len(parameters) > 0 {
-			// We can only modify 20 parameters at a time, so walk them until
-			// we've got them all.
+// ParameterModifyError returns err, mapped to a clearer error when AWS rejects the modification of a parameter
+// that can be set only when a DB Parameter Group of the specified family is created.
+func ParameterModifyError(err error, family string) error {
+	if !tfawserr.ErrMessageContains(err, errCodeInvalidParameterValue, "cannot be modified") {
+		return err
+	}
 
-			for parameters != nil {
-				var paramsToModify []*rds.Parameter
-				paramsToModify, parameters = ResourceParameterModifyChunk(parameters, maxParamModifyChunk)
+	name := "a parameter"
+	if m := immutableParameterRegexp.FindStringSubmatch(err.Error()); m != nil {
+		name = fmt.Sprintf("parameter %q", strings.Trim(m[1], `"'.`))
+	}
 
-				modifyOpts := rds.ModifyDBParameterGroupInput{
-					DBParameterGroupName: aws.String(d.Get("name").(string)),
-					Parameters:           paramsToModify,
-				}
+	return fmt.Errorf("%s cannot be changed in an existing DB Parameter Group of family %s; recreate the DB Parameter Group (e.g. by changing its name) to change it: %w", name, family, err)
+}
 
-				log.Printf("[DEBUG] Modify DB Parameter Group: %s", modifyOpts)
-				_, err := conn.ModifyDBParameterGroupWithContext(ctx, &modifyOpts)
-				if err != nil {
-					return sdkdiag.AppendErrorf(diags, "modifying DB Parameter Group: %s", err)
+// parameterDescribeThrottlingTimeout is how long a throttled page of a DB Parameter Group's parameters is retried.
+const parameterDescribeThrottlingTimeout = 2 * time.Minute
+
+// findDBParameters returns all pages of the DB Parameter Group's parameters.
+// Each page is retried on throttling so that a throttled page doesn't fail, or restart, the whole read.
+func findDBParameters(ctx context.Context, conn *rds.RDS, input *rds.DescribeDBParametersInput) ([]*rds.Parameter, error) {
+	var parameters []*rds.Parameter
+	for {
+		outputRaw, err := tfresource.RetryWhenAWSErrCodeEquals(ctx, parameterDescribeThrottlingTimeout, func() (interface{}, error) {
+			return conn.DescribeDBParametersWithContext(ctx, input)
+		}, errCodeThrottling, errCodeRequestLimitExceeded)
+		if err != nil {
+			return nil, err
+		}
+
+		output := outputRaw.(*rds.DescribeDBParametersOutput)
+		parameters = append(parameters, output.Parameters...)
+
+		if aws.StringValue(output.Marker) == "" {
+			break
+		}
+
+		input.Marker = output.Marker
+	}
+
+	return parameters, nil
+}
+
+// findParameterGroupOrderedParameters returns the configured parameters of the named DB Parameter Group in
+// configuration order, followed by any other user-modified parameters.
+func findParameterGroupOrderedParameters(ctx context.Context, conn *rds.RDS, name string, configured []*rds.Parameter) ([]*rds.Parameter, error) {
+	input := &rds.DescribeDBParametersInput{
+		DBParameterGroupName: aws.String(name),
+	}
+
+	parameters, err := findDBParameters(ctx, conn, input)
+	if err != nil {
+		return nil, err
+	}
+
+	byName := make(map[string]*rds.Parameter, len(parameters))
+	for _, v := range parameters {
+		if v != nil && v.ParameterName != nil {
+			byName[strings.ToLower(aws.StringValue(v.ParameterName))] = v
+		}
+	}
+
+	var ordered []*rds.Parameter
+	for _, v := range configured {
+		name := strings.ToLower(aws.StringValue(v.ParameterName))
+		if p, ok := byName[name]; ok {
+			ordered = append(ordered, p)
+			delete(byName, name)
+		}
+	}
+
+	for _, v := range parameters {
+		if v == nil || v.ParameterName == nil || aws.StringValue(v.Source) != "user" {
+			continue
+		}
+
+		if _, ok := byName[strings.ToLower(aws.StringValue(v.ParameterName))]; ok {
+			ordered = append(ordered, v)
+		}
+	}
+
+	return ordered, nil
+}
+
+// findParameterGroupParameters returns the parameters of the DB parameter group to persist to state. A scoped import
+// restricts them to the configured ones, i.e. those named in the import ID, whatever their source.
+func findParameterGroupParameters(ctx context.Context, conn *rds.RDS, d *schema.ResourceData, scopedImport bool) ([]*rds.Parameter, error) {
+	configParams := d.Get("parameter").(*schema.Set)
+	if configParams.Len() < 1 && d.IsNewResource() {
+		// A group just created from a configuration without parameters has no
+		// user-modified values, so there is nothing to describe.
+		return nil, nil
+	}
+
+	describeParametersOpts := rds.DescribeDBParametersInput{
+		DBParameterGroupName: aws.String(d.Id()),
+	}
+	if configParams.Len() < 1 {
+		// if we don't have any params in the ResourceData already, two possibilities
+		// first, we don't have a config available to us. Second, we do, but it has
+		// no parameters. We're going to assume the first, to be safe. In this case,
+		// we're only going to ask for the user-modified values, because any defaults
+		// the user may have _also_ set are indistinguishable from the hundreds of
+		// defaults AWS sets. If the user hasn't set any parameters, this will return
+		// an empty list anyways, so we just make some unnecessary requests. But in
+		// the more common case (I assume) of an import, this will make fewer requests
+		// and "do the right thing".
+		describeParametersOpts.Source = aws.String("user")
+	}
+
+	parameters, err := findDBParameters(ctx, conn, &describeParametersOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	var userParams []*rds.Parameter
+	if configParams.Len() < 1 {
+		// if we have no config/no parameters in config, we've already asked for only
+		// user-modified values, so we can just use the entire response.
+		userParams = parameters
+	} else {
+		// if we have a config available to us, we have two possible classes of value
+		// in the config. On the one hand, the user could have specified a parameter
+		// that _actually_ changed things, in which case its Source would be set to
+		// user. On the other, they may have specified a parameter that coincides with
+		// the default value. In that case, the Source will be set to "system" or
+		// "engine-default". We need to set the union of all "user" Source parameters
+		// _and_ the "system"/"engine-default" Source parameters _that appear in the
+		// config_ in the state, or the user gets a perpetual diff. See
+		// terraform-providers/terraform-provider-aws#593 for more context and details.
+		confParams := expandParameters(configParams.List())
+
+		// Optionally leave configured parameters that have their engine default value out of the state instead,
+		// so that the plan shows the redundant declarations.
+		var defaults map[string]*rds.Parameter
+		if d.Get("prune_default_value_parameters").(bool) {
+			var err error
+			defaults, err = findEngineDefaultParameters(ctx, conn, d.Get("family").(string))
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		for _, param := range parameters {
+			if param.Source == nil || param.ParameterName == nil {
+				continue
+			}
+			if v, ok := defaults[strings.ToLower(aws.StringValue(param.ParameterName))]; ok && v.ParameterValue != nil && aws.StringValue(v.ParameterValue) == aws.StringValue(param.ParameterValue) && parameterConfigured(confParams, param) {
+				log.Printf("[DEBUG] Not persisting %s to state, as it has the engine default value", aws.StringValue(param.ParameterName))
+				continue
+			}
+			if aws.StringValue(param.Source) == "user" && !scopedImport {
+				userParams = append(userParams, param)
+				continue
+			}
+			var paramFound bool
+			for _, cp := range confParams {
+				if cp.ParameterName == nil {
+					continue
+				}
+				if aws.StringValue(cp.ParameterName) == aws.StringValue(param.ParameterName) {
+					userParams = append(userParams, param)
+					break
 				}
 			}
+			if !paramFound {
+				log.Printf("[DEBUG] Not persisting %s to state, as its source is %q and it isn't in the config", aws.StringValue(param.ParameterName), aws.StringValue(param.Source))
+			}
+		}
+	}
+
+	return userParams, nil
+}
+
+func resourceParameterGroupUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	conn := meta.(*conns.AWSClient).RDSConn()
+
+	if d.HasChanges("ordered_parameter", "parameter") {
+		// Parameters of the group may also be managed with aws_db_parameter.
+		mutexKey := parameterGroupMutexKey(d.Get("name").(string))
+		conns.GlobalMutexKV.Lock(mutexKey)
+		defer conns.GlobalMutexKV.Unlock(mutexKey)
+
+		o, n := d.GetChange("parameter")
+		if o == nil {
+			o = new(schema.Set)
+		}
+		if n == nil {
+			n = new(schema.Set)
+		}
+
+		os := o.(*schema.Set)
+		ns := n.(*schema.Set)
+
+		ol, nl := d.GetChange("ordered_parameter")
+		oldOrdered := expandParameters(ol.([]interface{}))
+		newOrdered := expandParameters(nl.([]interface{}))
+
+		if err := CheckParameterCount(ns.Len() + len(newOrdered)); err != nil {
+			return sdkdiag.AppendErrorf(diags, "modifying DB Parameter Group: %s", err)
+		}
+
+		// Expand the "parameter" set to aws-sdk-go compat []rds.Parameter
+		parameters := expandParameters(ns.Difference(os).List())
+
+		// Ordered parameters bypass the set hashing and are applied in configuration order.
+		ordered := len(newOrdered) > 0
+		if ordered {
+			parameters = append(parameters, orderedParametersToModify(oldOrdered, newOrdered)...)
+		}
+
+		// The set difference includes parameters whose AWS value may already match, e.g. after import or drift.
+		if d.Get("skip_unchanged_parameters").(bool) && len(parameters) > 0 {
+			changed, err := ChangedParameters(ctx, conn, d.Get("name").(string), parameters)
+			if err != nil {
+				return sdkdiag.AppendErrorf(diags, "modifying DB Parameter Group: %s", err)
+			}
+
+			log.Printf("[DEBUG] DB Parameter Group (%s): skipping %d of %d parameters that already have the configured value", d.Id(), len(parameters)-len(changed), len(parameters))
+			parameters = changed
+		}
+
+		// Static parameters can only be applied with the pending-reboot apply method, e.g. when configured with the immediate default.
+		if parametersHaveImmediateApplyMethod(parameters) {
+			defaults, err := findEngineDefaultParameters(ctx, conn, d.Get("family").(string))
+			if err != nil {
+				return sdkdiag.AppendErrorf(diags, "modifying DB Parameter Group: %s", err)
+			}
+
+			var upgraded []string
+			parameters, upgraded = staticParametersPendingReboot(parameters, defaults)
+
+			for _, name := range upgraded {
+				log.Printf("[WARN] DB Parameter Group (%s) parameter %q is static, applying it with the pending-reboot apply method instead of immediate", d.Id(), name)
+			}
+		}
+
+		var requiresReboot bool
+
+		if len(parameters) > 0 {
+			// Capture the values before any chunk is applied, so that the applied chunks can be reverted to them.
+			var previous map[string]*string
+			if d.Get("rollback_on_failure").(bool) {
+				var err error
+				previous, err = findParameterGroupParameterValues(ctx, conn, d.Get("name").(string))
+				if err != nil {
+					return sdkdiag.AppendErrorf(diags, "modifying DB Parameter Group: %s", err)
+				}
+			}
+
+			name, family := d.Get("name").(string), d.Get("family").(string)
+			constraints := expandParameterGroupConstraints(d.Get("parameter_group_constraint").([]interface{}))
+
+			var err error
+			if ordered {
+				err = modifyParameterGroupParametersInOrder(ctx, conn, name, family, parameters, constraints)
+			} else {
+				err = modifyParameterGroupParameters(ctx, conn, name, family, d.Get("engine").(string), parameters, constraints)
+			}
+
+			if err != nil {
+				diags = sdkdiag.AppendErrorf(diags, "modifying DB Parameter Group: %s", err)
+
+				if previous != nil {
+					if err := rollbackParameterGroupParameters(ctx, conn, d.Get("name").(string), err, previous); err != nil {
+						diags = sdkdiag.AppendErrorf(diags, "rolling back DB Parameter Group (%s) parameters: %s", d.Id(), err)
+					}
+				}
+
+				// Chunks applied before the failure, and not rolled back, are in effect, so refresh the state with what AWS has.
+				return append(diags, resourceParameterGroupRead(ctx, d, meta)...)
+			}
+
+			requiresReboot = parametersRequireReboot(parameters)
 		}
 
 		toRemove := map[string]*rds.Parameter{}
 
-		for _, p := range expandParameters(os.List()) {
+		for _, p := range append(expandParameters(os.List()), oldOrdered...) {
 			if p.ParameterName != nil {
 				toRemove[*p.ParameterName] = p
 			}
 		}
 
-		for _, p := range expandParameters(ns.List()) {
+		for _, p := range append(expandParameters(ns.List()), newOrdered...) {
 			if p.ParameterName != nil {
 				delete(toRemove, *p.ParameterName)
 			}
@@ -320,28 +905,19 @@ func resourceParameterGroupUpdate(ctx context.Context, d *schema.ResourceData, m
 			resetParameters = append(resetParameters, v)
 		}
 		if len(resetParameters) > 0 {
-			for resetParameters != nil {
-				var paramsToReset []*rds.Parameter
-				if len(resetParameters) <= maxParamModifyChunk {
-					paramsToReset, resetParameters = resetParameters[:], nil
-				} else {
-					paramsToReset, resetParameters = resetParameters[:maxParamModifyChunk], resetParameters[maxParamModifyChunk:]
-				}
-
-				parameterGroupName := d.Get("name").(string)
-				resetOpts := rds.ResetDBParameterGroupInput{
-					DBParameterGroupName: aws.String(parameterGroupName),
-					Parameters:           paramsToReset,
-					ResetAllParameters:   aws.Bool(false),
-				}
+			// Every parameter is removed, so the whole group can be reset at once.
+			resetAll := d.Get("reset_all_parameters_on_clear").(bool) && ns.Len() == 0 && len(newOrdered) == 0
 
-				log.Printf("[DEBUG] Reset DB Parameter Group: %s", resetOpts)
-				_, err := conn.ResetDBParameterGroupWithContext(ctx, &resetOpts)
-				if err != nil {
-					return sdkdiag.AppendErrorf(diags, "resetting DB Parameter Group: %s", err)
-				}
+			reboot, err := resetParameterGroupParameters(ctx, conn, d.Get("name").(string), resetParameters, resetAll)
+			if err != nil {
+				diags = sdkdiag.AppendErrorf(diags, "resetting DB Parameter Group: %s", err)
+				return append(diags, resourceParameterGroupRead(ctx, d, meta)...)
 			}
+
+			requiresReboot = requiresReboot || reboot
 		}
+
+		d.Set("requires_reboot", requiresReboot)
 	}
 
 	if d.HasChange("tags_all") {
@@ -355,6 +931,341 @@ func resourceParameterGroupUpdate(ctx context.Context, d *schema.ResourceData, m
 	return append(diags, resourceParameterGroupRead(ctx, d, meta)...)
 }
 
+// parameterGroupMutexKey returns the key that serializes the provider's changes to the parameters of the named DB
+// parameter group, since aws_db_parameter_group and aws_db_parameter may modify the same group concurrently.
+func parameterGroupMutexKey(name string) string {
+	return fmt.Sprintf("rds-db-parameter-group-%s", name)
+}
+
+// resetParameterGroupParameters resets parameters of the named DB Parameter Group to their defaults, in chunks, and
+// returns whether any of them is static and so requires a reboot. With all, every parameter of the group, including
+// those that aren't in parameters, is reset in a single call.
+func resetParameterGroupParameters(ctx context.Context, conn *rds.RDS, name string, parameters []*rds.Parameter, all bool) (bool, error) {
+	applyTypes, err := findParameterApplyTypes(ctx, conn, name)
+	if err != nil {
+		return false, err
+	}
+
+	chunks := ResourceParameterResetChunks(parameters, applyTypes, maxParamModifyChunk)
+
+	if all {
+		input := &rds.ResetDBParameterGroupInput{
+			DBParameterGroupName: aws.String(name),
+			ResetAllParameters:   aws.Bool(true),
+		}
+
+		log.Printf("[DEBUG] Resetting all DB Parameter Group (%s) parameters", name)
+		if _, err := conn.ResetDBParameterGroupWithContext(ctx, input); err != nil {
+			return false, err
+		}
+
+		for _, chunk := range chunks {
+			if parametersRequireReboot(chunk) {
+				return true, nil
+			}
+		}
+
+		return false, nil
+	}
+
+	var requiresReboot bool
+
+	for _, paramsToReset := range chunks {
+		resetOpts := rds.ResetDBParameterGroupInput{
+			DBParameterGroupName: aws.String(name),
+			Parameters:           paramsToReset,
+			ResetAllParameters:   aws.Bool(false),
+		}
+
+		log.Printf("[DEBUG] Reset DB Parameter Group: %s", resetOpts)
+		if _, err := conn.ResetDBParameterGroupWithContext(ctx, &resetOpts); err != nil {
+			return requiresReboot, err
+		}
+
+		requiresReboot = requiresReboot || parametersRequireReboot(paramsToReset)
+	}
+
+	return requiresReboot, nil
+}
+
+// modifyParameterGroupParameters applies parameters to the named DB Parameter Group of the specified family.
+// The parameters named in each of constraints are applied in the same chunk.
+func modifyParameterGroupParameters(ctx context.Context, conn *rds.RDS, name, family, engine string, parameters []*rds.Parameter, constraints [][]string) error {
+	chunks, err := parameterModifyChunks(parameters, family, engine, constraints)
+	if err != nil {
+		return err
+	}
+
+	return modifyParameterGroupParameterChunks(ctx, conn, name, family, chunks)
+}
+
+// modifyParameterGroupParametersInOrder is modifyParameterGroupParameters without any prioritization, i.e. the
+// parameters are applied exactly in the specified order, except that the parameters named in a constraint are
+// applied together at the position of the first of them.
+func modifyParameterGroupParametersInOrder(ctx context.Context, conn *rds.RDS, name, family string, parameters []*rds.Parameter, constraints [][]string) error {
+	chunks, err := orderedParameterModifyChunks(parameters, constraints)
+	if err != nil {
+		return err
+	}
+
+	return modifyParameterGroupParameterChunks(ctx, conn, name, family, chunks)
+}
+
+// parameterModifyChunks splits parameters into the chunks that modifyParameterGroupParameters applies.
+func parameterModifyChunks(parameters []*rds.Parameter, family, engine string, constraints [][]string) ([][]*rds.Parameter, error) {
+	// We can only modify 20 parameters at a time, so walk them until
+	// we've got them all. Chunk up front to report progress on large groups,
+	// and so that a constraint that can't be met fails before anything is applied.
+	var chunks [][]*rds.Parameter
+	for parameters != nil {
+		var chunk []*rds.Parameter
+		var err error
+		chunk, parameters, err = ResourceParameterModifyChunkWithConstraints(parameters, maxParamModifyChunk, parameterModifyPriorities(family, engine), constraints)
+		if err != nil {
+			return nil, err
+		}
+		chunks = append(chunks, chunk)
+	}
+
+	return chunks, nil
+}
+
+// orderedParameterModifyChunks splits parameters into the chunks that modifyParameterGroupParametersInOrder applies.
+func orderedParameterModifyChunks(parameters []*rds.Parameter, constraints [][]string) ([][]*rds.Parameter, error) {
+	var chunks [][]*rds.Parameter
+	for len(parameters) > 0 {
+		var chunk []*rds.Parameter
+		var err error
+		chunk, parameters, err = resourceParameterConstrainedChunk(parameters, maxParamModifyChunk, constraints)
+		if err != nil {
+			return nil, err
+		}
+		chunks = append(chunks, chunk)
+	}
+
+	return chunks, nil
+}
+
+// estimatedParameterModifyCalls returns the number of ModifyDBParameterGroup calls that an update from the old to the
+// new parameters makes. It is an upper bound: the update may skip parameters after reading them from AWS, e.g. with
+// skip_unchanged_parameters. Removed parameters are not counted.
+func estimatedParameterModifyCalls(o, n *schema.Set, ol, nl []interface{}, family, engine string, constraints [][]string) (int, error) {
+	parameters := expandParameters(n.Difference(o).List())
+
+	var chunks [][]*rds.Parameter
+	var err error
+	if newOrdered := expandParameters(nl); len(newOrdered) > 0 {
+		parameters = append(parameters, orderedParametersToModify(expandParameters(ol), newOrdered)...)
+		chunks, err = orderedParameterModifyChunks(parameters, constraints)
+	} else if len(parameters) > 0 {
+		chunks, err = parameterModifyChunks(parameters, family, engine, constraints)
+	}
+
+	if err != nil {
+		return 0, err
+	}
+
+	return len(chunks), nil
+}
+
+// parameterModifyChunkError is the error of a chunk of parameters that failed to apply, with the parameters of the
+// chunks that were applied before it.
+type parameterModifyChunkError struct {
+	applied []*rds.Parameter
+	err     error
+}
+
+func (e *parameterModifyChunkError) Error() string {
+	return e.err.Error()
+}
+
+func (e *parameterModifyChunkError) Unwrap() error {
+	return e.err
+}
+
+func modifyParameterGroupParameterChunks(ctx context.Context, conn *rds.RDS, name, family string, chunks [][]*rds.Parameter) error {
+	var applied []*rds.Parameter
+	for i, chunk := range chunks {
+		modifyOpts := rds.ModifyDBParameterGroupInput{
+			DBParameterGroupName: aws.String(name),
+			Parameters:           chunk,
+		}
+
+		log.Printf("[DEBUG] Modify DB Parameter Group (%s): applying chunk %d of %d (%d parameters): %s", name, i+1, len(chunks), len(chunk), modifyOpts)
+		_, err := conn.ModifyDBParameterGroupWithContext(ctx, &modifyOpts)
+		if err != nil {
+			return &parameterModifyChunkError{
+				applied: applied,
+				err:     ParameterModifyError(err, family),
+			}
+		}
+
+		applied = append(applied, chunk...)
+	}
+
+	return nil
+}
+
+// rollbackParameterGroupParameters reverts the parameters applied before the chunk that failed with err to their
+// previous values, keyed by lower-cased parameter name. Parameters without a previous value are reset to their
+// defaults. The rollback is best-effort: every chunk is attempted, and the errors of the failed ones are returned.
+func rollbackParameterGroupParameters(ctx context.Context, conn *rds.RDS, name string, err error, previous map[string]*string) error {
+	var chunkErr *parameterModifyChunkError
+	if !errors.As(err, &chunkErr) {
+		return nil
+	}
+
+	var restore, reset []*rds.Parameter
+	for _, p := range chunkErr.applied {
+		if v, ok := previous[strings.ToLower(aws.StringValue(p.ParameterName))]; ok && v != nil {
+			restore = append(restore, &rds.Parameter{
+				ApplyMethod:    p.ApplyMethod,
+				ParameterName:  p.ParameterName,
+				ParameterValue: v,
+			})
+		} else {
+			reset = append(reset, &rds.Parameter{
+				ApplyMethod:   p.ApplyMethod,
+				ParameterName: p.ParameterName,
+			})
+		}
+	}
+
+	var result *multierror.Error
+
+	for len(restore) > 0 {
+		n := len(restore)
+		if n > maxParamModifyChunk {
+			n = maxParamModifyChunk
+		}
+
+		log.Printf("[DEBUG] Rolling back DB Parameter Group (%s): restoring %d parameters", name, n)
+		if _, err := conn.ModifyDBParameterGroupWithContext(ctx, &rds.ModifyDBParameterGroupInput{
+			DBParameterGroupName: aws.String(name),
+			Parameters:           restore[:n],
+		}); err != nil {
+			result = multierror.Append(result, err)
+		}
+
+		restore = restore[n:]
+	}
+
+	for len(reset) > 0 {
+		n := len(reset)
+		if n > maxParamModifyChunk {
+			n = maxParamModifyChunk
+		}
+
+		log.Printf("[DEBUG] Rolling back DB Parameter Group (%s): resetting %d parameters", name, n)
+		if _, err := conn.ResetDBParameterGroupWithContext(ctx, &rds.ResetDBParameterGroupInput{
+			DBParameterGroupName: aws.String(name),
+			Parameters:           reset[:n],
+			ResetAllParameters:   aws.Bool(false),
+		}); err != nil {
+			result = multierror.Append(result, err)
+		}
+
+		reset = reset[n:]
+	}
+
+	return result.ErrorOrNil()
+}
+
+// orderedParametersToModify returns the new ordered parameters that are added or changed from the old ones, in order.
+func orderedParametersToModify(oldParameters, newParameters []*rds.Parameter) []*rds.Parameter {
+	existing := make(map[string]*rds.Parameter, len(oldParameters))
+	for _, v := range oldParameters {
+		existing[aws.StringValue(v.ParameterName)] = v
+	}
+
+	var parameters []*rds.Parameter
+	for _, v := range newParameters {
+		if p, ok := existing[aws.StringValue(v.ParameterName)]; ok && aws.StringValue(p.ParameterValue) == aws.StringValue(v.ParameterValue) && aws.StringValue(p.ApplyMethod) == aws.StringValue(v.ApplyMethod) {
+			continue
+		}
+
+		parameters = append(parameters, v)
+	}
+
+	return parameters
+}
+
+// flattenParameterGroupParameters is flattenParameters with each parameter classified as static, i.e. applied only
+// with the pending-reboot apply method, or dynamic by the apply type read with it.
+func flattenParameterGroupParameters(apiObjects []*rds.Parameter) []map[string]interface{} {
+	tfList := make([]map[string]interface{}, 0, len(apiObjects))
+
+	for _, apiObject := range apiObjects {
+		if apiObject == nil || apiObject.ParameterName == nil {
+			continue
+		}
+
+		tfMap := flattenParameters([]*rds.Parameter{apiObject})[0]
+		if v := apiObject.Description; v != nil {
+			tfMap["description"] = aws.StringValue(v)
+		}
+		if v := apiObject.IsModifiable; v != nil {
+			tfMap["is_modifiable"] = aws.BoolValue(v)
+		}
+		tfMap["is_static"] = strings.EqualFold(aws.StringValue(apiObject.ApplyType), "static")
+		if v := apiObject.MinimumEngineVersion; v != nil {
+			tfMap["minimum_engine_version"] = aws.StringValue(v)
+		}
+
+		tfList = append(tfList, tfMap)
+	}
+
+	return tfList
+}
+
+// flattenParameterGroupAllParameters returns the name, value and source of every parameter, sorted by name.
+// Parameters without a value, e.g. engine defaults that are unset, have an empty value.
+func flattenParameterGroupAllParameters(apiObjects []*rds.Parameter) []interface{} {
+	tfList := make([]interface{}, 0, len(apiObjects))
+
+	for _, apiObject := range apiObjects {
+		if apiObject == nil || apiObject.ParameterName == nil {
+			continue
+		}
+
+		tfList = append(tfList, map[string]interface{}{
+			"name":   aws.StringValue(apiObject.ParameterName),
+			"source": aws.StringValue(apiObject.Source),
+			"value":  aws.StringValue(apiObject.ParameterValue),
+		})
+	}
+
+	sort.Slice(tfList, func(i, j int) bool {
+		return tfList[i].(map[string]interface{})["name"].(string) < tfList[j].(map[string]interface{})["name"].(string)
+	})
+
+	return tfList
+}
+
+// parametersHaveImmediateApplyMethod returns whether any of the parameters is applied with the immediate apply method,
+// either explicitly or by default.
+func parametersHaveImmediateApplyMethod(parameters []*rds.Parameter) bool {
+	for _, p := range parameters {
+		if applyMethod := aws.StringValue(p.ApplyMethod); applyMethod == "" || strings.EqualFold(applyMethod, "immediate") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// parametersRequireReboot returns whether any of the parameters is applied with the pending-reboot apply method,
+// i.e. takes effect only after the DB instances using the DB Parameter Group are rebooted.
+func parametersRequireReboot(parameters []*rds.Parameter) bool {
+	for _, p := range parameters {
+		if strings.EqualFold(aws.StringValue(p.ApplyMethod), "pending-reboot") {
+			return true
+		}
+	}
+
+	return false
+}
+
 func resourceParameterGroupDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
 	conn := meta.(*conns.AWSClient).RDSClient()
 	deleteOpts := rds_sdkv2.DeleteDBParameterGroupInput{
@@ -386,6 +1297,7 @@ func resourceParameterGroupDelete(ctx context.Context, d *schema.ResourceData, m
 func resourceParameterHash(v interface{}) int {
 	var buf bytes.Buffer
 	m := v.(map[string]interface{})
+	// The computed description, is_modifiable, is_static and minimum_engine_version aren't hashed, so that configured and read parameters hash the same.
 	// Store the value as a lower case string, to match how we store them in FlattenParameters
 	buf.WriteString(fmt.Sprintf("%s-", strings.ToLower(m["name"].(string))))
 	buf.WriteString(fmt.Sprintf("%s-", strings.ToLower(m["apply_method"].(string))))
@@ -396,33 +1308,109 @@ func resourceParameterHash(v interface{}) int {
 	return create.StringHashcode(buf.String())
 }
 
-func ResourceParameterModifyChunk(all []*rds.Parameter, maxChunkSize int) ([]*rds.Parameter, []*rds.Parameter) {
-	// Since the hash randomly affect the set "order," this attempts to prioritize important
-	// parameters to go in the first chunk (i.e., charset)
+// defaultParameterModifyPriorities lists the name substrings of the immediate parameters that must be applied first,
+// in order, for engines without built-in priorities.
+var defaultParameterModifyPriorities = []string{"character_set"}
+
+// builtinParameterModifyPriorities lists, by engine, the priorities of engines whose plugin parameters, e.g.
+// default_authentication_plugin, must be applied before the settings that depend on the plugins.
+var builtinParameterModifyPriorities = map[string][]string{
+	"aurora":       {"plugin", "character_set"},
+	"aurora-mysql": {"plugin", "character_set"},
+	"mariadb":      {"plugin", "character_set"},
+	"mysql":        {"plugin", "character_set"},
+}
 
-	if len(all) <= maxChunkSize {
-		return all[:], nil
+// parameterModifyPriorities returns the parameter priorities for the specified DB parameter group family and engine.
+func parameterModifyPriorities(family, engine string) []string {
+	if v, ok := builtinParameterModifyPriorities[parameterGroupEngine(family, engine)]; ok {
+		return v
 	}
 
-	var modifyChunk, remainder []*rds.Parameter
+	return defaultParameterModifyPriorities
+}
 
-	// pass 1
-	for i, p := range all {
-		if len(modifyChunk) >= maxChunkSize {
-			remainder = append(remainder, all[i:]...)
-			return modifyChunk, remainder
-		}
+// reservedParameterNames lists, by engine, the names of parameters that AWS manages, e.g. the default IAM
+// roles set by associating roles with an Aurora MySQL DB cluster.
+var reservedParameterNames = map[string][]string{
+	"aurora":            {"aurora_load_from_s3_role", "aurora_select_into_s3_role", "aws_default_lambda_role", "aws_default_s3_role"},
+	"aurora-mysql":      {"aurora_load_from_s3_role", "aurora_select_into_s3_role", "aws_default_lambda_role", "aws_default_s3_role"},
+	"aurora-postgresql": {"rds.extensions"},
+	"postgres":          {"rds.extensions"},
+}
 
-		if strings.Contains(aws.StringValue(p.ParameterName), "character_set") && aws.StringValue(p.ApplyMethod) != "pending-reboot" {
-			modifyChunk = append(modifyChunk, p)
-			continue
+// reservedParameters returns the specified parameter names that AWS manages for the DB parameter group family and
+// engine, in order.
+func reservedParameters(family, engine string, names []string) []string {
+	reserved := make(map[string]struct{})
+	for _, name := range reservedParameterNames[parameterGroupEngine(family, engine)] {
+		reserved[strings.ToLower(name)] = struct{}{}
+	}
+
+	var result []string
+	for _, name := range names {
+		if _, ok := reserved[strings.ToLower(name)]; ok {
+			result = append(result, name)
 		}
+	}
 
-		remainder = append(remainder, p)
+	return result
+}
+
+// parameterGroupFamilyEngines lists the engines that DB parameter group families are named after. The legacy Aurora
+// MySQL families, e.g. aurora5.6, don't name their engine and only have built-in metadata when the engine is specified.
+var parameterGroupFamilyEngines = []string{"aurora-mysql", "aurora-postgresql", "mariadb", "mysql", "postgres"}
+
+// parameterGroupEngine returns engine, if specified, or the engine that the DB parameter group family is named after.
+func parameterGroupEngine(family, engine string) string {
+	if engine != "" {
+		return engine
 	}
 
-	all = remainder
-	remainder = nil
+	for _, v := range parameterGroupFamilyEngines {
+		if strings.HasPrefix(family, v) {
+			return v
+		}
+	}
+
+	return ""
+}
+
+// ResourceParameterModifyChunk returns the next chunk of at most maxChunkSize parameters to modify and the remainder.
+// Repeatedly chunking the remainder applies every parameter exactly once with immediate charset parameters first and
+// pending-reboot parameters last. Within each pass the relative input order of parameters is preserved.
+func ResourceParameterModifyChunk(all []*rds.Parameter, maxChunkSize int) ([]*rds.Parameter, []*rds.Parameter) {
+	return ResourceParameterModifyChunkWithPriorities(all, maxChunkSize, defaultParameterModifyPriorities)
+}
+
+// ResourceParameterModifyChunkWithPriorities is ResourceParameterModifyChunk with the immediate parameters whose names
+// contain each of priorities, in turn, applied first in place of charset parameters.
+func ResourceParameterModifyChunkWithPriorities(all []*rds.Parameter, maxChunkSize int, priorities []string) ([]*rds.Parameter, []*rds.Parameter) {
+	// Since the hash randomly affect the set "order," this attempts to prioritize important
+	// parameters to go in the first chunk (i.e., charset). The passes are applied even when
+	// all parameters fit in a single chunk so that the final chunk is ordered consistently.
+
+	var modifyChunk, remainder []*rds.Parameter
+
+	// pass 1 - prioritized parameters, one pass per priority
+	for _, priority := range priorities {
+		for i, p := range all {
+			if len(modifyChunk) >= maxChunkSize {
+				remainder = append(remainder, all[i:]...)
+				return modifyChunk, remainder
+			}
+
+			if strings.Contains(aws.StringValue(p.ParameterName), priority) && aws.StringValue(p.ApplyMethod) != "pending-reboot" {
+				modifyChunk = append(modifyChunk, p)
+				continue
+			}
+
+			remainder = append(remainder, p)
+		}
+
+		all = remainder
+		remainder = nil
+	}
 
 	// pass 2 - avoid pending reboot
 	for i, p := range all {
@@ -454,3 +1442,382 @@ func ResourceParameterModifyChunk(all []*rds.Parameter, maxChunkSize int) ([]*rd
 
 	return modifyChunk, remainder
 }
+
+// ResourceParameterModifyChunkWithConstraints is ResourceParameterModifyChunkWithPriorities with the parameters named in
+// each of constraints kept in the same chunk, at the position of the first of them. Names are matched case-insensitively.
+// An error is returned if the parameters of a constraint don't fit in a single chunk.
+func ResourceParameterModifyChunkWithConstraints(all []*rds.Parameter, maxChunkSize int, priorities []string, constraints [][]string) ([]*rds.Parameter, []*rds.Parameter, error) {
+	if len(constraints) == 0 {
+		chunk, remainder := ResourceParameterModifyChunkWithPriorities(all, maxChunkSize, priorities)
+		return chunk, remainder, nil
+	}
+
+	// Order every parameter as the prioritized chunking would, then chunk that order.
+	ordered, _ := ResourceParameterModifyChunkWithPriorities(all, len(all), priorities)
+
+	return resourceParameterConstrainedChunk(ordered, maxChunkSize, constraints)
+}
+
+// resourceParameterConstrainedChunk returns the next chunk of at most maxChunkSize of the ordered parameters
+// and the remainder, keeping the parameters named in each of constraints together.
+func resourceParameterConstrainedChunk(ordered []*rds.Parameter, maxChunkSize int, constraints [][]string) ([]*rds.Parameter, []*rds.Parameter, error) {
+	constraint := make(map[string]int)
+	for i, names := range constraints {
+		for _, name := range names {
+			constraint[strings.ToLower(name)] = i
+		}
+	}
+
+	// Group the parameters into units that must be applied in the same chunk.
+	var units [][]*rds.Parameter
+	unitIndex := make(map[int]int)
+	for _, p := range ordered {
+		i, ok := constraint[strings.ToLower(aws.StringValue(p.ParameterName))]
+		if !ok {
+			units = append(units, []*rds.Parameter{p})
+			continue
+		}
+
+		if j, ok := unitIndex[i]; ok {
+			units[j] = append(units[j], p)
+			continue
+		}
+
+		unitIndex[i] = len(units)
+		units = append(units, []*rds.Parameter{p})
+	}
+
+	for i := range constraints {
+		if j, ok := unitIndex[i]; ok && len(units[j]) > maxChunkSize {
+			n := len(units[j])
+			return nil, nil, fmt.Errorf("parameter_group_constraint (%s) has %d parameters to modify, which can't be applied together: at most %d parameters can be modified at a time", strings.Join(constraints[i], ", "), n, maxChunkSize)
+		}
+	}
+
+	var modifyChunk, remainder []*rds.Parameter
+	for i, unit := range units {
+		if len(modifyChunk)+len(unit) > maxChunkSize {
+			for _, unit := range units[i:] {
+				remainder = append(remainder, unit...)
+			}
+			break
+		}
+
+		modifyChunk = append(modifyChunk, unit...)
+	}
+
+	return modifyChunk, remainder, nil
+}
+
+// ResourceParameterResetChunks splits the parameters to be reset into chunks of at most maxChunkSize, ordered by name.
+// A static parameter can only be reset with the pending-reboot apply method, so that is used for every parameter whose
+// apply type (keyed by lower-cased parameter name) is static, whatever apply_method was configured for it.
+// The input parameters are not modified.
+func ResourceParameterResetChunks(parameters []*rds.Parameter, applyTypes map[string]string, maxChunkSize int) [][]*rds.Parameter {
+	all := make([]*rds.Parameter, 0, len(parameters))
+	for _, v := range parameters {
+		p := *v
+		if applyTypes[strings.ToLower(aws.StringValue(p.ParameterName))] == "static" {
+			p.ApplyMethod = aws.String("pending-reboot")
+		}
+		all = append(all, &p)
+	}
+
+	sort.Slice(all, func(i, j int) bool {
+		return aws.StringValue(all[i].ParameterName) < aws.StringValue(all[j].ParameterName)
+	})
+
+	var chunks [][]*rds.Parameter
+	for len(all) > maxChunkSize {
+		chunks, all = append(chunks, all[:maxChunkSize]), all[maxChunkSize:]
+	}
+	if len(all) > 0 {
+		chunks = append(chunks, all)
+	}
+
+	return chunks
+}
+
+// CompatibleParameters splits parameters into those that are valid in a DB parameter group family, according to its
+// engine default parameters keyed by lower-cased parameter name, and the names of those that are not.
+func CompatibleParameters(parameters []*rds.Parameter, defaults map[string]*rds.Parameter) ([]*rds.Parameter, []string) {
+	var compatible []*rds.Parameter
+	var dropped []string
+	for _, v := range parameters {
+		if name := aws.StringValue(v.ParameterName); defaults[strings.ToLower(name)] != nil {
+			compatible = append(compatible, v)
+		} else {
+			dropped = append(dropped, name)
+		}
+	}
+
+	return compatible, dropped
+}
+
+// findEngineDefaultParameters returns the engine default parameters of the specified DB parameter group family,
+// keyed by lower-cased parameter name.
+func findEngineDefaultParameters(ctx context.Context, conn *rds.RDS, family string) (map[string]*rds.Parameter, error) {
+	input := &rds.DescribeEngineDefaultParametersInput{
+		DBParameterGroupFamily: aws.String(family),
+	}
+
+	parameters := make(map[string]*rds.Parameter)
+	err := conn.DescribeEngineDefaultParametersPagesWithContext(ctx, input, func(page *rds.DescribeEngineDefaultParametersOutput, lastPage bool) bool {
+		if page == nil || page.EngineDefaults == nil {
+			return !lastPage
+		}
+
+		for _, v := range page.EngineDefaults.Parameters {
+			if v != nil && v.ParameterName != nil {
+				parameters[strings.ToLower(aws.StringValue(v.ParameterName))] = v
+			}
+		}
+
+		return !lastPage
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("reading RDS DB Parameter Group family (%s) parameters: %w", family, err)
+	}
+
+	return parameters, nil
+}
+
+// staticParametersPendingReboot returns the parameters with the pending-reboot apply method in place of immediate
+// for those that are static, according to the engine default parameters keyed by lower-cased parameter name, and the
+// names of the parameters whose apply method was changed. The input parameters are not modified.
+func staticParametersPendingReboot(parameters []*rds.Parameter, defaults map[string]*rds.Parameter) ([]*rds.Parameter, []string) {
+	var output []*rds.Parameter
+	var upgraded []string
+
+	for _, v := range parameters {
+		name := strings.ToLower(aws.StringValue(v.ParameterName))
+
+		if applyMethod := aws.StringValue(v.ApplyMethod); (applyMethod == "" || strings.EqualFold(applyMethod, "immediate")) && defaults[name] != nil && strings.EqualFold(aws.StringValue(defaults[name].ApplyType), "static") {
+			p := *v
+			p.ApplyMethod = aws.String("pending-reboot")
+			output = append(output, &p)
+			upgraded = append(upgraded, name)
+			continue
+		}
+
+		output = append(output, v)
+	}
+
+	return output, upgraded
+}
+
+// configuredStaticParameterApplyMethods returns the read parameters with the configured immediate apply method in place of
+// pending-reboot for those that are static, as staticParametersPendingReboot applies them, so that the configuration
+// doesn't show a difference. The input parameters are not modified.
+func configuredStaticParameterApplyMethods(parameters, configured []*rds.Parameter) []*rds.Parameter {
+	immediate := make(map[string]bool)
+	for _, v := range configured {
+		if applyMethod := aws.StringValue(v.ApplyMethod); applyMethod == "" || strings.EqualFold(applyMethod, "immediate") {
+			immediate[strings.ToLower(aws.StringValue(v.ParameterName))] = true
+		}
+	}
+
+	output := make([]*rds.Parameter, 0, len(parameters))
+	for _, v := range parameters {
+		if immediate[strings.ToLower(aws.StringValue(v.ParameterName))] && strings.EqualFold(aws.StringValue(v.ApplyType), "static") && strings.EqualFold(aws.StringValue(v.ApplyMethod), "pending-reboot") {
+			p := *v
+			p.ApplyMethod = aws.String("immediate")
+			output = append(output, &p)
+			continue
+		}
+
+		output = append(output, v)
+	}
+
+	return output
+}
+
+// parameterValueDiffSuppress suppresses differences between parameter values that differ only in surrounding whitespace,
+// e.g. a value resolved from an SSM parameter with a trailing newline, when trim_parameter_value_whitespace is enabled.
+func parameterValueDiffSuppress(k, old, new string, d *schema.ResourceData) bool {
+	return d.Get("trim_parameter_value_whitespace").(bool) && strings.TrimSpace(old) == strings.TrimSpace(new)
+}
+
+// configuredParameterValues returns the read parameters with the configured value in place of the read one for those
+// whose values differ only in surrounding whitespace. The "parameter" set hashes values, so differences in set elements
+// aren't suppressed by parameterValueDiffSuppress. The input parameters are not modified.
+func configuredParameterValues(parameters, configured []*rds.Parameter) []*rds.Parameter {
+	values := make(map[string]string)
+	for _, v := range configured {
+		values[strings.ToLower(aws.StringValue(v.ParameterName))] = aws.StringValue(v.ParameterValue)
+	}
+
+	output := make([]*rds.Parameter, 0, len(parameters))
+	for _, v := range parameters {
+		if value, ok := values[strings.ToLower(aws.StringValue(v.ParameterName))]; ok && value != aws.StringValue(v.ParameterValue) && strings.TrimSpace(value) == strings.TrimSpace(aws.StringValue(v.ParameterValue)) {
+			p := *v
+			p.ParameterValue = aws.String(value)
+			output = append(output, &p)
+			continue
+		}
+
+		output = append(output, v)
+	}
+
+	return output
+}
+
+// parameterConfigured returns whether the parameter is one of the configured parameters.
+func parameterConfigured(configured []*rds.Parameter, parameter *rds.Parameter) bool {
+	for _, v := range configured {
+		if aws.StringValue(v.ParameterName) == strings.ToLower(aws.StringValue(parameter.ParameterName)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ChangedParameters returns the parameters whose values differ from their current values in the named DB parameter group.
+func ChangedParameters(ctx context.Context, conn *rds.RDS, name string, parameters []*rds.Parameter) ([]*rds.Parameter, error) {
+	current, err := findParameterGroupParameterValues(ctx, conn, name)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return changedParameters(parameters, current), nil
+}
+
+// changedParameters returns the parameters whose values differ from the current values, keyed by lower-cased parameter name.
+// A parameter without a current value is always changed.
+func changedParameters(parameters []*rds.Parameter, current map[string]*string) []*rds.Parameter {
+	var changed []*rds.Parameter
+	for _, v := range parameters {
+		if value, ok := current[strings.ToLower(aws.StringValue(v.ParameterName))]; ok && value != nil && aws.StringValue(value) == aws.StringValue(v.ParameterValue) {
+			continue
+		}
+
+		changed = append(changed, v)
+	}
+
+	return changed
+}
+
+// findParameterGroupParameterValues returns the current value of every parameter in the named DB parameter group,
+// keyed by lower-cased parameter name. Parameters without a value map to nil.
+func findParameterGroupParameterValues(ctx context.Context, conn *rds.RDS, name string) (map[string]*string, error) {
+	input := &rds.DescribeDBParametersInput{
+		DBParameterGroupName: aws.String(name),
+	}
+
+	parameters, err := findDBParameters(ctx, conn, input)
+
+	if err != nil {
+		return nil, fmt.Errorf("reading RDS DB Parameter Group (%s) parameters: %w", name, err)
+	}
+
+	current := make(map[string]*string)
+	for _, v := range parameters {
+		if v != nil && v.ParameterName != nil {
+			current[strings.ToLower(aws.StringValue(v.ParameterName))] = v.ParameterValue
+		}
+	}
+
+	return current, nil
+}
+
+// findParameterApplyTypes returns the apply type (static or dynamic) of each user-modified parameter in the
+// specified DB parameter group, keyed by lower-cased parameter name.
+func findParameterApplyTypes(ctx context.Context, conn *rds.RDS, name string) (map[string]string, error) {
+	input := &rds.DescribeDBParametersInput{
+		DBParameterGroupName: aws.String(name),
+		Source:               aws.String("user"),
+	}
+
+	parameters, err := findDBParameters(ctx, conn, input)
+
+	if err != nil {
+		return nil, fmt.Errorf("reading RDS DB Parameter Group (%s) parameters: %w", name, err)
+	}
+
+	applyTypes := make(map[string]string)
+	for _, v := range parameters {
+		if v != nil && v.ParameterName != nil {
+			applyTypes[strings.ToLower(aws.StringValue(v.ParameterName))] = aws.StringValue(v.ApplyType)
+		}
+	}
+
+	return applyTypes, nil
+}
+
+// GenerateParameterBlocks returns the HCL `parameter` blocks equivalent to the user-modified parameters of the
+// specified DB parameter group, to ease bringing parameter groups created outside of Terraform under management.
+func GenerateParameterBlocks(ctx context.Context, conn *rds.RDS, name string) (string, error) {
+	input := &rds.DescribeDBParametersInput{
+		DBParameterGroupName: aws.String(name),
+		Source:               aws.String("user"),
+	}
+
+	parameters, err := findDBParameters(ctx, conn, input)
+
+	if err != nil {
+		return "", fmt.Errorf("reading RDS DB Parameter Group (%s) parameters: %w", name, err)
+	}
+
+	sort.Slice(parameters, func(i, j int) bool {
+		return aws.StringValue(parameters[i].ParameterName) < aws.StringValue(parameters[j].ParameterName)
+	})
+
+	var buf strings.Builder
+	for i, v := range parameters {
+		if i > 0 {
+			buf.WriteString("\n")
+		}
+
+		name, value := strings.ToLower(aws.StringValue(v.ParameterName)), aws.StringValue(v.ParameterValue)
+
+		buf.WriteString("parameter {\n")
+		if applyMethod := strings.ToLower(aws.StringValue(v.ApplyMethod)); applyMethod != "" && applyMethod != "immediate" {
+			fmt.Fprintf(&buf, "  name         = %s\n", quoteHCLString(name))
+			fmt.Fprintf(&buf, "  value        = %s\n", quoteHCLString(value))
+			fmt.Fprintf(&buf, "  apply_method = %s\n", quoteHCLString(applyMethod))
+		} else {
+			fmt.Fprintf(&buf, "  name  = %s\n", quoteHCLString(name))
+			fmt.Fprintf(&buf, "  value = %s\n", quoteHCLString(value))
+		}
+		buf.WriteString("}\n")
+	}
+
+	return buf.String(), nil
+}
+
+// quoteHCLString returns a double-quoted HCL string literal, escaping template sequences.
+// Only the escapes defined by the HCL native syntax are used; other non-printable characters are written as \uNNNN.
+func quoteHCLString(s string) string {
+	s = strings.NewReplacer("${", "$${", "%{", "%%{").Replace(s)
+
+	var buf strings.Builder
+	buf.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '\\':
+			buf.WriteString(`\\`)
+		case '"':
+			buf.WriteString(`\"`)
+		case '\n':
+			buf.WriteString(`\n`)
+		case '\r':
+			buf.WriteString(`\r`)
+		case '\t':
+			buf.WriteString(`\t`)
+		default:
+			if unicode.IsPrint(r) {
+				buf.WriteRune(r)
+			} else if r > 0xFFFF {
+				fmt.Fprintf(&buf, `\U%08X`, r)
+			} else {
+				fmt.Fprintf(&buf, `\u%04X`, r)
+			}
+		}
+	}
+	buf.WriteByte('"')
+
+	return buf.String()
+}
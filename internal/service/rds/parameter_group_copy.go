@@ -0,0 +1,368 @@
+package rds
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/rds"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-aws/internal/conns"
+	"github.com/hashicorp/terraform-provider-aws/internal/errs/sdkdiag"
+	tftags "github.com/hashicorp/terraform-provider-aws/internal/tags"
+	"github.com/hashicorp/terraform-provider-aws/internal/tfresource"
+	"github.com/hashicorp/terraform-provider-aws/internal/verify"
+)
+
+// ResourceParameterGroupCopy copies a DB parameter group, optionally to another family, e.g. ahead of an engine
+// major version upgrade, as the family of a DB parameter group can't be changed in place.
+func ResourceParameterGroupCopy() *schema.Resource {
+	return &schema.Resource{
+		CreateWithoutTimeout: resourceParameterGroupCopyCreate,
+		ReadWithoutTimeout:   resourceParameterGroupCopyRead,
+		UpdateWithoutTimeout: resourceParameterGroupCopyUpdate,
+		DeleteWithoutTimeout: resourceParameterGroupDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"arn": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Default:  "Managed by Terraform",
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validParamGroupName,
+			},
+			"parameter": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"apply_method": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Default:  "immediate",
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"value": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+					},
+				},
+				Set: resourceParameterHash,
+			},
+			"requires_reboot": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"source_parameter_group_name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				// The source can't be read back, so an imported copy doesn't have one to compare with.
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return old == "" && d.Id() != ""
+				},
+			},
+			"target_family": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"tags":     tftags.TagsSchema(),
+			"tags_all": tftags.TagsSchemaComputed(),
+		},
+
+		CustomizeDiff: customdiff.Sequence(
+			verify.SetTagsDiff,
+			customdiff.ComputedIf("requires_reboot", func(_ context.Context, diff *schema.ResourceDiff, _ interface{}) bool {
+				return diff.HasChange("parameter")
+			}),
+		),
+	}
+}
+
+func resourceParameterGroupCopyCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	conn := meta.(*conns.AWSClient).RDSConn()
+	defaultTagsConfig := meta.(*conns.AWSClient).DefaultTagsConfig
+	tags := defaultTagsConfig.MergeTags(tftags.New(d.Get("tags").(map[string]interface{})))
+
+	name := d.Get("name").(string)
+	sourceName := d.Get("source_parameter_group_name").(string)
+
+	source, err := FindDBParameterGroupByName(ctx, conn, sourceName)
+
+	if err != nil {
+		return sdkdiag.AppendErrorf(diags, "copying RDS DB Parameter Group (%s): reading source: %s", sourceName, err)
+	}
+
+	targetFamily := d.Get("target_family").(string)
+	if targetFamily == "" {
+		targetFamily = aws.StringValue(source.DBParameterGroupFamily)
+	}
+
+	output, err := copyParameterGroup(ctx, conn, source, name, d.Get("description").(string), targetFamily, Tags(tags.IgnoreAWS()))
+
+	if output != nil {
+		d.SetId(aws.StringValue(output.DBParameterGroupName))
+	}
+
+	if err != nil {
+		return sdkdiag.AppendErrorf(diags, "copying RDS DB Parameter Group (%s) to %s: %s", sourceName, name, err)
+	}
+
+	// The overrides are applied for the family of the copy, which defaults to the source's.
+	d.Set("target_family", targetFamily)
+
+	return append(diags, resourceParameterGroupCopyUpdate(ctx, d, meta)...)
+}
+
+func resourceParameterGroupCopyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	conn := meta.(*conns.AWSClient).RDSConn()
+	defaultTagsConfig := meta.(*conns.AWSClient).DefaultTagsConfig
+	ignoreTagsConfig := meta.(*conns.AWSClient).IgnoreTagsConfig
+
+	group, err := FindDBParameterGroupByName(ctx, conn, d.Id())
+
+	if !d.IsNewResource() && tfresource.NotFound(err) {
+		log.Printf("[WARN] RDS DB Parameter Group (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return diags
+	}
+
+	if err != nil {
+		return sdkdiag.AppendErrorf(diags, "reading RDS DB Parameter Group (%s): %s", d.Id(), err)
+	}
+
+	d.Set("arn", group.DBParameterGroupArn)
+	d.Set("description", group.Description)
+	d.Set("name", group.DBParameterGroupName)
+	d.Set("target_family", group.DBParameterGroupFamily)
+
+	// Only the overridden parameters are managed, not the ones copied from the source.
+	configured := expandParameters(d.Get("parameter").(*schema.Set).List())
+	parameters, err := findParameterGroupOverriddenParameters(ctx, conn, d.Id(), configured)
+
+	if err != nil {
+		return sdkdiag.AppendErrorf(diags, "reading RDS DB Parameter Group (%s): %s", d.Id(), err)
+	}
+
+	if err := d.Set("parameter", flattenParameters(configuredStaticParameterApplyMethods(parameters, configured))); err != nil {
+		return sdkdiag.AppendErrorf(diags, "setting parameter: %s", err)
+	}
+
+	tags, err := ListTags(ctx, conn, d.Get("arn").(string))
+
+	if err != nil {
+		return sdkdiag.AppendErrorf(diags, "listing tags for RDS DB Parameter Group (%s): %s", d.Get("arn").(string), err)
+	}
+
+	tags = tags.IgnoreAWS().IgnoreConfig(ignoreTagsConfig)
+
+	//lintignore:AWSR002
+	if err := d.Set("tags", tags.RemoveDefaultConfig(defaultTagsConfig).Map()); err != nil {
+		return sdkdiag.AppendErrorf(diags, "setting tags: %s", err)
+	}
+
+	if err := d.Set("tags_all", tags.Map()); err != nil {
+		return sdkdiag.AppendErrorf(diags, "setting tags_all: %s", err)
+	}
+
+	return diags
+}
+
+func resourceParameterGroupCopyUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	conn := meta.(*conns.AWSClient).RDSConn()
+
+	if d.HasChange("parameter") {
+		o, n := d.GetChange("parameter")
+		os, ns := o.(*schema.Set), n.(*schema.Set)
+		family := d.Get("target_family").(string)
+
+		var requiresReboot bool
+
+		if parameters := expandParameters(ns.Difference(os).List()); len(parameters) > 0 {
+			// Static parameters can only be applied with the pending-reboot apply method.
+			defaults, err := findEngineDefaultParameters(ctx, conn, family)
+			if err != nil {
+				return sdkdiag.AppendErrorf(diags, "modifying RDS DB Parameter Group (%s): %s", d.Id(), err)
+			}
+
+			parameters, _ = staticParametersPendingReboot(parameters, defaults)
+
+			if err := modifyParameterGroupParameters(ctx, conn, d.Id(), family, "", parameters, nil); err != nil {
+				diags = sdkdiag.AppendErrorf(diags, "modifying RDS DB Parameter Group (%s): %s", d.Id(), err)
+				return append(diags, resourceParameterGroupCopyRead(ctx, d, meta)...)
+			}
+
+			requiresReboot = parametersRequireReboot(parameters)
+		}
+
+		// Overrides that have been removed are reset to their engine defaults, as the source's values may have changed since the copy.
+		var resetParameters []*rds.Parameter
+		for _, v := range expandParameters(os.List()) {
+			if !parameterConfigured(expandParameters(ns.List()), v) {
+				resetParameters = append(resetParameters, v)
+			}
+		}
+
+		if len(resetParameters) > 0 {
+			applyTypes, err := findParameterApplyTypes(ctx, conn, d.Id())
+			if err != nil {
+				return sdkdiag.AppendErrorf(diags, "resetting RDS DB Parameter Group (%s): %s", d.Id(), err)
+			}
+
+			for _, chunk := range ResourceParameterResetChunks(resetParameters, applyTypes, maxParamModifyChunk) {
+				input := &rds.ResetDBParameterGroupInput{
+					DBParameterGroupName: aws.String(d.Id()),
+					Parameters:           chunk,
+					ResetAllParameters:   aws.Bool(false),
+				}
+
+				log.Printf("[DEBUG] Resetting RDS DB Parameter Group: %s", input)
+				if _, err := conn.ResetDBParameterGroupWithContext(ctx, input); err != nil {
+					diags = sdkdiag.AppendErrorf(diags, "resetting RDS DB Parameter Group (%s): %s", d.Id(), err)
+					return append(diags, resourceParameterGroupCopyRead(ctx, d, meta)...)
+				}
+
+				requiresReboot = requiresReboot || parametersRequireReboot(chunk)
+			}
+		}
+
+		d.Set("requires_reboot", requiresReboot)
+	}
+
+	if d.HasChange("tags_all") && !d.IsNewResource() {
+		o, n := d.GetChange("tags_all")
+
+		if err := UpdateTags(ctx, conn, d.Get("arn").(string), o, n); err != nil {
+			return sdkdiag.AppendErrorf(diags, "updating RDS DB Parameter Group (%s) tags: %s", d.Get("arn").(string), err)
+		}
+	}
+
+	return append(diags, resourceParameterGroupCopyRead(ctx, d, meta)...)
+}
+
+// copyParameterGroup copies the source DB parameter group to a new one with the specified name in the target family.
+// Within the source's family, the group is copied with CopyDBParameterGroup. As that can't change the family, a copy to
+// another family is created and the source's user-modified parameters applied to it, failing before anything is created
+// if any of them isn't valid in the target family. The new group is returned with any error applying the parameters.
+func copyParameterGroup(ctx context.Context, conn *rds.RDS, source *rds.DBParameterGroup, name, description, targetFamily string, tags []*rds.Tag) (*rds.DBParameterGroup, error) {
+	sourceName := aws.StringValue(source.DBParameterGroupName)
+
+	if aws.StringValue(source.DBParameterGroupFamily) == targetFamily {
+		input := &rds.CopyDBParameterGroupInput{
+			SourceDBParameterGroupIdentifier:  source.DBParameterGroupArn,
+			Tags:                              tags,
+			TargetDBParameterGroupDescription: aws.String(description),
+			TargetDBParameterGroupIdentifier:  aws.String(name),
+		}
+
+		log.Printf("[DEBUG] Copying RDS DB Parameter Group: %s", input)
+		output, err := conn.CopyDBParameterGroupWithContext(ctx, input)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return output.DBParameterGroup, nil
+	}
+
+	parameters, err := findDBParameters(ctx, conn, &rds.DescribeDBParametersInput{
+		DBParameterGroupName: aws.String(sourceName),
+		Source:               aws.String("user"),
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("reading source parameters: %w", err)
+	}
+
+	defaults, err := findEngineDefaultParameters(ctx, conn, targetFamily)
+
+	if err != nil {
+		return nil, err
+	}
+
+	compatible, incompatible := CompatibleParameters(parameters, defaults)
+
+	if len(incompatible) > 0 {
+		return nil, fmt.Errorf("source parameters not valid in family %s: %s", targetFamily, strings.Join(incompatible, ", "))
+	}
+
+	input := &rds.CreateDBParameterGroupInput{
+		DBParameterGroupFamily: aws.String(targetFamily),
+		DBParameterGroupName:   aws.String(name),
+		Description:            aws.String(description),
+		Tags:                   tags,
+	}
+
+	log.Printf("[DEBUG] Creating RDS DB Parameter Group: %s", input)
+	output, err := conn.CreateDBParameterGroupWithContext(ctx, input)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// The new group isn't used by any DB instance yet, so every parameter can be applied on the next reboot, static or not.
+	copied := make([]*rds.Parameter, 0, len(compatible))
+	for _, v := range compatible {
+		copied = append(copied, &rds.Parameter{
+			ApplyMethod:    aws.String(rds.ApplyMethodPendingReboot),
+			ParameterName:  v.ParameterName,
+			ParameterValue: v.ParameterValue,
+		})
+	}
+
+	if len(copied) > 0 {
+		if err := modifyParameterGroupParameters(ctx, conn, name, targetFamily, "", copied, nil); err != nil {
+			return output.DBParameterGroup, fmt.Errorf("applying source parameters: %w", err)
+		}
+	}
+
+	return output.DBParameterGroup, nil
+}
+
+// findParameterGroupOverriddenParameters returns the configured parameters of the named DB parameter group, in
+// configuration order. The other parameters, e.g. those copied from a source group, are left out.
+func findParameterGroupOverriddenParameters(ctx context.Context, conn *rds.RDS, name string, configured []*rds.Parameter) ([]*rds.Parameter, error) {
+	if len(configured) == 0 {
+		return nil, nil
+	}
+
+	parameters, err := findParameterGroupOrderedParameters(ctx, conn, name, configured)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var output []*rds.Parameter
+	for _, v := range parameters {
+		if parameterConfigured(configured, v) {
+			output = append(output, v)
+		}
+	}
+
+	return output, nil
+}
@@ -0,0 +1,344 @@
+package rds_test
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/rds"
+	sdkacctest "github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/hashicorp/terraform-provider-aws/internal/acctest"
+	"github.com/hashicorp/terraform-provider-aws/internal/conns"
+	tfrds "github.com/hashicorp/terraform-provider-aws/internal/service/rds"
+	"github.com/hashicorp/terraform-provider-aws/internal/tfresource"
+)
+
+func TestCopyParameterGroup(t *testing.T) {
+	ctx := acctest.Context(t)
+	t.Parallel()
+
+	// query_cache_size was removed in MySQL 8.0.
+	families := map[string][]string{
+		"mysql5.7": {"character_set_server", "max_connections", "query_cache_size"},
+		"mysql8.0": {"character_set_server", "max_connections"},
+	}
+
+	source := &rds.DBParameterGroup{
+		DBParameterGroupArn:    aws.String("arn:aws:rds:us-west-2:123456789012:pg:source"), //lintignore:AWSAT003,AWSAT005
+		DBParameterGroupFamily: aws.String("mysql5.7"),
+		DBParameterGroupName:   aws.String("source"),
+	}
+
+	testCases := []struct {
+		Name             string
+		TargetFamily     string
+		SourceParameters []*rds.Parameter
+		ExpectError      *regexp.Regexp
+		ExpectedCalls    []string
+		ExpectedApplied  map[string]string
+	}{
+		{
+			Name:         "same family",
+			TargetFamily: "mysql5.7",
+			SourceParameters: []*rds.Parameter{
+				{ParameterName: aws.String("query_cache_size"), ParameterValue: aws.String("0")},
+			},
+			ExpectedCalls: []string{"CopyDBParameterGroup"},
+		},
+		{
+			Name:         "compatible family",
+			TargetFamily: "mysql8.0",
+			SourceParameters: []*rds.Parameter{
+				{ParameterName: aws.String("character_set_server"), ParameterValue: aws.String("utf8mb4")},
+				{ParameterName: aws.String("max_connections"), ParameterValue: aws.String("100")},
+			},
+			ExpectedCalls: []string{"DescribeDBParameters", "DescribeEngineDefaultParameters", "CreateDBParameterGroup", "ModifyDBParameterGroup"},
+			ExpectedApplied: map[string]string{
+				"character_set_server": "utf8mb4",
+				"max_connections":      "100",
+			},
+		},
+		{
+			Name:         "incompatible family",
+			TargetFamily: "mysql8.0",
+			SourceParameters: []*rds.Parameter{
+				{ParameterName: aws.String("character_set_server"), ParameterValue: aws.String("utf8mb4")},
+				{ParameterName: aws.String("query_cache_size"), ParameterValue: aws.String("0")},
+			},
+			ExpectError:   regexp.MustCompile(`source parameters not valid in family mysql8\.0: query_cache_size$`),
+			ExpectedCalls: []string{"DescribeDBParameters", "DescribeEngineDefaultParameters"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.Name, func(t *testing.T) {
+			t.Parallel()
+
+			var calls []string
+			applied := make(map[string]string)
+
+			conn := testParameterGroupConn(t, func(r *request.Request) {
+				calls = append(calls, r.Operation.Name)
+
+				switch input := r.Params.(type) {
+				case *rds.CopyDBParameterGroupInput:
+					r.Data.(*rds.CopyDBParameterGroupOutput).DBParameterGroup = &rds.DBParameterGroup{
+						DBParameterGroupFamily: source.DBParameterGroupFamily,
+						DBParameterGroupName:   input.TargetDBParameterGroupIdentifier,
+					}
+				case *rds.CreateDBParameterGroupInput:
+					r.Data.(*rds.CreateDBParameterGroupOutput).DBParameterGroup = &rds.DBParameterGroup{
+						DBParameterGroupFamily: input.DBParameterGroupFamily,
+						DBParameterGroupName:   input.DBParameterGroupName,
+					}
+				case *rds.DescribeDBParametersInput:
+					r.Data.(*rds.DescribeDBParametersOutput).Parameters = testCase.SourceParameters
+				case *rds.DescribeEngineDefaultParametersInput:
+					data := r.Data.(*rds.DescribeEngineDefaultParametersOutput)
+					data.EngineDefaults = &rds.EngineDefaults{}
+					for _, v := range families[aws.StringValue(input.DBParameterGroupFamily)] {
+						data.EngineDefaults.Parameters = append(data.EngineDefaults.Parameters, &rds.Parameter{ParameterName: aws.String(v)})
+					}
+				case *rds.ModifyDBParameterGroupInput:
+					for _, p := range input.Parameters {
+						if got, want := aws.StringValue(p.ApplyMethod), rds.ApplyMethodPendingReboot; got != want {
+							t.Errorf("parameter %q: got apply method %q, expected %q", aws.StringValue(p.ParameterName), got, want)
+						}
+						applied[aws.StringValue(p.ParameterName)] = aws.StringValue(p.ParameterValue)
+					}
+				}
+			})
+
+			output, err := tfrds.CopyParameterGroup(ctx, conn, source, "target", "test", testCase.TargetFamily, nil)
+
+			if testCase.ExpectError == nil && err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if testCase.ExpectError != nil {
+				if err == nil || !testCase.ExpectError.MatchString(err.Error()) {
+					t.Fatalf("got error %v, expected to match %q", err, testCase.ExpectError)
+				}
+			} else if got, want := aws.StringValue(output.DBParameterGroupFamily), testCase.TargetFamily; got != want {
+				t.Errorf("got family %q, expected %q", got, want)
+			}
+
+			if !reflect.DeepEqual(calls, testCase.ExpectedCalls) {
+				t.Errorf("got calls %v, expected %v", calls, testCase.ExpectedCalls)
+			}
+
+			if len(applied) > 0 || len(testCase.ExpectedApplied) > 0 {
+				if !reflect.DeepEqual(applied, testCase.ExpectedApplied) {
+					t.Errorf("got applied parameters %v, expected %v", applied, testCase.ExpectedApplied)
+				}
+			}
+		})
+	}
+}
+
+func TestAccRDSParameterGroupCopy_basic(t *testing.T) {
+	ctx := acctest.Context(t)
+	var v rds.DBParameterGroup
+	resourceName := "aws_db_parameter_group_copy.test"
+	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ErrorCheck:               acctest.ErrorCheck(t, rds.EndpointsID),
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckParameterGroupCopyDestroy(ctx),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccParameterGroupCopyConfig_basic(rName, "mysql5.7"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckParameterGroupExists(ctx, resourceName, &v),
+					resource.TestCheckResourceAttr(resourceName, "name", rName+"-copy"),
+					resource.TestCheckResourceAttr(resourceName, "target_family", "mysql5.7"),
+					resource.TestCheckResourceAttr(resourceName, "parameter.#", "0"),
+					testAccCheckParameterGroupParameterValue(ctx, resourceName, "character_set_server", "utf8mb4"),
+					testAccCheckParameterGroupParameterValue(ctx, resourceName, "query_cache_size", "0"),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"requires_reboot", "source_parameter_group_name"},
+			},
+		},
+	})
+}
+
+func TestAccRDSParameterGroupCopy_targetFamily(t *testing.T) {
+	ctx := acctest.Context(t)
+	var v rds.DBParameterGroup
+	resourceName := "aws_db_parameter_group_copy.test"
+	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ErrorCheck:               acctest.ErrorCheck(t, rds.EndpointsID),
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckParameterGroupCopyDestroy(ctx),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccParameterGroupCopyConfig_targetFamily(rName, "4096"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckParameterGroupExists(ctx, resourceName, &v),
+					resource.TestCheckResourceAttr(resourceName, "target_family", "mysql8.0"),
+					resource.TestCheckResourceAttr(resourceName, "parameter.#", "1"),
+					testAccCheckParameterGroupParameterValue(ctx, resourceName, "character_set_server", "utf8mb4"),
+					testAccCheckParameterGroupParameterValue(ctx, resourceName, "max_connections", "4096"),
+				),
+			},
+			{
+				Config: testAccParameterGroupCopyConfig_targetFamily(rName, "2048"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckParameterGroupExists(ctx, resourceName, &v),
+					resource.TestCheckResourceAttr(resourceName, "parameter.#", "1"),
+					testAccCheckParameterGroupParameterValue(ctx, resourceName, "max_connections", "2048"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccRDSParameterGroupCopy_incompatibleParameters(t *testing.T) {
+	ctx := acctest.Context(t)
+	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ErrorCheck:               acctest.ErrorCheck(t, rds.EndpointsID),
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckParameterGroupCopyDestroy(ctx),
+		Steps: []resource.TestStep{
+			{
+				// query_cache_size isn't valid in MySQL 8.0.
+				Config:      testAccParameterGroupCopyConfig_basic(rName, "mysql8.0"),
+				ExpectError: regexp.MustCompile(`source parameters not valid in family mysql8\.0: query_cache_size`),
+			},
+		},
+	})
+}
+
+func testAccCheckParameterGroupCopyDestroy(ctx context.Context) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		conn := acctest.Provider.Meta().(*conns.AWSClient).RDSConn()
+
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != "aws_db_parameter_group" && rs.Type != "aws_db_parameter_group_copy" {
+				continue
+			}
+
+			_, err := tfrds.FindDBParameterGroupByName(ctx, conn, rs.Primary.ID)
+
+			if tfresource.NotFound(err) {
+				continue
+			}
+
+			if err != nil {
+				return err
+			}
+
+			return fmt.Errorf("RDS DB Parameter Group %s still exists", rs.Primary.ID)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckParameterGroupParameterValue(ctx context.Context, n, name, value string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		conn := acctest.Provider.Meta().(*conns.AWSClient).RDSConn()
+
+		output, err := conn.DescribeDBParametersWithContext(ctx, &rds.DescribeDBParametersInput{
+			DBParameterGroupName: aws.String(rs.Primary.ID),
+			Source:               aws.String("user"),
+		})
+
+		if err != nil {
+			return err
+		}
+
+		for _, v := range output.Parameters {
+			if aws.StringValue(v.ParameterName) == name {
+				if got := aws.StringValue(v.ParameterValue); got != value {
+					return fmt.Errorf("DB Parameter %s: got value %q, expected %q", name, got, value)
+				}
+
+				return nil
+			}
+		}
+
+		return fmt.Errorf("DB Parameter %s is not user defined", name)
+	}
+}
+
+func testAccParameterGroupCopyConfig_source(rName string) string {
+	return fmt.Sprintf(`
+resource "aws_db_parameter_group" "source" {
+  name   = %[1]q
+  family = "mysql5.7"
+
+  parameter {
+    name  = "character_set_server"
+    value = "utf8mb4"
+  }
+
+  parameter {
+    name         = "query_cache_size"
+    value        = "0"
+    apply_method = "pending-reboot"
+  }
+}
+`, rName)
+}
+
+func testAccParameterGroupCopyConfig_basic(rName, targetFamily string) string {
+	return acctest.ConfigCompose(testAccParameterGroupCopyConfig_source(rName), fmt.Sprintf(`
+resource "aws_db_parameter_group_copy" "test" {
+  name                        = "%[1]s-copy"
+  source_parameter_group_name = aws_db_parameter_group.source.name
+  target_family               = %[2]q
+}
+`, rName, targetFamily))
+}
+
+func testAccParameterGroupCopyConfig_targetFamily(rName, maxConnections string) string {
+	return fmt.Sprintf(`
+resource "aws_db_parameter_group" "source" {
+  name   = %[1]q
+  family = "mysql5.7"
+
+  parameter {
+    name  = "character_set_server"
+    value = "utf8mb4"
+  }
+}
+
+resource "aws_db_parameter_group_copy" "test" {
+  name                        = "%[1]s-copy"
+  source_parameter_group_name = aws_db_parameter_group.source.name
+  target_family               = "mysql8.0"
+
+  parameter {
+    name  = "max_connections"
+    value = %[2]q
+  }
+}
+`, rName, maxConnections)
+}
@@ -0,0 +1,111 @@
+package rds
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/rds"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-aws/internal/conns"
+	"github.com/hashicorp/terraform-provider-aws/internal/errs/sdkdiag"
+)
+
+// DataSourceParameterGroupDiff previews which of the desired parameters a modify of the named DB parameter group would change.
+func DataSourceParameterGroupDiff() *schema.Resource {
+	return &schema.Resource{
+		ReadWithoutTimeout: dataSourceParameterGroupDiffRead,
+
+		Schema: map[string]*schema.Schema{
+			"changed_parameter": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"current_value": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"value": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"parameter": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"value": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceParameterGroupDiffRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	conn := meta.(*conns.AWSClient).RDSConn()
+
+	name := d.Get("name").(string)
+	current, err := findParameterGroupParameterValues(ctx, conn, name)
+
+	if err != nil {
+		return sdkdiag.AppendErrorf(diags, "reading RDS DB Parameter Group (%s) diff: %s", name, err)
+	}
+
+	var parameters []*rds.Parameter
+	for _, v := range d.Get("parameter").(*schema.Set).List() {
+		tfMap := v.(map[string]interface{})
+		parameters = append(parameters, &rds.Parameter{
+			ParameterName:  aws.String(strings.ToLower(tfMap["name"].(string))),
+			ParameterValue: aws.String(tfMap["value"].(string)),
+		})
+	}
+
+	d.SetId(name)
+	if err := d.Set("changed_parameter", flattenParameterGroupDiff(changedParameters(parameters, current), current)); err != nil {
+		return sdkdiag.AppendErrorf(diags, "setting changed_parameter: %s", err)
+	}
+
+	return diags
+}
+
+// flattenParameterGroupDiff returns the changed parameters, ordered by name, with their current values.
+func flattenParameterGroupDiff(changed []*rds.Parameter, current map[string]*string) []interface{} {
+	sort.Slice(changed, func(i, j int) bool {
+		return aws.StringValue(changed[i].ParameterName) < aws.StringValue(changed[j].ParameterName)
+	})
+
+	tfList := make([]interface{}, 0, len(changed))
+	for _, v := range changed {
+		name := aws.StringValue(v.ParameterName)
+		tfList = append(tfList, map[string]interface{}{
+			"current_value": aws.StringValue(current[name]),
+			"name":          name,
+			"value":         aws.StringValue(v.ParameterValue),
+		})
+	}
+
+	return tfList
+}
@@ -0,0 +1,129 @@
+package rds_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/rds"
+	sdkacctest "github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-provider-aws/internal/acctest"
+	tfrds "github.com/hashicorp/terraform-provider-aws/internal/service/rds"
+)
+
+func TestFlattenParameterGroupDiff(t *testing.T) {
+	t.Parallel()
+
+	current := map[string]*string{
+		"character_set_client": aws.String("utf8"),
+		"character_set_server": aws.String("utf8"),
+		"max_connections":      aws.String("100"),
+		"sql_mode":             nil,
+	}
+
+	testCases := []struct {
+		Name     string
+		Desired  map[string]string
+		Expected []interface{}
+	}{
+		{
+			Name:     "none desired",
+			Expected: []interface{}{},
+		},
+		{
+			Name: "all unchanged",
+			Desired: map[string]string{
+				"character_set_client": "utf8",
+				"character_set_server": "utf8",
+			},
+			Expected: []interface{}{},
+		},
+		{
+			Name: "partial overlap",
+			Desired: map[string]string{
+				"character_set_client": "utf8",
+				"character_set_server": "utf8mb4",
+				"max_connections":      "100",
+			},
+			Expected: []interface{}{
+				map[string]interface{}{"current_value": "utf8", "name": "character_set_server", "value": "utf8mb4"},
+			},
+		},
+		{
+			Name: "unset and unknown parameters",
+			Desired: map[string]string{
+				"max_connections": "200",
+				"sql_mode":        "TRADITIONAL",
+				"time_zone":       "UTC",
+			},
+			Expected: []interface{}{
+				map[string]interface{}{"current_value": "100", "name": "max_connections", "value": "200"},
+				map[string]interface{}{"current_value": "", "name": "sql_mode", "value": "TRADITIONAL"},
+				map[string]interface{}{"current_value": "", "name": "time_zone", "value": "UTC"},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.Name, func(t *testing.T) {
+			t.Parallel()
+
+			var parameters []*rds.Parameter
+			for name, value := range testCase.Desired {
+				parameters = append(parameters, &rds.Parameter{
+					ParameterName:  aws.String(name),
+					ParameterValue: aws.String(value),
+				})
+			}
+
+			got := tfrds.FlattenParameterGroupDiff(tfrds.ChangedParametersFromValues(parameters, current), current)
+
+			if !reflect.DeepEqual(got, testCase.Expected) {
+				t.Errorf("got %v, expected %v", got, testCase.Expected)
+			}
+		})
+	}
+}
+
+func TestAccRDSParameterGroupDiffDataSource_basic(t *testing.T) {
+	rName := sdkacctest.RandomWithPrefix(acctest.ResourcePrefix)
+	dataSourceName := "data.aws_db_parameter_group_diff.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ErrorCheck:               acctest.ErrorCheck(t, rds.EndpointsID),
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccParameterGroupDiffDataSourceConfig_basic(rName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "changed_parameter.#", "1"),
+					resource.TestCheckResourceAttr(dataSourceName, "changed_parameter.0.current_value", "utf8"),
+					resource.TestCheckResourceAttr(dataSourceName, "changed_parameter.0.name", "character_set_server"),
+					resource.TestCheckResourceAttr(dataSourceName, "changed_parameter.0.value", "utf8mb4"),
+				),
+			},
+		},
+	})
+}
+
+func testAccParameterGroupDiffDataSourceConfig_basic(rName string) string {
+	return acctest.ConfigCompose(testAccParameterGroupConfig_basic(rName), `
+data "aws_db_parameter_group_diff" "test" {
+  name = aws_db_parameter_group.test.name
+
+  parameter {
+    name  = "character_set_client"
+    value = "utf8"
+  }
+
+  parameter {
+    name  = "character_set_server"
+    value = "utf8mb4"
+  }
+}
+`)
+}
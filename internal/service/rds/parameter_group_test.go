@@ -2,16 +2,23 @@ package rds_test
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"reflect"
 	"regexp"
+	"sort"
+	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/rds"
 	sdkacctest "github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/hashicorp/terraform-provider-aws/internal/acctest"
 	"github.com/hashicorp/terraform-provider-aws/internal/conns"
@@ -53,9 +60,10 @@ func TestAccRDSParameterGroup_basic(t *testing.T) {
 				),
 			},
 			{
-				ResourceName:      resourceName,
-				ImportState:       true,
-				ImportStateVerify: true,
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"estimated_modify_calls"},
 			},
 			{
 				Config: testAccParameterGroupConfig_addParameters(groupName),
@@ -115,6 +123,7 @@ func TestAccRDSParameterGroup_basic(t *testing.T) {
 
 func TestAccRDSParameterGroup_caseWithMixedParameters(t *testing.T) {
 	ctx := acctest.Context(t)
+	resourceName := "aws_db_parameter_group.test"
 	groupName := fmt.Sprintf("parameter-group-test-terraform-%d", sdkacctest.RandInt())
 
 	resource.ParallelTest(t, resource.TestCase{
@@ -125,7 +134,20 @@ func TestAccRDSParameterGroup_caseWithMixedParameters(t *testing.T) {
 		Steps: []resource.TestStep{
 			{
 				Config: testAccParameterGroupConfig_caseWithMixedParameters(groupName),
-				Check:  resource.ComposeTestCheckFunc(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "parameter.*", map[string]string{
+						"name":      "character_set_server",
+						"is_static": "false",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "parameter.*", map[string]string{
+						"name":      "innodb_log_file_size",
+						"is_static": "true",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "parameter.*", map[string]string{
+						"name":      "max_allowed_packet",
+						"is_static": "false",
+					}),
+				),
 			},
 		},
 	})
@@ -151,6 +173,8 @@ func TestAccRDSParameterGroup_limit(t *testing.T) {
 					resource.TestCheckResourceAttr(resourceName, "name", groupName),
 					resource.TestCheckResourceAttr(resourceName, "family", "mysql5.6"),
 					resource.TestCheckResourceAttr(resourceName, "description", "RDS default parameter group: Exceed default AWS parameter group limit of twenty"),
+					// 41 parameters are applied in chunks of 20.
+					resource.TestCheckResourceAttr(resourceName, "estimated_modify_calls", "3"),
 					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "parameter.*", map[string]string{
 						"name":  "character_set_server",
 						"value": "utf8",
@@ -318,9 +342,10 @@ func TestAccRDSParameterGroup_limit(t *testing.T) {
 				),
 			},
 			{
-				ResourceName:      resourceName,
-				ImportState:       true,
-				ImportStateVerify: true,
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"estimated_modify_calls", "requires_reboot"},
 			},
 			{
 				Config: testAccParameterGroupConfig_updateExceedDefaultLimit(groupName),
@@ -524,6 +549,341 @@ func TestAccRDSParameterGroup_disappears(t *testing.T) {
 	})
 }
 
+func TestAccRDSParameterGroup_alreadyExists(t *testing.T) {
+	ctx := acctest.Context(t)
+	groupName := fmt.Sprintf("parameter-group-test-terraform-%d", sdkacctest.RandInt())
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ErrorCheck:               acctest.ErrorCheck(t, rds.EndpointsID),
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckParameterGroupDestroy(ctx),
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccParameterGroupConfig_alreadyExists(groupName),
+				ExpectError: regexp.MustCompile(`already exists. To manage it with Terraform, import it`),
+			},
+		},
+	})
+}
+
+// A failure applying parameters after the group is created must leave the group in state,
+// so that it is replaced rather than orphaned on the next apply.
+func TestAccRDSParameterGroup_createParameterFailure(t *testing.T) {
+	ctx := acctest.Context(t)
+	var v rds.DBParameterGroup
+	resourceName := "aws_db_parameter_group.test"
+	groupName := fmt.Sprintf("parameter-group-test-terraform-%d", sdkacctest.RandInt())
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ErrorCheck:               acctest.ErrorCheck(t, rds.EndpointsID),
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckParameterGroupDestroy(ctx),
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccParameterGroupConfig_invalidParameter(groupName),
+				ExpectError: regexp.MustCompile(`modifying DB Parameter Group`),
+			},
+			{
+				Config: testAccParameterGroupConfig_basic(groupName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckParameterGroupExists(ctx, resourceName, &v),
+					resource.TestCheckResourceAttr(resourceName, "name", groupName),
+					resource.TestCheckResourceAttr(resourceName, "parameter.#", "3"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccRDSParameterGroup_skipUnchangedParameters(t *testing.T) {
+	ctx := acctest.Context(t)
+	var v rds.DBParameterGroup
+	resourceName := "aws_db_parameter_group.test"
+	groupName := fmt.Sprintf("parameter-group-test-terraform-%d", sdkacctest.RandInt())
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ErrorCheck:               acctest.ErrorCheck(t, rds.EndpointsID),
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckParameterGroupDestroy(ctx),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccParameterGroupConfig_skipUnchangedParameters(groupName, "utf8mb4"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckParameterGroupExists(ctx, resourceName, &v),
+					resource.TestCheckResourceAttr(resourceName, "skip_unchanged_parameters", "true"),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "parameter.*", map[string]string{
+						"name":  "character_set_server",
+						"value": "utf8mb4",
+					}),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"estimated_modify_calls", "skip_unchanged_parameters"},
+			},
+			{
+				Config: testAccParameterGroupConfig_skipUnchangedParameters(groupName, "latin1"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckParameterGroupExists(ctx, resourceName, &v),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "parameter.*", map[string]string{
+						"name":  "character_set_server",
+						"value": "latin1",
+					}),
+				),
+			},
+		},
+	})
+}
+
+func TestAccRDSParameterGroup_resetAllParametersOnClear(t *testing.T) {
+	ctx := acctest.Context(t)
+	var v rds.DBParameterGroup
+	resourceName := "aws_db_parameter_group.test"
+	groupName := fmt.Sprintf("parameter-group-test-terraform-%d", sdkacctest.RandInt())
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ErrorCheck:               acctest.ErrorCheck(t, rds.EndpointsID),
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckParameterGroupDestroy(ctx),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccParameterGroupConfig_resetAllParametersOnClear(groupName, true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckParameterGroupExists(ctx, resourceName, &v),
+					resource.TestCheckResourceAttr(resourceName, "reset_all_parameters_on_clear", "true"),
+					resource.TestCheckResourceAttr(resourceName, "parameter.#", "2"),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"estimated_modify_calls", "reset_all_parameters_on_clear"},
+			},
+			{
+				Config: testAccParameterGroupConfig_resetAllParametersOnClear(groupName, false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckParameterGroupExists(ctx, resourceName, &v),
+					resource.TestCheckResourceAttr(resourceName, "parameter.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccRDSParameterGroup_importScopedParameters(t *testing.T) {
+	ctx := acctest.Context(t)
+	var v rds.DBParameterGroup
+	resourceName := "aws_db_parameter_group.test"
+	groupName := fmt.Sprintf("parameter-group-test-terraform-%d", sdkacctest.RandInt())
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ErrorCheck:               acctest.ErrorCheck(t, rds.EndpointsID),
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckParameterGroupDestroy(ctx),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccParameterGroupConfig_basic(groupName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckParameterGroupExists(ctx, resourceName, &v),
+					resource.TestCheckResourceAttr(resourceName, "parameter.#", "3"),
+				),
+			},
+			{
+				ResourceName:  resourceName,
+				ImportState:   true,
+				ImportStateId: groupName + ":character_set_server,character_set_client",
+				ImportStateCheck: func(s []*terraform.InstanceState) error {
+					if len(s) != 1 {
+						return fmt.Errorf("expected 1 state, got %d", len(s))
+					}
+
+					if got, want := s[0].ID, groupName; got != want {
+						return fmt.Errorf("ID: got %q, expected %q", got, want)
+					}
+
+					if got, want := s[0].Attributes["parameter.#"], "2"; got != want {
+						return fmt.Errorf("parameter.#: got %q, expected %q", got, want)
+					}
+
+					for k, v := range s[0].Attributes {
+						if strings.HasPrefix(k, "parameter.") && strings.HasSuffix(k, ".name") && v == "character_set_results" {
+							return fmt.Errorf("parameter %q imported, expected only the named parameters", v)
+						}
+
+						if strings.HasPrefix(k, "parameter.") && strings.HasSuffix(k, ".value") && v != "utf8" {
+							return fmt.Errorf("%s: got %q, expected %q", k, v, "utf8")
+						}
+					}
+
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func TestAccRDSParameterGroup_importAllParameters(t *testing.T) {
+	ctx := acctest.Context(t)
+	var v rds.DBParameterGroup
+	resourceName := "aws_db_parameter_group.test"
+	groupName := fmt.Sprintf("parameter-group-test-terraform-%d", sdkacctest.RandInt())
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ErrorCheck:               acctest.ErrorCheck(t, rds.EndpointsID),
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckParameterGroupDestroy(ctx),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccParameterGroupConfig_basic(groupName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckParameterGroupExists(ctx, resourceName, &v),
+					resource.TestCheckResourceAttr(resourceName, "all_parameters.#", "0"),
+					resource.TestCheckResourceAttr(resourceName, "snapshot_all_parameters", "false"),
+				),
+			},
+			{
+				ResourceName:  resourceName,
+				ImportState:   true,
+				ImportStateId: groupName + "/all_parameters",
+				ImportStateCheck: func(s []*terraform.InstanceState) error {
+					if len(s) != 1 {
+						return fmt.Errorf("expected 1 state, got %d", len(s))
+					}
+
+					if got, want := s[0].ID, groupName; got != want {
+						return fmt.Errorf("ID: got %q, expected %q", got, want)
+					}
+
+					if got, want := s[0].Attributes["snapshot_all_parameters"], "true"; got != want {
+						return fmt.Errorf("snapshot_all_parameters: got %q, expected %q", got, want)
+					}
+
+					// The snapshot has every parameter of the family, not only the 3 user-source ones.
+					if n, err := strconv.Atoi(s[0].Attributes["all_parameters.#"]); err != nil || n <= 3 {
+						return fmt.Errorf("all_parameters.#: got %q, expected more than 3", s[0].Attributes["all_parameters.#"])
+					}
+
+					for k, v := range s[0].Attributes {
+						if strings.HasPrefix(k, "all_parameters.") && strings.HasSuffix(k, ".name") && v == "character_set_server" {
+							if source := s[0].Attributes[strings.TrimSuffix(k, ".name")+".source"]; source != "user" {
+								return fmt.Errorf("%s source: got %q, expected %q", v, source, "user")
+							}
+
+							return nil
+						}
+					}
+
+					return fmt.Errorf("all_parameters: character_set_server not found")
+				},
+			},
+		},
+	})
+}
+
+func TestAccRDSParameterGroup_parameterGroupConstraint(t *testing.T) {
+	ctx := acctest.Context(t)
+	var v rds.DBParameterGroup
+	resourceName := "aws_db_parameter_group.test"
+	groupName := fmt.Sprintf("parameter-group-test-terraform-%d", sdkacctest.RandInt())
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ErrorCheck:               acctest.ErrorCheck(t, rds.EndpointsID),
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckParameterGroupDestroy(ctx),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccParameterGroupConfig_parameterGroupConstraint(groupName, "utf8", "utf8_general_ci"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckParameterGroupExists(ctx, resourceName, &v),
+					resource.TestCheckResourceAttr(resourceName, "parameter_group_constraint.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "parameter_group_constraint.0.names.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "parameter.*", map[string]string{
+						"name":  "character_set_server",
+						"value": "utf8",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "parameter.*", map[string]string{
+						"name":  "collation_server",
+						"value": "utf8_general_ci",
+					}),
+				),
+			},
+			{
+				Config: testAccParameterGroupConfig_parameterGroupConstraint(groupName, "latin1", "latin1_swedish_ci"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckParameterGroupExists(ctx, resourceName, &v),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "parameter.*", map[string]string{
+						"name":  "character_set_server",
+						"value": "latin1",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "parameter.*", map[string]string{
+						"name":  "collation_server",
+						"value": "latin1_swedish_ci",
+					}),
+				),
+			},
+		},
+	})
+}
+
+func TestAccRDSParameterGroup_orderedParameters(t *testing.T) {
+	ctx := acctest.Context(t)
+	var v rds.DBParameterGroup
+	resourceName := "aws_db_parameter_group.test"
+	groupName := fmt.Sprintf("parameter-group-test-terraform-%d", sdkacctest.RandInt())
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ErrorCheck:               acctest.ErrorCheck(t, rds.EndpointsID),
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckParameterGroupDestroy(ctx),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccParameterGroupConfig_orderedParameters(groupName, "utf8"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckParameterGroupExists(ctx, resourceName, &v),
+					resource.TestCheckResourceAttr(resourceName, "parameter.#", "0"),
+					resource.TestCheckResourceAttr(resourceName, "ordered_parameter.#", "3"),
+					resource.TestCheckResourceAttr(resourceName, "ordered_parameter.0.name", "character_set_server"),
+					resource.TestCheckResourceAttr(resourceName, "ordered_parameter.0.value", "utf8"),
+					resource.TestCheckResourceAttr(resourceName, "ordered_parameter.1.name", "character_set_client"),
+					resource.TestCheckResourceAttr(resourceName, "ordered_parameter.1.value", "utf8"),
+					resource.TestCheckResourceAttr(resourceName, "ordered_parameter.2.name", "character_set_results"),
+					resource.TestCheckResourceAttr(resourceName, "ordered_parameter.2.value", "utf8"),
+				),
+			},
+			{
+				Config: testAccParameterGroupConfig_orderedParameters(groupName, "ascii"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckParameterGroupExists(ctx, resourceName, &v),
+					resource.TestCheckResourceAttr(resourceName, "ordered_parameter.#", "3"),
+					resource.TestCheckResourceAttr(resourceName, "ordered_parameter.0.name", "character_set_server"),
+					resource.TestCheckResourceAttr(resourceName, "ordered_parameter.0.value", "ascii"),
+					resource.TestCheckResourceAttr(resourceName, "ordered_parameter.2.name", "character_set_results"),
+					resource.TestCheckResourceAttr(resourceName, "ordered_parameter.2.value", "ascii"),
+				),
+			},
+			{
+				Config: testAccParameterGroupConfig_basic(groupName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckParameterGroupExists(ctx, resourceName, &v),
+					resource.TestCheckResourceAttr(resourceName, "ordered_parameter.#", "0"),
+					resource.TestCheckResourceAttr(resourceName, "parameter.#", "3"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccRDSParameterGroup_namePrefix(t *testing.T) {
 	ctx := acctest.Context(t)
 	var v rds.DBParameterGroup
@@ -585,6 +945,7 @@ func TestAccRDSParameterGroup_withApplyMethod(t *testing.T) {
 					resource.TestCheckResourceAttr(resourceName, "name", groupName),
 					resource.TestCheckResourceAttr(resourceName, "family", "mysql5.6"),
 					resource.TestCheckResourceAttr(resourceName, "description", "Managed by Terraform"),
+					resource.TestCheckResourceAttr(resourceName, "requires_reboot", "true"),
 					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "parameter.*", map[string]string{
 						"name":         "character_set_server",
 						"value":        "utf8",
@@ -598,9 +959,10 @@ func TestAccRDSParameterGroup_withApplyMethod(t *testing.T) {
 				),
 			},
 			{
-				ResourceName:      resourceName,
-				ImportState:       true,
-				ImportStateVerify: true,
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"estimated_modify_calls", "requires_reboot"},
 			},
 		},
 	})
@@ -660,7 +1022,7 @@ func TestAccRDSParameterGroup_matchDefault(t *testing.T) {
 				ResourceName:            resourceName,
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"parameter"},
+				ImportStateVerifyIgnore: []string{"estimated_modify_calls", "parameter", "requires_reboot"},
 			},
 		},
 	})
@@ -685,6 +1047,7 @@ func TestAccRDSParameterGroup_updateParameters(t *testing.T) {
 					testAccCheckParameterGroupAttributes(&v, groupName),
 					resource.TestCheckResourceAttr(resourceName, "name", groupName),
 					resource.TestCheckResourceAttr(resourceName, "family", "mysql5.6"),
+					resource.TestCheckResourceAttr(resourceName, "requires_reboot", "false"),
 					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "parameter.*", map[string]string{
 						"name":  "character_set_results",
 						"value": "utf8",
@@ -700,9 +1063,10 @@ func TestAccRDSParameterGroup_updateParameters(t *testing.T) {
 				),
 			},
 			{
-				ResourceName:      resourceName,
-				ImportState:       true,
-				ImportStateVerify: true,
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"estimated_modify_calls"},
 			},
 			{
 				Config: testAccParameterGroupConfig_updateParametersUpdated(groupName),
@@ -753,9 +1117,10 @@ func TestAccRDSParameterGroup_caseParameters(t *testing.T) {
 				),
 			},
 			{
-				ResourceName:      resourceName,
-				ImportState:       true,
-				ImportStateVerify: true,
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"estimated_modify_calls"},
 			},
 			{
 				Config: testAccParameterGroupConfig_upperCase(rName, "max_connections"),
@@ -764,6 +1129,94 @@ func TestAccRDSParameterGroup_caseParameters(t *testing.T) {
 	})
 }
 
+func TestFlattenParameterGroupParameters(t *testing.T) {
+	t.Parallel()
+
+	parameters := []*rds.Parameter{
+		{
+			ApplyType:      aws.String("dynamic"),
+			IsModifiable:   aws.Bool(true),
+			ParameterName:  aws.String("max_connections"),
+			ParameterValue: aws.String("100"),
+		},
+		{
+			ApplyMethod:          aws.String("pending-reboot"),
+			ApplyType:            aws.String("static"),
+			Description:          aws.String("Size of each log file in a log group."),
+			IsModifiable:         aws.Bool(false),
+			MinimumEngineVersion: aws.String("8.0.30"),
+			ParameterName:        aws.String("innodb_log_file_size"),
+			ParameterValue:       aws.String("2147483648"),
+		},
+		{
+			ApplyMethod:    aws.String("pending-reboot"),
+			ApplyType:      aws.String("Static"),
+			ParameterName:  aws.String("Performance_Schema"),
+			ParameterValue: aws.String("1"),
+		},
+		{
+			ParameterName:  aws.String("character_set_server"),
+			ParameterValue: aws.String("utf8"),
+		},
+		{
+			ApplyType: aws.String("static"),
+		},
+	}
+
+	expected := []map[string]interface{}{
+		{
+			"is_modifiable": true,
+			"is_static":     false,
+			"name":          "max_connections",
+			"value":         "100",
+		},
+		{
+			"apply_method":           "pending-reboot",
+			"description":            "Size of each log file in a log group.",
+			"is_modifiable":          false,
+			"is_static":              true,
+			"minimum_engine_version": "8.0.30",
+			"name":                   "innodb_log_file_size",
+			"value":                  "2147483648",
+		},
+		{
+			"apply_method": "pending-reboot",
+			"is_static":    true,
+			"name":         "performance_schema",
+			"value":        "1",
+		},
+		{
+			"is_static": false,
+			"name":      "character_set_server",
+			"value":     "utf8",
+		},
+	}
+
+	if got := tfrds.FlattenParameterGroupParameters(parameters); !reflect.DeepEqual(got, expected) {
+		t.Errorf("got %v, expected %v", got, expected)
+	}
+
+	// description, is_modifiable, is_static and minimum_engine_version don't affect the hash, so a read parameter matches the configured one.
+	d := tfrds.ResourceParameterGroup().TestResourceData()
+	if err := d.Set("parameter", expected[1:2]); err != nil {
+		t.Fatalf("setting parameter: %s", err)
+	}
+
+	configured := schema.NewSet(d.Get("parameter").(*schema.Set).F, []interface{}{
+		map[string]interface{}{
+			"apply_method":  "pending-reboot",
+			"is_modifiable": true,
+			"is_static":     false,
+			"name":          "innodb_log_file_size",
+			"value":         "2147483648",
+		},
+	})
+
+	if diff := configured.Difference(d.Get("parameter").(*schema.Set)); diff.Len() != 0 {
+		t.Errorf("got %d changed parameters, expected none", diff.Len())
+	}
+}
+
 func TestDBParameterModifyChunk(t *testing.T) {
 	t.Parallel()
 
@@ -993,163 +1446,2525 @@ func TestDBParameterModifyChunk(t *testing.T) {
 	}
 }
 
-func testAccCheckParamaterGroupDisappears(ctx context.Context, v *rds.DBParameterGroup) resource.TestCheckFunc {
-	return func(s *terraform.State) error {
-		conn := acctest.Provider.Meta().(*conns.AWSClient).RDSConn()
+func TestDBParameterModifyChunk_invariants(t *testing.T) {
+	t.Parallel()
 
-		for _, rs := range s.RootModule().Resources {
-			if rs.Type != "aws_db_parameter_group" {
-				continue
-			}
-			_, err := conn.DeleteDBParameterGroupWithContext(ctx, &rds.DeleteDBParameterGroupInput{
-				DBParameterGroupName: v.DBParameterGroupName,
-			})
-			return err
-		}
-		return nil
-	}
-}
+	const chunkSize = 20
 
-func testAccCheckParameterGroupDestroy(ctx context.Context) resource.TestCheckFunc {
-	return func(s *terraform.State) error {
-		conn := acctest.Provider.Meta().(*conns.AWSClient).RDSConn()
+	for _, count := range []int{21, 40, 61} {
+		count := count
 
-		for _, rs := range s.RootModule().Resources {
-			if rs.Type != "aws_db_parameter_group" {
-				continue
+		t.Run(fmt.Sprintf("%d parameters", count), func(t *testing.T) {
+			t.Parallel()
+
+			parameters := testDBParameterModifyChunkParameters(count)
+
+			var chunks [][]*rds.Parameter
+			for remainder := parameters; remainder != nil; {
+				var chunk []*rds.Parameter
+				chunk, remainder = tfrds.ResourceParameterModifyChunk(remainder, chunkSize)
+				chunks = append(chunks, chunk)
+
+				if len(chunks) > count {
+					t.Fatalf("chunking did not terminate after %d chunks", len(chunks))
+				}
 			}
 
-			// Try to find the Group
-			resp, err := conn.DescribeDBParameterGroupsWithContext(ctx, &rds.DescribeDBParameterGroupsInput{
-				DBParameterGroupName: aws.String(rs.Primary.ID),
-			})
+			if got, want := len(chunks), (count+chunkSize-1)/chunkSize; got != want {
+				t.Errorf("got %d chunks, expected %d", got, want)
+			}
 
-			if err == nil {
-				if len(resp.DBParameterGroups) != 0 &&
-					*resp.DBParameterGroups[0].DBParameterGroupName == rs.Primary.ID {
-					return fmt.Errorf("DB Parameter Group still exists")
+			var applied []*rds.Parameter
+			for i, chunk := range chunks {
+				if len(chunk) == 0 || len(chunk) > chunkSize {
+					t.Errorf("chunk %d has %d parameters, expected between 1 and %d", i, len(chunk), chunkSize)
 				}
+
+				applied = append(applied, chunk...)
 			}
 
-			// Verify the error
-			newerr, ok := err.(awserr.Error)
-			if !ok {
-				return err
+			// No parameter lost or duplicated.
+			seen := make(map[string]int)
+			for _, p := range applied {
+				seen[aws.StringValue(p.ParameterName)]++
 			}
-			if newerr.Code() != "DBParameterGroupNotFound" {
-				return err
+			for _, p := range parameters {
+				name := aws.StringValue(p.ParameterName)
+				if n := seen[name]; n != 1 {
+					t.Errorf("parameter %q applied %d times, expected 1", name, n)
+				}
+			}
+			if got, want := len(applied), len(parameters); got != want {
+				t.Errorf("got %d applied parameters, expected %d", got, want)
 			}
-		}
 
-		return nil
+			// Immediate charset parameters first, pending-reboot parameters last.
+			var seenOther, seenPendingReboot bool
+			for i, p := range applied {
+				name, pendingReboot := aws.StringValue(p.ParameterName), aws.StringValue(p.ApplyMethod) == "pending-reboot"
+
+				switch {
+				case pendingReboot:
+					seenPendingReboot = true
+				case strings.Contains(name, "character_set"):
+					if seenOther || seenPendingReboot {
+						t.Errorf("charset parameter %q applied at position %d, after other parameters", name, i)
+					}
+				default:
+					if seenPendingReboot {
+						t.Errorf("immediate parameter %q applied at position %d, after pending-reboot parameters", name, i)
+					}
+					seenOther = true
+				}
+			}
+		})
 	}
 }
 
-func testAccCheckParameterGroupAttributes(v *rds.DBParameterGroup, name string) resource.TestCheckFunc {
-	return func(s *terraform.State) error {
-		if *v.DBParameterGroupName != name {
-			return fmt.Errorf("Bad Parameter Group name, expected (%s), got (%s)", name, *v.DBParameterGroupName)
-		}
+func TestDBParameterModifyChunkWithPriorities(t *testing.T) {
+	t.Parallel()
 
-		if *v.DBParameterGroupFamily != "mysql5.6" {
-			return fmt.Errorf("bad family: %#v", v.DBParameterGroupFamily)
-		}
+	// MariaDB-style ordering: GTID settings before binary logging settings before charsets.
+	priorities := []string{"gtid_", "binlog_", "character_set"}
 
-		return nil
+	parameters := []*rds.Parameter{
+		{
+			ApplyMethod:    aws.String("immediate"),
+			ParameterName:  aws.String("character_set_server"),
+			ParameterValue: aws.String("utf8mb4"),
+		},
+		{
+			ApplyMethod:    aws.String("pending-reboot"),
+			ParameterName:  aws.String("gtid_strict_mode_reboot"),
+			ParameterValue: aws.String("1"),
+		},
+		{
+			ApplyMethod:    aws.String("immediate"),
+			ParameterName:  aws.String("binlog_format"),
+			ParameterValue: aws.String("ROW"),
+		},
+		{
+			ApplyMethod:    aws.String("immediate"),
+			ParameterName:  aws.String("max_connections"),
+			ParameterValue: aws.String("100"),
+		},
+		{
+			ApplyMethod:    aws.String("immediate"),
+			ParameterName:  aws.String("gtid_strict_mode"),
+			ParameterValue: aws.String("1"),
+		},
+		{
+			ApplyMethod:    aws.String("immediate"),
+			ParameterName:  aws.String("binlog_row_image"),
+			ParameterValue: aws.String("FULL"),
+		},
 	}
-}
 
-func testAccCheckParameterGroupExists(ctx context.Context, rName string, v *rds.DBParameterGroup) resource.TestCheckFunc {
-	return func(s *terraform.State) error {
-		rs, ok := s.RootModule().Resources[rName]
-		if !ok {
-			return fmt.Errorf("Not found: %s", rName)
-		}
+	cases := []struct {
+		Name      string
+		ChunkSize int
+		Expected  [][]string
+	}{
+		{
+			Name:      "Single chunk",
+			ChunkSize: 20,
+			Expected: [][]string{
+				{"gtid_strict_mode", "binlog_format", "binlog_row_image", "character_set_server", "max_connections", "gtid_strict_mode_reboot"},
+			},
+		},
+		{
+			Name:      "Multiple chunks",
+			ChunkSize: 2,
+			Expected: [][]string{
+				{"gtid_strict_mode", "binlog_format"},
+				{"binlog_row_image", "character_set_server"},
+				{"max_connections", "gtid_strict_mode_reboot"},
+			},
+		},
+	}
 
-		if rs.Primary.ID == "" {
-			return fmt.Errorf("No DB Parameter Group ID is set")
-		}
+	for _, tc := range cases {
+		tc := tc
 
-		conn := acctest.Provider.Meta().(*conns.AWSClient).RDSConn()
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
 
-		opts := rds.DescribeDBParameterGroupsInput{
-			DBParameterGroupName: aws.String(rs.Primary.ID),
-		}
+			var got [][]string
+			for remainder := parameters; remainder != nil; {
+				var chunk []*rds.Parameter
+				chunk, remainder = tfrds.ResourceParameterModifyChunkWithPriorities(remainder, tc.ChunkSize, priorities)
 
-		resp, err := conn.DescribeDBParameterGroupsWithContext(ctx, &opts)
+				var names []string
+				for _, p := range chunk {
+					names = append(names, aws.StringValue(p.ParameterName))
+				}
+				got = append(got, names)
 
-		if err != nil {
-			return err
-		}
+				if len(got) > len(parameters) {
+					t.Fatalf("chunking did not terminate after %d chunks", len(got))
+				}
+			}
 
-		if len(resp.DBParameterGroups) != 1 ||
-			*resp.DBParameterGroups[0].DBParameterGroupName != rs.Primary.ID {
-			return fmt.Errorf("DB Parameter Group not found")
-		}
+			if !reflect.DeepEqual(got, tc.Expected) {
+				t.Errorf("got %v, expected %v", got, tc.Expected)
+			}
+		})
+	}
+}
 
-		*v = *resp.DBParameterGroups[0]
+func TestDBParameterModifyChunk_pluginPriorities(t *testing.T) {
+	t.Parallel()
 
-		return nil
+	parameters := []*rds.Parameter{
+		{
+			ApplyMethod:    aws.String("immediate"),
+			ParameterName:  aws.String("max_connections"),
+			ParameterValue: aws.String("100"),
+		},
+		{
+			ApplyMethod:    aws.String("immediate"),
+			ParameterName:  aws.String("character_set_server"),
+			ParameterValue: aws.String("utf8mb4"),
+		},
+		{
+			ApplyMethod:    aws.String("immediate"),
+			ParameterName:  aws.String("sql_mode"),
+			ParameterValue: aws.String("TRADITIONAL"),
+		},
+		{
+			ApplyMethod:    aws.String("immediate"),
+			ParameterName:  aws.String("default_authentication_plugin"),
+			ParameterValue: aws.String("caching_sha2_password"),
+		},
 	}
-}
 
-func testAccCheckParameterNotUserDefined(ctx context.Context, rName, paramName string) resource.TestCheckFunc {
-	return func(s *terraform.State) error {
-		rs, ok := s.RootModule().Resources[rName]
-		if !ok {
-			return fmt.Errorf("Not found: %s", rName)
-		}
+	cases := []struct {
+		Family   string
+		Engine   string
+		Expected []string
+	}{
+		{
+			Family:   "mysql8.0",
+			Expected: []string{"default_authentication_plugin", "character_set_server"},
+		},
+		{
+			Family:   "mariadb10.6",
+			Expected: []string{"default_authentication_plugin", "character_set_server"},
+		},
+		{
+			Family:   "aurora-mysql8.0",
+			Expected: []string{"default_authentication_plugin", "character_set_server"},
+		},
+		{
+			Family:   "postgres14",
+			Expected: []string{"character_set_server", "max_connections"},
+		},
+		// The legacy Aurora MySQL family doesn't name its engine.
+		{
+			Family:   "aurora5.6",
+			Expected: []string{"character_set_server", "max_connections"},
+		},
+		{
+			Family:   "aurora5.6",
+			Engine:   "aurora",
+			Expected: []string{"default_authentication_plugin", "character_set_server"},
+		},
+		{
+			Family:   "postgres14",
+			Engine:   "mysql",
+			Expected: []string{"default_authentication_plugin", "character_set_server"},
+		},
+	}
 
-		if rs.Primary.ID == "" {
-			return fmt.Errorf("No DB Parameter Group ID is set")
-		}
+	for _, tc := range cases {
+		tc := tc
 
-		conn := acctest.Provider.Meta().(*conns.AWSClient).RDSConn()
+		t.Run(fmt.Sprintf("%s %s", tc.Family, tc.Engine), func(t *testing.T) {
+			t.Parallel()
 
-		opts := rds.DescribeDBParametersInput{
-			DBParameterGroupName: aws.String(rs.Primary.ID),
-			Source:               aws.String("user"),
-		}
+			chunk, _ := tfrds.ResourceParameterModifyChunkWithPriorities(parameters, 2, tfrds.ParameterModifyPrioritiesForFamily(tc.Family, tc.Engine))
 
-		userDefined := false
-		err := conn.DescribeDBParametersPagesWithContext(ctx, &opts, func(page *rds.DescribeDBParametersOutput, lastPage bool) bool {
-			for _, param := range page.Parameters {
-				if *param.ParameterName == paramName {
-					userDefined = true
-					return false
-				}
+			var got []string
+			for _, p := range chunk {
+				got = append(got, aws.StringValue(p.ParameterName))
+			}
+
+			if !reflect.DeepEqual(got, tc.Expected) {
+				t.Errorf("got first chunk %v, expected %v", got, tc.Expected)
 			}
-			return true
 		})
+	}
+}
 
-		if userDefined {
-			return fmt.Errorf("DB Parameter is user defined")
-		}
+func TestReservedParameters(t *testing.T) {
+	t.Parallel()
 
-		return err
+	names := []string{"max_connections", "AWS_DEFAULT_S3_ROLE", "rds.extensions", "character_set_server"}
+
+	cases := []struct {
+		Family   string
+		Engine   string
+		Expected []string
+	}{
+		{
+			Family:   "aurora-mysql8.0",
+			Expected: []string{"AWS_DEFAULT_S3_ROLE"},
+		},
+		{
+			Family:   "postgres14",
+			Expected: []string{"rds.extensions"},
+		},
+		{
+			Family: "mysql8.0",
+		},
+		{
+			Family: "aurora5.6",
+		},
+		{
+			Family:   "aurora5.6",
+			Engine:   "aurora",
+			Expected: []string{"AWS_DEFAULT_S3_ROLE"},
+		},
 	}
-}
 
-func testAccParameterGroupConfig_basic(rName string) string {
-	return fmt.Sprintf(`
-resource "aws_db_parameter_group" "test" {
-  name   = %[1]q
-  family = "mysql5.6"
+	for _, tc := range cases {
+		if got := tfrds.ReservedParameters(tc.Family, tc.Engine, names); !reflect.DeepEqual(got, tc.Expected) {
+			t.Errorf("%s %s: got reserved parameters %v, expected %v", tc.Family, tc.Engine, got, tc.Expected)
+		}
+	}
+}
 
-  parameter {
-    name  = "character_set_server"
-    value = "utf8"
-  }
+func TestCheckParameterGroupImmediateApply(t *testing.T) {
+	ctx := acctest.Context(t)
+	t.Parallel()
 
-  parameter {
-    name  = "character_set_client"
-    value = "utf8"
-  }
+	old := []*rds.Parameter{
+		{ParameterName: aws.String("character_set_server"), ParameterValue: aws.String("utf8"), ApplyMethod: aws.String(rds.ApplyMethodImmediate)},
+	}
 
-  parameter {
-    name  = "character_set_results"
-    value = "utf8"
-  }
+	testCases := []struct {
+		Name          string
+		New           []*rds.Parameter
+		Instances     []*rds.DBInstance
+		ExpectedCalls int
+		ExpectedError string
+	}{
+		{
+			Name: "immediate change to attached instance",
+			New: []*rds.Parameter{
+				{ParameterName: aws.String("character_set_server"), ParameterValue: aws.String("utf8mb4"), ApplyMethod: aws.String(rds.ApplyMethodImmediate)},
+				{ParameterName: aws.String("max_connections"), ParameterValue: aws.String("100"), ApplyMethod: aws.String(rds.ApplyMethodImmediate)},
+			},
+			Instances: []*rds.DBInstance{
+				{
+					DBInstanceIdentifier: aws.String("attached"),
+					DBInstanceStatus:     aws.String("available"),
+					DBParameterGroups:    []*rds.DBParameterGroupStatus{{DBParameterGroupName: aws.String("test")}},
+				},
+				{
+					DBInstanceIdentifier: aws.String("stopped"),
+					DBInstanceStatus:     aws.String("stopped"),
+					DBParameterGroups:    []*rds.DBParameterGroupStatus{{DBParameterGroupName: aws.String("test")}},
+				},
+				{
+					DBInstanceIdentifier: aws.String("other"),
+					DBInstanceStatus:     aws.String("available"),
+					DBParameterGroups:    []*rds.DBParameterGroupStatus{{DBParameterGroupName: aws.String("other")}},
+				},
+			},
+			ExpectedCalls: 1,
+			ExpectedError: "DB Parameter Group (test) parameters character_set_server, max_connections use the immediate apply method and would affect the live databases of DB instances attached right away",
+		},
+		{
+			Name: "immediate change to unattached group",
+			New: []*rds.Parameter{
+				{ParameterName: aws.String("character_set_server"), ParameterValue: aws.String("utf8mb4"), ApplyMethod: aws.String(rds.ApplyMethodImmediate)},
+			},
+			Instances: []*rds.DBInstance{
+				{
+					DBInstanceIdentifier: aws.String("other"),
+					DBInstanceStatus:     aws.String("available"),
+					DBParameterGroups:    []*rds.DBParameterGroupStatus{{DBParameterGroupName: aws.String("other")}},
+				},
+			},
+			ExpectedCalls: 1,
+		},
+		{
+			Name: "pending-reboot change",
+			New: []*rds.Parameter{
+				{ParameterName: aws.String("character_set_server"), ParameterValue: aws.String("utf8mb4"), ApplyMethod: aws.String(rds.ApplyMethodPendingReboot)},
+			},
+		},
+		{
+			Name: "apply method change only",
+			New: []*rds.Parameter{
+				{ParameterName: aws.String("character_set_server"), ParameterValue: aws.String("utf8"), ApplyMethod: aws.String(rds.ApplyMethodImmediate)},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.Name, func(t *testing.T) {
+			t.Parallel()
+
+			var calls int
+			conn := testParameterGroupConn(t, func(r *request.Request) {
+				calls++
+				r.Data.(*rds.DescribeDBInstancesOutput).DBInstances = testCase.Instances
+			})
+
+			err := tfrds.CheckParameterGroupImmediateApply(ctx, conn, "test", old, testCase.New)
+
+			var got string
+			if err != nil {
+				got = err.Error()
+			}
+
+			if calls != testCase.ExpectedCalls {
+				t.Errorf("got %d DescribeDBInstances calls, expected %d", calls, testCase.ExpectedCalls)
+			}
+
+			if got != testCase.ExpectedError {
+				t.Errorf("got error %q, expected %q", got, testCase.ExpectedError)
+			}
+		})
+	}
+}
+
+func TestDBParameterModifyChunkWithConstraints(t *testing.T) {
+	t.Parallel()
+
+	parameters := []*rds.Parameter{
+		{
+			ApplyMethod:    aws.String("immediate"),
+			ParameterName:  aws.String("max_connections"),
+			ParameterValue: aws.String("100"),
+		},
+		{
+			ApplyMethod:    aws.String("immediate"),
+			ParameterName:  aws.String("character_set_server"),
+			ParameterValue: aws.String("utf8mb4"),
+		},
+		{
+			ApplyMethod:    aws.String("immediate"),
+			ParameterName:  aws.String("innodb_lock_wait_timeout"),
+			ParameterValue: aws.String("60"),
+		},
+		{
+			ApplyMethod:    aws.String("immediate"),
+			ParameterName:  aws.String("collation_server"),
+			ParameterValue: aws.String("utf8mb4_unicode_ci"),
+		},
+		{
+			ApplyMethod:    aws.String("pending-reboot"),
+			ParameterName:  aws.String("performance_schema"),
+			ParameterValue: aws.String("1"),
+		},
+	}
+
+	cases := []struct {
+		Name        string
+		ChunkSize   int
+		Constraints [][]string
+		Expected    [][]string
+		ExpectError *regexp.Regexp
+	}{
+		{
+			Name:      "No constraints",
+			ChunkSize: 2,
+			Expected: [][]string{
+				{"character_set_server", "max_connections"},
+				{"innodb_lock_wait_timeout", "collation_server"},
+				{"performance_schema"},
+			},
+		},
+		{
+			Name:        "Dependent collation in the charset chunk",
+			ChunkSize:   2,
+			Constraints: [][]string{{"character_set_server", "collation_server"}},
+			Expected: [][]string{
+				{"character_set_server", "collation_server"},
+				{"max_connections", "innodb_lock_wait_timeout"},
+				{"performance_schema"},
+			},
+		},
+		{
+			Name:        "Constraint doesn't fit in the rest of a chunk",
+			ChunkSize:   3,
+			Constraints: [][]string{{"innodb_lock_wait_timeout", "performance_schema"}},
+			Expected: [][]string{
+				{"character_set_server", "max_connections"},
+				{"innodb_lock_wait_timeout", "performance_schema", "collation_server"},
+			},
+		},
+		{
+			Name:        "Names are case-insensitive",
+			ChunkSize:   2,
+			Constraints: [][]string{{"Character_Set_Server", "COLLATION_SERVER"}},
+			Expected: [][]string{
+				{"character_set_server", "collation_server"},
+				{"max_connections", "innodb_lock_wait_timeout"},
+				{"performance_schema"},
+			},
+		},
+		{
+			Name:        "Constraint names not modified",
+			ChunkSize:   2,
+			Constraints: [][]string{{"binlog_format", "binlog_row_image"}},
+			Expected: [][]string{
+				{"character_set_server", "max_connections"},
+				{"innodb_lock_wait_timeout", "collation_server"},
+				{"performance_schema"},
+			},
+		},
+		{
+			Name:        "Constraint larger than a chunk",
+			ChunkSize:   2,
+			Constraints: [][]string{{"character_set_server", "collation_server", "max_connections"}},
+			ExpectError: regexp.MustCompile(`parameter_group_constraint \(character_set_server, collation_server, max_connections\) has 3 parameters to modify`),
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+
+			var got [][]string
+			for remainder := parameters; remainder != nil; {
+				var chunk []*rds.Parameter
+				var err error
+				chunk, remainder, err = tfrds.ResourceParameterModifyChunkWithConstraints(remainder, tc.ChunkSize, []string{"character_set"}, tc.Constraints)
+
+				if tc.ExpectError != nil {
+					if err == nil {
+						t.Fatal("expected error, got none")
+					}
+
+					if !tc.ExpectError.MatchString(err.Error()) {
+						t.Errorf("got error %q, expected to match %q", err, tc.ExpectError)
+					}
+
+					return
+				}
+
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+
+				var names []string
+				for _, p := range chunk {
+					names = append(names, aws.StringValue(p.ParameterName))
+				}
+				got = append(got, names)
+
+				if len(got) > len(parameters) {
+					t.Fatalf("chunking did not terminate after %d chunks", len(got))
+				}
+			}
+
+			if !reflect.DeepEqual(got, tc.Expected) {
+				t.Errorf("got %v, expected %v", got, tc.Expected)
+			}
+		})
+	}
+}
+
+// testDBParameterModifyChunkParameters returns a deterministic mix of charset, collation,
+// pending-reboot and ordinary parameters.
+func testDBParameterModifyChunkParameters(count int) []*rds.Parameter {
+	parameters := make([]*rds.Parameter, 0, count)
+
+	for i := 0; i < count; i++ {
+		var name, applyMethod string
+
+		switch i % 5 {
+		case 0:
+			name, applyMethod = fmt.Sprintf("character_set_%d", i), "immediate"
+		case 1:
+			name, applyMethod = fmt.Sprintf("collation_%d", i), "immediate"
+		case 2:
+			name, applyMethod = fmt.Sprintf("innodb_%d", i), "pending-reboot"
+		case 3:
+			name, applyMethod = fmt.Sprintf("character_set_reboot_%d", i), "pending-reboot"
+		default:
+			name, applyMethod = fmt.Sprintf("ordinary_%d", i), "immediate"
+		}
+
+		// Reverse the natural order so that prioritized parameters are spread across the input.
+		parameters = append([]*rds.Parameter{{
+			ApplyMethod:    aws.String(applyMethod),
+			ParameterName:  aws.String(name),
+			ParameterValue: aws.String(fmt.Sprint(i)),
+		}}, parameters...)
+	}
+
+	return parameters
+}
+
+func TestEstimatedParameterModifyCalls(t *testing.T) {
+	t.Parallel()
+
+	parameters := func(n int, value string) []interface{} {
+		var tfList []interface{}
+		for i := 0; i < n; i++ {
+			tfList = append(tfList, map[string]interface{}{
+				"apply_method": "immediate",
+				"name":         fmt.Sprintf("parameter%02d", i),
+				"value":        value,
+			})
+		}
+		return tfList
+	}
+	set := func(tfList []interface{}) *schema.Set {
+		return schema.NewSet(tfrds.ResourceParameterGroup().Schema["parameter"].Set, tfList)
+	}
+
+	cases := []struct {
+		Name        string
+		Old         []interface{}
+		New         []interface{}
+		OldOrdered  []interface{}
+		NewOrdered  []interface{}
+		Constraints [][]string
+		Expected    int
+	}{
+		{
+			Name: "none",
+		},
+		{
+			Name:     "one chunk",
+			New:      parameters(5, "1"),
+			Expected: 1,
+		},
+		{
+			Name:     "full chunk",
+			New:      parameters(20, "1"),
+			Expected: 1,
+		},
+		{
+			Name:     "two chunks",
+			New:      parameters(21, "1"),
+			Expected: 2,
+		},
+		{
+			Name:     "three chunks",
+			New:      parameters(41, "1"),
+			Expected: 3,
+		},
+		{
+			Name:     "unchanged",
+			Old:      parameters(41, "1"),
+			New:      parameters(41, "1"),
+			Expected: 0,
+		},
+		{
+			Name:     "changed values",
+			Old:      parameters(30, "1"),
+			New:      append(parameters(30, "1")[:5], parameters(30, "2")[5:]...),
+			Expected: 2,
+		},
+		{
+			Name:     "removed only",
+			Old:      parameters(30, "1"),
+			Expected: 0,
+		},
+		{
+			Name:       "ordered",
+			NewOrdered: parameters(25, "1"),
+			Expected:   2,
+		},
+		{
+			Name:        "constraint",
+			New:         parameters(20, "1"),
+			Constraints: [][]string{{"parameter00", "parameter19"}},
+			Expected:    1,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := tfrds.EstimatedParameterModifyCalls(set(tc.Old), set(tc.New), tc.OldOrdered, tc.NewOrdered, "mysql8.0", "", tc.Constraints)
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if got != tc.Expected {
+				t.Errorf("got %d ModifyDBParameterGroup calls, expected %d", got, tc.Expected)
+			}
+		})
+	}
+}
+
+func TestCheckParameterCount(t *testing.T) {
+	t.Parallel()
+
+	if err := tfrds.CheckParameterCount(tfrds.ParameterCountLimit); err != nil {
+		t.Errorf("got unexpected error at the limit: %s", err)
+	}
+
+	err := tfrds.CheckParameterCount(tfrds.ParameterCountLimit + 1)
+
+	if err == nil {
+		t.Fatal("expected error above the limit, got none")
+	}
+
+	if expected := regexp.MustCompile(`exceeds the limit of \d+ parameters`); !expected.MatchString(err.Error()) {
+		t.Errorf("expected error %s, got: %s", expected, err)
+	}
+}
+
+func TestParameterModifyError(t *testing.T) {
+	t.Parallel()
+
+	immutable := awserr.New("InvalidParameterValue", "The parameter lower_case_table_names cannot be modified.", nil)
+	other := awserr.New("InvalidParameterValue", "Could not find parameter with name: not_a_parameter", nil)
+
+	testCases := []struct {
+		Name     string
+		Err      error
+		Expected *regexp.Regexp
+	}{
+		{
+			Name:     "immutable parameter",
+			Err:      immutable,
+			Expected: regexp.MustCompile(`^parameter "lower_case_table_names" cannot be changed in an existing DB Parameter Group of family mysql8.0; recreate the DB Parameter Group`),
+		},
+		{
+			Name:     "other error",
+			Err:      other,
+			Expected: regexp.MustCompile(`^InvalidParameterValue: Could not find parameter`),
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.Name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tfrds.ParameterModifyError(testCase.Err, "mysql8.0")
+
+			if !errors.Is(err, testCase.Err) {
+				t.Errorf("expected error to wrap %s, got: %s", testCase.Err, err)
+			}
+
+			if !testCase.Expected.MatchString(err.Error()) {
+				t.Errorf("expected error %s, got: %s", testCase.Expected, err)
+			}
+		})
+	}
+
+	if err := tfrds.ParameterModifyError(nil, "mysql8.0"); err != nil {
+		t.Errorf("got unexpected error: %s", err)
+	}
+}
+
+func TestDBParameterResetChunks(t *testing.T) {
+	t.Parallel()
+
+	const chunkSize = 20
+
+	applyTypes := map[string]string{
+		"innodb_buffer_pool_size": "static",
+		"max_connections":         "dynamic",
+	}
+
+	testCases := []struct {
+		Name           string
+		Parameters     []*rds.Parameter
+		ExpectedChunks [][]*rds.Parameter
+	}{
+		{
+			Name: "empty",
+		},
+		{
+			Name: "static parameter uses pending-reboot",
+			Parameters: []*rds.Parameter{
+				{
+					ApplyMethod:    aws.String("immediate"),
+					ParameterName:  aws.String("innodb_buffer_pool_size"),
+					ParameterValue: aws.String("134217728"),
+				},
+				{
+					ApplyMethod:    aws.String("immediate"),
+					ParameterName:  aws.String("max_connections"),
+					ParameterValue: aws.String("100"),
+				},
+				{
+					ApplyMethod:    aws.String("pending-reboot"),
+					ParameterName:  aws.String("character_set_server"),
+					ParameterValue: aws.String("utf8"),
+				},
+			},
+			ExpectedChunks: [][]*rds.Parameter{
+				{
+					{
+						ApplyMethod:    aws.String("pending-reboot"),
+						ParameterName:  aws.String("character_set_server"),
+						ParameterValue: aws.String("utf8"),
+					},
+					{
+						ApplyMethod:    aws.String("pending-reboot"),
+						ParameterName:  aws.String("innodb_buffer_pool_size"),
+						ParameterValue: aws.String("134217728"),
+					},
+					{
+						ApplyMethod:    aws.String("immediate"),
+						ParameterName:  aws.String("max_connections"),
+						ParameterValue: aws.String("100"),
+					},
+				},
+			},
+		},
+		{
+			Name: "unknown apply type keeps apply method",
+			Parameters: []*rds.Parameter{
+				{
+					ApplyMethod:    aws.String("immediate"),
+					ParameterName:  aws.String("unknown"),
+					ParameterValue: aws.String("1"),
+				},
+			},
+			ExpectedChunks: [][]*rds.Parameter{
+				{
+					{
+						ApplyMethod:    aws.String("immediate"),
+						ParameterName:  aws.String("unknown"),
+						ParameterValue: aws.String("1"),
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+
+			chunks := tfrds.ResourceParameterResetChunks(tc.Parameters, applyTypes, chunkSize)
+
+			if !reflect.DeepEqual(chunks, tc.ExpectedChunks) {
+				t.Errorf("got chunks %s, expected %s", chunks, tc.ExpectedChunks)
+			}
+		})
+	}
+}
+
+func TestDBParameterResetChunks_chunking(t *testing.T) {
+	t.Parallel()
+
+	const chunkSize = 20
+
+	parameters := testDBParameterModifyChunkParameters(41)
+	chunks := tfrds.ResourceParameterResetChunks(parameters, nil, chunkSize)
+
+	if got, want := len(chunks), 3; got != want {
+		t.Fatalf("got %d chunks, expected %d", got, want)
+	}
+
+	var reset []*rds.Parameter
+	for i, chunk := range chunks {
+		if len(chunk) == 0 || len(chunk) > chunkSize {
+			t.Errorf("chunk %d has %d parameters, expected between 1 and %d", i, len(chunk), chunkSize)
+		}
+
+		reset = append(reset, chunk...)
+	}
+
+	if got, want := len(reset), len(parameters); got != want {
+		t.Errorf("got %d reset parameters, expected %d", got, want)
+	}
+
+	for i := 1; i < len(reset); i++ {
+		if prev, name := aws.StringValue(reset[i-1].ParameterName), aws.StringValue(reset[i].ParameterName); prev >= name {
+			t.Errorf("parameter %q reset after %q, expected name order", name, prev)
+		}
+	}
+
+	// The input is not modified.
+	if got, want := aws.StringValue(parameters[0].ParameterName), "character_set_40"; got != want {
+		t.Errorf("got first input parameter %q, expected %q", got, want)
+	}
+}
+
+func TestCompatibleParameters(t *testing.T) {
+	t.Parallel()
+
+	// query_cache_size was removed in MySQL 8.0.
+	families := map[string]map[string]*rds.Parameter{
+		"mysql5.7": testParameterGroupEngineDefaults("character_set_server", "max_connections", "query_cache_size"),
+		"mysql8.0": testParameterGroupEngineDefaults("character_set_server", "max_connections"),
+	}
+
+	parameters := []*rds.Parameter{
+		{ParameterName: aws.String("character_set_server"), ParameterValue: aws.String("utf8")},
+		{ParameterName: aws.String("query_cache_size"), ParameterValue: aws.String("0")},
+		{ParameterName: aws.String("Max_Connections"), ParameterValue: aws.String("100")},
+	}
+
+	testCases := []struct {
+		Family             string
+		ExpectedCompatible []string
+		ExpectedDropped    []string
+	}{
+		{
+			Family:             "mysql5.7",
+			ExpectedCompatible: []string{"character_set_server", "query_cache_size", "Max_Connections"},
+		},
+		{
+			Family:             "mysql8.0",
+			ExpectedCompatible: []string{"character_set_server", "Max_Connections"},
+			ExpectedDropped:    []string{"query_cache_size"},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.Family, func(t *testing.T) {
+			t.Parallel()
+
+			compatible, dropped := tfrds.CompatibleParameters(parameters, families[tc.Family])
+
+			var got []string
+			for _, v := range compatible {
+				got = append(got, aws.StringValue(v.ParameterName))
+			}
+
+			if !reflect.DeepEqual(got, tc.ExpectedCompatible) {
+				t.Errorf("got compatible %v, expected %v", got, tc.ExpectedCompatible)
+			}
+
+			if !reflect.DeepEqual(dropped, tc.ExpectedDropped) {
+				t.Errorf("got dropped %v, expected %v", dropped, tc.ExpectedDropped)
+			}
+		})
+	}
+}
+
+func testParameterGroupConn(t *testing.T, send func(r *request.Request)) *rds.RDS {
+	t.Helper()
+
+	sess, err := session.NewSession(nil)
+	if err != nil {
+		t.Fatalf("creating session: %s", err)
+	}
+
+	conn := rds.New(sess)
+	conn.Handlers.Clear()
+	conn.Handlers.Send.PushBack(send)
+
+	return conn
+}
+
+// testParameterGroupClient returns an AWS client whose RDS requests are answered by send instead of AWS.
+func testParameterGroupClient(ctx context.Context, t *testing.T, send func(r *request.Request)) *conns.AWSClient {
+	t.Helper()
+
+	config := &conns.Config{
+		AccessKey:               "mock",
+		Region:                  "us-west-2",
+		SecretKey:               "mock",
+		SkipCredsValidation:     true,
+		SkipRegionValidation:    true,
+		SkipRequestingAccountId: true,
+	}
+
+	client, diags := config.ConfigureProvider(ctx, &conns.AWSClient{})
+	if diags.HasError() {
+		t.Fatalf("configuring AWS client: %v", diags)
+	}
+
+	client.RDSConn().Handlers.Clear()
+	client.RDSConn().Handlers.Send.PushBack(send)
+
+	return client
+}
+
+// testParameterGroupEngineDefaults returns engine default parameters with the specified names, keyed by name.
+func testParameterGroupEngineDefaults(names ...string) map[string]*rds.Parameter {
+	defaults := make(map[string]*rds.Parameter, len(names))
+	for _, v := range names {
+		defaults[v] = &rds.Parameter{ParameterName: aws.String(v)}
+	}
+
+	return defaults
+}
+
+func TestFindParameterGroupParameters(t *testing.T) {
+	ctx := acctest.Context(t)
+	t.Parallel()
+
+	testCases := []struct {
+		Name          string
+		NewResource   bool
+		ExpectedCalls int
+	}{
+		{
+			Name:          "new resource",
+			NewResource:   true,
+			ExpectedCalls: 0,
+		},
+		{
+			Name:          "refresh",
+			ExpectedCalls: 1,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.Name, func(t *testing.T) {
+			t.Parallel()
+
+			var calls int
+			conn := testParameterGroupConn(t, func(r *request.Request) {
+				input := r.Params.(*rds.DescribeDBParametersInput)
+				data := r.Data.(*rds.DescribeDBParametersOutput)
+				calls++
+
+				if got, want := aws.StringValue(input.Source), "user"; got != want {
+					t.Errorf("got source %q, expected %q", got, want)
+				}
+
+				data.Parameters = []*rds.Parameter{
+					{
+						ParameterName:  aws.String("character_set_server"),
+						ParameterValue: aws.String("utf8"),
+						Source:         aws.String("user"),
+					},
+				}
+			})
+
+			d := tfrds.ResourceParameterGroup().TestResourceData()
+			d.SetId("test")
+			if testCase.NewResource {
+				d.MarkNewResource()
+			}
+
+			parameters, err := tfrds.FindParameterGroupParameters(ctx, conn, d, false)
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if calls != testCase.ExpectedCalls {
+				t.Errorf("got %d DescribeDBParameters calls, expected %d", calls, testCase.ExpectedCalls)
+			}
+
+			if got, want := len(parameters), testCase.ExpectedCalls; got != want {
+				t.Errorf("got %d parameters, expected %d", got, want)
+			}
+		})
+	}
+}
+
+func TestFindParameterGroupParameters_throttling(t *testing.T) {
+	ctx := acctest.Context(t)
+	t.Parallel()
+
+	var calls int
+	conn := testParameterGroupConn(t, func(r *request.Request) {
+		input := r.Params.(*rds.DescribeDBParametersInput)
+		data := r.Data.(*rds.DescribeDBParametersOutput)
+		calls++
+
+		switch aws.StringValue(input.Marker) {
+		case "":
+			data.Parameters = []*rds.Parameter{{ParameterName: aws.String("character_set_server"), Source: aws.String("user")}}
+			data.Marker = aws.String("page-2")
+		case "page-2":
+			// Throttle the second page once.
+			if calls == 2 {
+				r.Error = awserr.New("Throttling", "Rate exceeded", nil)
+				return
+			}
+
+			data.Parameters = []*rds.Parameter{{ParameterName: aws.String("max_connections"), Source: aws.String("user")}}
+		default:
+			t.Errorf("unexpected marker %q", aws.StringValue(input.Marker))
+		}
+	})
+
+	d := tfrds.ResourceParameterGroup().TestResourceData()
+	d.SetId("test")
+
+	parameters, err := tfrds.FindParameterGroupParameters(ctx, conn, d, false)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// The first page isn't requested again.
+	if calls != 3 {
+		t.Errorf("got %d DescribeDBParameters calls, expected 3", calls)
+	}
+
+	var names []string
+	for _, p := range parameters {
+		names = append(names, aws.StringValue(p.ParameterName))
+	}
+	if want := []string{"character_set_server", "max_connections"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("got parameters %v, expected %v", names, want)
+	}
+}
+
+func TestFindParameterGroupParameters_pruneDefaultValueParameters(t *testing.T) {
+	ctx := acctest.Context(t)
+	t.Parallel()
+
+	testCases := []struct {
+		Name     string
+		Prune    bool
+		Expected []string
+	}{
+		{
+			Name:     "keep",
+			Expected: []string{"character_set_server", "max_connections", "time_zone"},
+		},
+		{
+			Name:     "prune",
+			Prune:    true,
+			Expected: []string{"max_connections", "time_zone"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.Name, func(t *testing.T) {
+			t.Parallel()
+
+			conn := testParameterGroupConn(t, func(r *request.Request) {
+				switch r.Params.(type) {
+				case *rds.DescribeDBParametersInput:
+					data := r.Data.(*rds.DescribeDBParametersOutput)
+					data.Parameters = []*rds.Parameter{
+						// Configured, with the engine default value.
+						{ParameterName: aws.String("character_set_server"), ParameterValue: aws.String("latin1"), Source: aws.String("engine-default")},
+						// Configured, changed from the engine default value.
+						{ParameterName: aws.String("max_connections"), ParameterValue: aws.String("100"), Source: aws.String("user")},
+						// Not configured, with the engine default value.
+						{ParameterName: aws.String("time_zone"), ParameterValue: aws.String("UTC"), Source: aws.String("user")},
+					}
+				case *rds.DescribeEngineDefaultParametersInput:
+					if !testCase.Prune {
+						t.Error("unexpected DescribeEngineDefaultParameters call")
+					}
+
+					data := r.Data.(*rds.DescribeEngineDefaultParametersOutput)
+					data.EngineDefaults = &rds.EngineDefaults{
+						Parameters: []*rds.Parameter{
+							{ParameterName: aws.String("character_set_server"), ParameterValue: aws.String("latin1")},
+							{ParameterName: aws.String("max_connections")},
+							{ParameterName: aws.String("time_zone"), ParameterValue: aws.String("UTC")},
+						},
+					}
+				}
+			})
+
+			d := schema.TestResourceDataRaw(t, tfrds.ResourceParameterGroup().Schema, map[string]interface{}{
+				"family": "mysql8.0",
+				"parameter": []interface{}{
+					map[string]interface{}{
+						"name":  "character_set_server",
+						"value": "latin1",
+					},
+					map[string]interface{}{
+						"name":  "max_connections",
+						"value": "100",
+					},
+				},
+				"prune_default_value_parameters": testCase.Prune,
+			})
+			d.SetId("test")
+
+			parameters, err := tfrds.FindParameterGroupParameters(ctx, conn, d, false)
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			var names []string
+			for _, p := range parameters {
+				names = append(names, aws.StringValue(p.ParameterName))
+			}
+			if !reflect.DeepEqual(names, testCase.Expected) {
+				t.Errorf("got parameters %v, expected %v", names, testCase.Expected)
+			}
+		})
+	}
+}
+
+func TestFlattenParameterGroupAllParameters(t *testing.T) {
+	t.Parallel()
+
+	apiObjects := []*rds.Parameter{
+		{ParameterName: aws.String("max_connections"), ParameterValue: aws.String("100"), Source: aws.String("user")},
+		{ParameterName: aws.String("character_set_server"), ParameterValue: aws.String("utf8"), Source: aws.String("system")},
+		nil,
+		{ParameterName: aws.String("autocommit"), Source: aws.String("engine-default")},
+	}
+
+	expected := []interface{}{
+		map[string]interface{}{"name": "autocommit", "source": "engine-default", "value": ""},
+		map[string]interface{}{"name": "character_set_server", "source": "system", "value": "utf8"},
+		map[string]interface{}{"name": "max_connections", "source": "user", "value": "100"},
+	}
+
+	if got := tfrds.FlattenParameterGroupAllParameters(apiObjects); !reflect.DeepEqual(got, expected) {
+		t.Errorf("got %v, expected %v", got, expected)
+	}
+}
+
+func TestParameterGroupImportID(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		ID                     string
+		ExpectedName           string
+		ExpectedParameterNames []string
+		ExpectedAllParameters  bool
+		ExpectError            bool
+	}{
+		{
+			ID:           "test",
+			ExpectedName: "test",
+		},
+		{
+			ID:                    "test/all_parameters",
+			ExpectedName:          "test",
+			ExpectedAllParameters: true,
+		},
+		{
+			ID:                     "test:character_set_server/all_parameters",
+			ExpectedName:           "test",
+			ExpectedParameterNames: []string{"character_set_server"},
+			ExpectedAllParameters:  true,
+		},
+		{
+			ID:          "/all_parameters",
+			ExpectError: true,
+		},
+		{
+			ID:                     "test:character_set_server",
+			ExpectedName:           "test",
+			ExpectedParameterNames: []string{"character_set_server"},
+		},
+		{
+			ID:                     "test:Character_Set_Server, max_connections",
+			ExpectedName:           "test",
+			ExpectedParameterNames: []string{"character_set_server", "max_connections"},
+		},
+		{
+			ID:          "test:",
+			ExpectError: true,
+		},
+		{
+			ID:          "test:character_set_server,",
+			ExpectError: true,
+		},
+		{
+			ID:          ":character_set_server",
+			ExpectError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.ID, func(t *testing.T) {
+			t.Parallel()
+
+			name, parameterNames, allParameters, err := tfrds.ParameterGroupImportID(testCase.ID)
+
+			if testCase.ExpectError {
+				if err == nil {
+					t.Fatal("expected error, got none")
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if name != testCase.ExpectedName {
+				t.Errorf("got name %q, expected %q", name, testCase.ExpectedName)
+			}
+
+			if !reflect.DeepEqual(parameterNames, testCase.ExpectedParameterNames) {
+				t.Errorf("got parameter names %v, expected %v", parameterNames, testCase.ExpectedParameterNames)
+			}
+
+			if allParameters != testCase.ExpectedAllParameters {
+				t.Errorf("got all parameters %t, expected %t", allParameters, testCase.ExpectedAllParameters)
+			}
+		})
+	}
+}
+
+func TestParameterGroupImportRead(t *testing.T) {
+	ctx := acctest.Context(t)
+	t.Parallel()
+
+	// The parameter name list of a scoped import only restricts the read following the import, not later refreshes.
+	testCases := []struct {
+		ID              string
+		Expected        []string
+		ExpectedRefresh []string
+	}{
+		{
+			ID:              "test",
+			Expected:        []string{"character_set_server", "max_connections"},
+			ExpectedRefresh: []string{"character_set_server", "max_connections"},
+		},
+		{
+			ID:              "test:max_connections,time_zone",
+			Expected:        []string{"max_connections", "time_zone"},
+			ExpectedRefresh: []string{"character_set_server", "max_connections", "time_zone"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.ID, func(t *testing.T) {
+			t.Parallel()
+
+			client := testParameterGroupClient(ctx, t, func(r *request.Request) {
+				switch data := r.Data.(type) {
+				case *rds.DescribeDBParameterGroupsOutput:
+					data.DBParameterGroups = []*rds.DBParameterGroup{{
+						DBParameterGroupArn:    aws.String("arn:aws:rds:us-west-2:123456789012:pg:test"), //lintignore:AWSAT003,AWSAT005
+						DBParameterGroupFamily: aws.String("mysql8.0"),
+						DBParameterGroupName:   aws.String("test"),
+					}}
+				case *rds.DescribeDBParametersOutput:
+					input := r.Params.(*rds.DescribeDBParametersInput)
+
+					if got, want := aws.StringValue(input.DBParameterGroupName), "test"; got != want {
+						t.Errorf("got DB Parameter Group name %q, expected %q", got, want)
+					}
+
+					data.Parameters = []*rds.Parameter{
+						{ParameterName: aws.String("character_set_server"), ParameterValue: aws.String("utf8"), Source: aws.String("user")},
+						{ParameterName: aws.String("max_connections"), ParameterValue: aws.String("100"), Source: aws.String("user")},
+					}
+
+					if aws.StringValue(input.Source) != "user" {
+						data.Parameters = append(data.Parameters, &rds.Parameter{ParameterName: aws.String("time_zone"), ParameterValue: aws.String("UTC"), Source: aws.String("engine-default")})
+					}
+				case *rds.ListTagsForResourceOutput:
+				}
+			})
+
+			d := tfrds.ResourceParameterGroup().TestResourceData()
+			d.SetId(testCase.ID)
+
+			imported, err := tfrds.ResourceParameterGroup().Importer.StateContext(ctx, d, client)
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			d = imported[0]
+
+			for i, expected := range [][]string{testCase.Expected, testCase.ExpectedRefresh} {
+				if diags := tfrds.ResourceParameterGroup().ReadWithoutTimeout(ctx, d, client); diags.HasError() {
+					t.Fatalf("unexpected error: %v", diags)
+				}
+
+				if got, want := d.Id(), "test"; got != want {
+					t.Errorf("read %d: got ID %q, expected %q", i+1, got, want)
+				}
+
+				var names []string
+				for _, v := range d.Get("parameter").(*schema.Set).List() {
+					names = append(names, v.(map[string]interface{})["name"].(string))
+				}
+				sort.Strings(names)
+
+				if !reflect.DeepEqual(names, expected) {
+					t.Errorf("read %d: got parameters %v, expected %v", i+1, names, expected)
+				}
+			}
+		})
+	}
+}
+
+func TestModifyParameterGroupParameters(t *testing.T) {
+	ctx := acctest.Context(t)
+	t.Parallel()
+
+	testCases := []struct {
+		Count         int
+		ExpectedCalls int
+	}{
+		{
+			Count:         1,
+			ExpectedCalls: 1,
+		},
+		{
+			Count:         20,
+			ExpectedCalls: 1,
+		},
+		{
+			Count:         21,
+			ExpectedCalls: 2,
+		},
+		{
+			Count:         300,
+			ExpectedCalls: 15,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(fmt.Sprintf("%d parameters", testCase.Count), func(t *testing.T) {
+			t.Parallel()
+
+			var calls int
+			seen := make(map[string]int)
+			conn := testParameterGroupConn(t, func(r *request.Request) {
+				input := r.Params.(*rds.ModifyDBParameterGroupInput)
+				calls++
+
+				if got, want := aws.StringValue(input.DBParameterGroupName), "test"; got != want {
+					t.Errorf("got DB Parameter Group name %q, expected %q", got, want)
+				}
+
+				if got := len(input.Parameters); got > 20 {
+					t.Errorf("got %d parameters in a single call, expected at most 20", got)
+				}
+
+				for _, p := range input.Parameters {
+					seen[aws.StringValue(p.ParameterName)]++
+				}
+			})
+
+			parameters := testDBParameterModifyChunkParameters(testCase.Count)
+
+			err := tfrds.ModifyParameterGroupParameters(ctx, conn, "test", "mysql8.0", "", parameters, nil)
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if calls != testCase.ExpectedCalls {
+				t.Errorf("got %d ModifyDBParameterGroup calls, expected %d", calls, testCase.ExpectedCalls)
+			}
+
+			for _, p := range parameters {
+				if name := aws.StringValue(p.ParameterName); seen[name] != 1 {
+					t.Errorf("parameter %q applied %d times, expected 1", name, seen[name])
+				}
+			}
+		})
+	}
+}
+
+func TestModifyParameterGroupParameters_rollback(t *testing.T) {
+	ctx := acctest.Context(t)
+	t.Parallel()
+
+	parameters := testDBParameterModifyChunkParameters(60)
+
+	// Ordinary parameters have no value before the apply, so rolling them back resets them.
+	previous := make(map[string]*string)
+	values := make(map[string]string)
+	for _, p := range parameters {
+		name := aws.StringValue(p.ParameterName)
+		if strings.HasPrefix(name, "ordinary_") {
+			previous[name] = nil
+		} else {
+			previous[name] = aws.String("old")
+			values[name] = "old"
+		}
+		p.ParameterValue = aws.String("new")
+	}
+
+	var modifyCalls, resetCalls int
+	conn := testParameterGroupConn(t, func(r *request.Request) {
+		switch input := r.Params.(type) {
+		case *rds.ModifyDBParameterGroupInput:
+			modifyCalls++
+
+			if modifyCalls == 2 {
+				r.Error = awserr.New("InvalidParameterValue", "Could not find parameter with name: innodb_7", nil)
+				return
+			}
+
+			for _, p := range input.Parameters {
+				values[aws.StringValue(p.ParameterName)] = aws.StringValue(p.ParameterValue)
+			}
+		case *rds.ResetDBParameterGroupInput:
+			resetCalls++
+
+			for _, p := range input.Parameters {
+				if name := aws.StringValue(p.ParameterName); !strings.HasPrefix(name, "ordinary_") {
+					t.Errorf("parameter %q reset, expected its previous value to be restored", name)
+				}
+
+				delete(values, aws.StringValue(p.ParameterName))
+			}
+		}
+	})
+
+	err := tfrds.ModifyParameterGroupParameters(ctx, conn, "test", "mysql8.0", "", parameters, nil)
+
+	if err == nil {
+		t.Fatal("expected error, got none")
+	}
+
+	if err := tfrds.RollbackParameterGroupParameters(ctx, conn, "test", err, previous); err != nil {
+		t.Fatalf("unexpected rollback error: %s", err)
+	}
+
+	// The first chunk is applied, the second fails and the first is then restored in one call.
+	if got, want := modifyCalls, 3; got != want {
+		t.Errorf("got %d ModifyDBParameterGroup calls, expected %d", got, want)
+	}
+
+	if got, want := resetCalls, 1; got != want {
+		t.Errorf("got %d ResetDBParameterGroup calls, expected %d", got, want)
+	}
+
+	for name, v := range previous {
+		if got, ok := values[name]; v == nil && ok {
+			t.Errorf("parameter %q: got value %q, expected none", name, got)
+		} else if v != nil && got != aws.StringValue(v) {
+			t.Errorf("parameter %q: got value %q, expected %q", name, got, aws.StringValue(v))
+		}
+	}
+}
+
+func TestResetParameterGroupParameters(t *testing.T) {
+	ctx := acctest.Context(t)
+	t.Parallel()
+
+	testCases := []struct {
+		Name           string
+		All            bool
+		Parameters     []*rds.Parameter
+		ExpectedCalls  int
+		ExpectedReboot bool
+	}{
+		{
+			Name:           "chunked",
+			Parameters:     testDBParameterModifyChunkParameters(60),
+			ExpectedCalls:  3,
+			ExpectedReboot: true,
+		},
+		{
+			Name:           "all",
+			All:            true,
+			Parameters:     testDBParameterModifyChunkParameters(60),
+			ExpectedCalls:  1,
+			ExpectedReboot: true,
+		},
+		{
+			Name: "all dynamic",
+			All:  true,
+			Parameters: []*rds.Parameter{
+				{ApplyMethod: aws.String("immediate"), ParameterName: aws.String("max_connections")},
+			},
+			ExpectedCalls: 1,
+		},
+		{
+			Name: "all static",
+			All:  true,
+			Parameters: []*rds.Parameter{
+				{ApplyMethod: aws.String("immediate"), ParameterName: aws.String("innodb_log_file_size")},
+			},
+			ExpectedCalls:  1,
+			ExpectedReboot: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.Name, func(t *testing.T) {
+			t.Parallel()
+
+			var calls, reset int
+			conn := testParameterGroupConn(t, func(r *request.Request) {
+				switch input := r.Params.(type) {
+				case *rds.DescribeDBParametersInput:
+					r.Data.(*rds.DescribeDBParametersOutput).Parameters = []*rds.Parameter{
+						{ApplyType: aws.String("static"), ParameterName: aws.String("innodb_log_file_size")},
+					}
+				case *rds.ResetDBParameterGroupInput:
+					calls++
+
+					if got := aws.BoolValue(input.ResetAllParameters); got != testCase.All {
+						t.Errorf("got ResetAllParameters %t, expected %t", got, testCase.All)
+					}
+
+					if testCase.All && len(input.Parameters) > 0 {
+						t.Errorf("got %d parameters with ResetAllParameters, expected none", len(input.Parameters))
+					}
+
+					reset += len(input.Parameters)
+				}
+			})
+
+			requiresReboot, err := tfrds.ResetParameterGroupParameters(ctx, conn, "test", testCase.Parameters, testCase.All)
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if got, want := calls, testCase.ExpectedCalls; got != want {
+				t.Errorf("got %d ResetDBParameterGroup calls, expected %d", got, want)
+			}
+
+			if !testCase.All && reset != len(testCase.Parameters) {
+				t.Errorf("got %d parameters reset, expected %d", reset, len(testCase.Parameters))
+			}
+
+			if requiresReboot != testCase.ExpectedReboot {
+				t.Errorf("got requires reboot %t, expected %t", requiresReboot, testCase.ExpectedReboot)
+			}
+		})
+	}
+}
+
+func TestStaticParametersPendingReboot(t *testing.T) {
+	t.Parallel()
+
+	defaults := map[string]*rds.Parameter{
+		"innodb_log_file_size": {ApplyType: aws.String("static"), ParameterName: aws.String("innodb_log_file_size")},
+		"max_connections":      {ApplyType: aws.String("dynamic"), ParameterName: aws.String("max_connections")},
+		"performance_schema":   {ApplyType: aws.String("Static"), ParameterName: aws.String("performance_schema")},
+	}
+
+	// A static parameter lacking an explicit apply_method gets the schema's immediate default.
+	d := schema.TestResourceDataRaw(t, tfrds.ResourceParameterGroup().Schema, map[string]interface{}{
+		"family": "mysql8.0",
+		"parameter": []interface{}{
+			map[string]interface{}{
+				"name":  "innodb_log_file_size",
+				"value": "2147483648",
+			},
+		},
+	})
+	var configured []*rds.Parameter
+	for _, v := range d.Get("parameter").(*schema.Set).List() {
+		m := v.(map[string]interface{})
+		configured = append(configured, &rds.Parameter{
+			ApplyMethod:    aws.String(m["apply_method"].(string)),
+			ParameterName:  aws.String(m["name"].(string)),
+			ParameterValue: aws.String(m["value"].(string)),
+		})
+	}
+
+	parameters := append(configured,
+		&rds.Parameter{
+			ApplyMethod:    aws.String("immediate"),
+			ParameterName:  aws.String("max_connections"),
+			ParameterValue: aws.String("100"),
+		},
+		&rds.Parameter{
+			ApplyMethod:    aws.String("pending-reboot"),
+			ParameterName:  aws.String("performance_schema"),
+			ParameterValue: aws.String("1"),
+		},
+		&rds.Parameter{
+			ParameterName:  aws.String("Performance_Schema"),
+			ParameterValue: aws.String("1"),
+		},
+		&rds.Parameter{
+			ApplyMethod:    aws.String("immediate"),
+			ParameterName:  aws.String("unknown_parameter"),
+			ParameterValue: aws.String("1"),
+		},
+	)
+
+	got, upgraded := tfrds.StaticParametersPendingReboot(parameters, defaults)
+
+	var gotApplyMethods []string
+	for _, p := range got {
+		gotApplyMethods = append(gotApplyMethods, aws.StringValue(p.ApplyMethod))
+	}
+
+	if want := []string{"pending-reboot", "immediate", "pending-reboot", "pending-reboot", "immediate"}; !reflect.DeepEqual(gotApplyMethods, want) {
+		t.Errorf("got apply methods %v, expected %v", gotApplyMethods, want)
+	}
+
+	if want := []string{"innodb_log_file_size", "performance_schema"}; !reflect.DeepEqual(upgraded, want) {
+		t.Errorf("got upgraded %v, expected %v", upgraded, want)
+	}
+
+	if got := aws.StringValue(parameters[0].ApplyMethod); got != "immediate" {
+		t.Errorf("input parameter modified, got apply method %q", got)
+	}
+
+	if !tfrds.ParametersRequireReboot(got) {
+		t.Error("expected parameters to require reboot")
+	}
+
+	// Reading the upgraded parameter back keeps the configured apply_method.
+	read := []*rds.Parameter{
+		{
+			ApplyMethod:    aws.String("pending-reboot"),
+			ApplyType:      aws.String("static"),
+			ParameterName:  aws.String("innodb_log_file_size"),
+			ParameterValue: aws.String("2147483648"),
+		},
+		{
+			ApplyMethod:    aws.String("pending-reboot"),
+			ApplyType:      aws.String("dynamic"),
+			ParameterName:  aws.String("max_connections"),
+			ParameterValue: aws.String("100"),
+		},
+	}
+
+	refreshed := tfrds.ConfiguredStaticParameterApplyMethods(read, append(configured, &rds.Parameter{
+		ApplyMethod:   aws.String("immediate"),
+		ParameterName: aws.String("max_connections"),
+	}))
+
+	if got, want := aws.StringValue(refreshed[0].ApplyMethod), "immediate"; got != want {
+		t.Errorf("got static parameter apply method %q, expected %q", got, want)
+	}
+
+	if got, want := aws.StringValue(refreshed[1].ApplyMethod), "pending-reboot"; got != want {
+		t.Errorf("got dynamic parameter apply method %q, expected %q", got, want)
+	}
+
+	if got := aws.StringValue(read[0].ApplyMethod); got != "pending-reboot" {
+		t.Errorf("read parameter modified, got apply method %q", got)
+	}
+
+	if err := d.Set("parameter", tfrds.FlattenParameterGroupParameters(refreshed[:1])); err != nil {
+		t.Fatalf("setting parameter: %s", err)
+	}
+
+	if diff := schema.NewSet(d.Get("parameter").(*schema.Set).F, []interface{}{
+		map[string]interface{}{
+			"apply_method": "immediate",
+			"name":         "innodb_log_file_size",
+			"value":        "2147483648",
+		},
+	}).Difference(d.Get("parameter").(*schema.Set)); diff.Len() != 0 {
+		t.Errorf("got %d changed parameters, expected none", diff.Len())
+	}
+}
+
+func TestParametersHaveImmediateApplyMethod(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		Name         string
+		ApplyMethods []string
+		Expected     bool
+	}{
+		{
+			Name: "none",
+		},
+		{
+			Name:         "pending-reboot",
+			ApplyMethods: []string{"pending-reboot", "pending-reboot"},
+		},
+		{
+			Name:         "immediate",
+			ApplyMethods: []string{"pending-reboot", "immediate"},
+			Expected:     true,
+		},
+		{
+			Name:         "default",
+			ApplyMethods: []string{"pending-reboot", ""},
+			Expected:     true,
+		},
+		{
+			Name:         "upper case",
+			ApplyMethods: []string{"Immediate"},
+			Expected:     true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.Name, func(t *testing.T) {
+			t.Parallel()
+
+			var parameters []*rds.Parameter
+			for i, v := range testCase.ApplyMethods {
+				parameters = append(parameters, &rds.Parameter{
+					ApplyMethod:    aws.String(v),
+					ParameterName:  aws.String(fmt.Sprintf("parameter_%d", i)),
+					ParameterValue: aws.String("1"),
+				})
+			}
+
+			if got, want := tfrds.ParametersHaveImmediateApplyMethod(parameters), testCase.Expected; got != want {
+				t.Errorf("got %t, expected %t", got, want)
+			}
+		})
+	}
+}
+
+func TestParametersRequireReboot(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		Name         string
+		ApplyMethods []string
+		Expected     bool
+	}{
+		{
+			Name: "none",
+		},
+		{
+			Name:         "immediate",
+			ApplyMethods: []string{"immediate", "immediate"},
+		},
+		{
+			Name:         "pending-reboot",
+			ApplyMethods: []string{"pending-reboot"},
+			Expected:     true,
+		},
+		{
+			Name:         "mixed",
+			ApplyMethods: []string{"immediate", "pending-reboot", "immediate"},
+			Expected:     true,
+		},
+		{
+			Name:         "upper case",
+			ApplyMethods: []string{"Pending-Reboot"},
+			Expected:     true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.Name, func(t *testing.T) {
+			t.Parallel()
+
+			var parameters []*rds.Parameter
+			for i, v := range testCase.ApplyMethods {
+				parameters = append(parameters, &rds.Parameter{
+					ApplyMethod:    aws.String(v),
+					ParameterName:  aws.String(fmt.Sprintf("parameter_%d", i)),
+					ParameterValue: aws.String("1"),
+				})
+			}
+
+			if got, want := tfrds.ParametersRequireReboot(parameters), testCase.Expected; got != want {
+				t.Errorf("got %t, expected %t", got, want)
+			}
+		})
+	}
+}
+
+func TestParameterValueDiffSuppress(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		Name     string
+		Trim     bool
+		Old      string
+		New      string
+		Expected bool
+	}{
+		{
+			Name: "trailing whitespace, disabled",
+			Old:  "utf8mb4",
+			New:  "utf8mb4\n",
+		},
+		{
+			Name:     "trailing whitespace",
+			Trim:     true,
+			Old:      "utf8mb4",
+			New:      "utf8mb4\n",
+			Expected: true,
+		},
+		{
+			Name:     "surrounding whitespace",
+			Trim:     true,
+			Old:      " utf8mb4\t",
+			New:      "utf8mb4 ",
+			Expected: true,
+		},
+		{
+			Name: "inner whitespace",
+			Trim: true,
+			Old:  "a,b",
+			New:  "a, b",
+		},
+		{
+			Name: "different value",
+			Trim: true,
+			Old:  "utf8",
+			New:  "utf8mb4 ",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(testCase.Name, func(t *testing.T) {
+			t.Parallel()
+
+			d := schema.TestResourceDataRaw(t, tfrds.ResourceParameterGroup().Schema, map[string]interface{}{
+				"family":                          "mysql8.0",
+				"trim_parameter_value_whitespace": testCase.Trim,
+			})
+
+			if got, want := tfrds.ParameterValueDiffSuppress("ordered_parameter.0.value", testCase.Old, testCase.New, d), testCase.Expected; got != want {
+				t.Errorf("got %t, expected %t", got, want)
+			}
+		})
+	}
+}
+
+func TestConfiguredParameterValues(t *testing.T) {
+	t.Parallel()
+
+	d := schema.TestResourceDataRaw(t, tfrds.ResourceParameterGroup().Schema, map[string]interface{}{
+		"family": "mysql8.0",
+		"parameter": []interface{}{
+			map[string]interface{}{
+				"name":  "character_set_server",
+				"value": "utf8mb4\n",
+			},
+			map[string]interface{}{
+				"name":  "max_connections",
+				"value": "100",
+			},
+			map[string]interface{}{
+				"name":  "sql_mode",
+				"value": "STRICT_ALL_TABLES ",
+			},
+		},
+		"trim_parameter_value_whitespace": true,
+	})
+	var configured []*rds.Parameter
+	for _, v := range d.Get("parameter").(*schema.Set).List() {
+		m := v.(map[string]interface{})
+		configured = append(configured, &rds.Parameter{
+			ApplyMethod:    aws.String(m["apply_method"].(string)),
+			ParameterName:  aws.String(m["name"].(string)),
+			ParameterValue: aws.String(m["value"].(string)),
+		})
+	}
+
+	read := []*rds.Parameter{
+		{
+			ApplyMethod:    aws.String("immediate"),
+			ParameterName:  aws.String("character_set_server"),
+			ParameterValue: aws.String("utf8mb4"),
+		},
+		{
+			ApplyMethod:    aws.String("immediate"),
+			ParameterName:  aws.String("max_connections"),
+			ParameterValue: aws.String("100"),
+		},
+		{
+			ApplyMethod:    aws.String("immediate"),
+			ParameterName:  aws.String("sql_mode"),
+			ParameterValue: aws.String("TRADITIONAL"),
+		},
+		{
+			ApplyMethod:    aws.String("immediate"),
+			ParameterName:  aws.String("time_zone"),
+			ParameterValue: aws.String("UTC"),
+		},
+	}
+
+	got := tfrds.ConfiguredParameterValues(read, configured)
+
+	var gotValues []string
+	for _, p := range got {
+		gotValues = append(gotValues, aws.StringValue(p.ParameterValue))
+	}
+
+	if want := []string{"utf8mb4\n", "100", "TRADITIONAL", "UTC"}; !reflect.DeepEqual(gotValues, want) {
+		t.Errorf("got values %q, expected %q", gotValues, want)
+	}
+
+	if got := aws.StringValue(read[0].ParameterValue); got != "utf8mb4" {
+		t.Errorf("read parameter modified, got value %q", got)
+	}
+
+	// The trailing whitespace value hashes the same as the configured one, so only the drifted value differs.
+	if err := d.Set("parameter", tfrds.FlattenParameterGroupParameters(got[:3])); err != nil {
+		t.Fatalf("setting parameter: %s", err)
+	}
+
+	if diff := schema.NewSet(d.Get("parameter").(*schema.Set).F, []interface{}{
+		map[string]interface{}{
+			"apply_method": "immediate",
+			"name":         "character_set_server",
+			"value":        "utf8mb4\n",
+		},
+		map[string]interface{}{
+			"apply_method": "immediate",
+			"name":         "max_connections",
+			"value":        "100",
+		},
+		map[string]interface{}{
+			"apply_method": "immediate",
+			"name":         "sql_mode",
+			"value":        "STRICT_ALL_TABLES ",
+		},
+	}).Difference(d.Get("parameter").(*schema.Set)); diff.Len() != 1 {
+		t.Errorf("got %d changed parameters, expected 1", diff.Len())
+	}
+}
+
+func TestModifyParameterGroupParametersInOrder(t *testing.T) {
+	ctx := acctest.Context(t)
+	t.Parallel()
+
+	for _, count := range []int{1, 20, 21, 45} {
+		count := count
+
+		t.Run(fmt.Sprintf("%d parameters", count), func(t *testing.T) {
+			t.Parallel()
+
+			var calls int
+			var applied []string
+			conn := testParameterGroupConn(t, func(r *request.Request) {
+				input := r.Params.(*rds.ModifyDBParameterGroupInput)
+				calls++
+
+				if got := len(input.Parameters); got > 20 {
+					t.Errorf("got %d parameters in a single call, expected at most 20", got)
+				}
+
+				for _, p := range input.Parameters {
+					applied = append(applied, aws.StringValue(p.ParameterName))
+				}
+			})
+
+			// Mixed apply methods and charset parameters, which the unordered chunking would reorder.
+			parameters := testDBParameterModifyChunkParameters(count)
+
+			if err := tfrds.ModifyParameterGroupParametersInOrder(ctx, conn, "test", "mysql8.0", parameters, nil); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if got, want := calls, (count+19)/20; got != want {
+				t.Errorf("got %d ModifyDBParameterGroup calls, expected %d", got, want)
+			}
+
+			var expected []string
+			for _, p := range parameters {
+				expected = append(expected, aws.StringValue(p.ParameterName))
+			}
+
+			if !reflect.DeepEqual(applied, expected) {
+				t.Errorf("got applied order %v, expected %v", applied, expected)
+			}
+		})
+	}
+}
+
+func TestOrderedParametersToModify(t *testing.T) {
+	t.Parallel()
+
+	parameter := func(name, value, applyMethod string) *rds.Parameter {
+		return &rds.Parameter{
+			ApplyMethod:    aws.String(applyMethod),
+			ParameterName:  aws.String(name),
+			ParameterValue: aws.String(value),
+		}
+	}
+
+	oldParameters := []*rds.Parameter{
+		parameter("gtid_mode", "ON", "pending-reboot"),
+		parameter("binlog_format", "ROW", "immediate"),
+		parameter("max_connections", "100", "immediate"),
+	}
+	newParameters := []*rds.Parameter{
+		parameter("time_zone", "UTC", "immediate"),
+		parameter("gtid_mode", "ON", "pending-reboot"),
+		parameter("binlog_format", "MIXED", "immediate"),
+		parameter("max_connections", "100", "pending-reboot"),
+	}
+
+	var got []string
+	for _, p := range tfrds.OrderedParametersToModify(oldParameters, newParameters) {
+		got = append(got, aws.StringValue(p.ParameterName))
+	}
+
+	if want := []string{"time_zone", "binlog_format", "max_connections"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, expected %v", got, want)
+	}
+}
+
+func TestFindParameterGroupOrderedParameters(t *testing.T) {
+	ctx := acctest.Context(t)
+	t.Parallel()
+
+	conn := testParameterGroupConn(t, func(r *request.Request) {
+		input := r.Params.(*rds.DescribeDBParametersInput)
+		data := r.Data.(*rds.DescribeDBParametersOutput)
+
+		if input.Source != nil {
+			t.Errorf("got source %q, expected none", aws.StringValue(input.Source))
+		}
+
+		data.Parameters = []*rds.Parameter{
+			{ParameterName: aws.String("binlog_format"), ParameterValue: aws.String("ROW"), Source: aws.String("user")},
+			{ParameterName: aws.String("character_set_server"), ParameterValue: aws.String("utf8"), Source: aws.String("user")},
+			{ParameterName: aws.String("gtid_mode"), ParameterValue: aws.String("OFF"), Source: aws.String("engine-default")},
+			{ParameterName: aws.String("time_zone"), ParameterValue: aws.String("UTC"), Source: aws.String("system")},
+		}
+	})
+
+	configured := []*rds.Parameter{
+		{ParameterName: aws.String("gtid_mode"), ParameterValue: aws.String("OFF")},
+		{ParameterName: aws.String("binlog_format"), ParameterValue: aws.String("ROW")},
+		{ParameterName: aws.String("max_connections"), ParameterValue: aws.String("100")},
+	}
+
+	parameters, err := tfrds.FindParameterGroupOrderedParameters(ctx, conn, "test", configured)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var names []string
+	for _, p := range parameters {
+		names = append(names, aws.StringValue(p.ParameterName))
+	}
+
+	// Configured parameters in configuration order, then unconfigured user-modified parameters.
+	if want := []string{"gtid_mode", "binlog_format", "character_set_server"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("got parameters %v, expected %v", names, want)
+	}
+}
+
+func TestChangedParameters(t *testing.T) {
+	ctx := acctest.Context(t)
+	t.Parallel()
+
+	// 25 configured parameters, of which AWS already has the configured value for the first 20.
+	var parameters, current []*rds.Parameter
+	for i := 0; i < 25; i++ {
+		name := fmt.Sprintf("parameter_%d", i)
+		parameters = append(parameters, &rds.Parameter{
+			ParameterName:  aws.String(name),
+			ParameterValue: aws.String("configured"),
+		})
+
+		value := "configured"
+		if i >= 20 {
+			value = "current"
+		}
+		current = append(current, &rds.Parameter{
+			ParameterName:  aws.String(strings.ToUpper(name)),
+			ParameterValue: aws.String(value),
+		})
+	}
+
+	var describeCalls int
+	conn := testParameterGroupConn(t, func(r *request.Request) {
+		input := r.Params.(*rds.DescribeDBParametersInput)
+		data := r.Data.(*rds.DescribeDBParametersOutput)
+		describeCalls++
+
+		if input.Source != nil {
+			t.Errorf("got source %q, expected all sources", aws.StringValue(input.Source))
+		}
+
+		// Return 10 parameters per page.
+		i := 0
+		if marker := aws.StringValue(input.Marker); marker != "" {
+			fmt.Sscan(marker, &i)
+		}
+		j := i + 10
+		if j > len(current) {
+			j = len(current)
+		}
+		data.Parameters = current[i:j]
+		if j < len(current) {
+			data.Marker = aws.String(fmt.Sprint(j))
+		}
+	})
+
+	changed, err := tfrds.ChangedParameters(ctx, conn, "test", parameters)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if describeCalls != 3 {
+		t.Errorf("got %d DescribeDBParameters calls, expected 3", describeCalls)
+	}
+
+	var names []string
+	for _, p := range changed {
+		names = append(names, aws.StringValue(p.ParameterName))
+	}
+	if want := []string{"parameter_20", "parameter_21", "parameter_22", "parameter_23", "parameter_24"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("got changed parameters %v, expected %v", names, want)
+	}
+}
+
+func TestGenerateParameterBlocks(t *testing.T) {
+	ctx := acctest.Context(t)
+	t.Parallel()
+
+	conn := testParameterGroupConn(t, func(r *request.Request) {
+		input := r.Params.(*rds.DescribeDBParametersInput)
+
+		if got, want := aws.StringValue(input.Source), "user"; got != want {
+			r.Error = fmt.Errorf("unexpected Source %q, expected %q", got, want)
+			return
+		}
+
+		data := r.Data.(*rds.DescribeDBParametersOutput)
+
+		switch aws.StringValue(input.Marker) {
+		case "":
+			data.Marker = aws.String("page2")
+			data.Parameters = []*rds.Parameter{
+				{
+					ApplyMethod:    aws.String("immediate"),
+					ParameterName:  aws.String("max_connections"),
+					ParameterValue: aws.String("{DBInstanceClassMemory/12582880}"),
+					Source:         aws.String("user"),
+				},
+				{
+					ApplyMethod:    aws.String("pending-reboot"),
+					ParameterName:  aws.String("Character_Set_Server"),
+					ParameterValue: aws.String("utf8"),
+					Source:         aws.String("user"),
+				},
+			}
+		case "page2":
+			data.Parameters = []*rds.Parameter{
+				{
+					ParameterName:  aws.String("init_connect"),
+					ParameterValue: aws.String("SET @x = \"${var}\";\tSET @y = 'caf\u00e9\x01'"),
+					Source:         aws.String("user"),
+				},
+			}
+		}
+	})
+
+	got, err := tfrds.GenerateParameterBlocks(ctx, conn, "test")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	want := `parameter {
+  name         = "character_set_server"
+  value        = "utf8"
+  apply_method = "pending-reboot"
+}
+
+parameter {
+  name  = "init_connect"
+  value = "SET @x = \"$${var}\";\tSET @y = 'café\u0001'"
+}
+
+parameter {
+  name  = "max_connections"
+  value = "{DBInstanceClassMemory/12582880}"
+}
+`
+
+	if got != want {
+		t.Errorf("GenerateParameterBlocks got:\n%s\nexpected:\n%s", got, want)
+	}
+}
+
+func testAccCheckParamaterGroupDisappears(ctx context.Context, v *rds.DBParameterGroup) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		conn := acctest.Provider.Meta().(*conns.AWSClient).RDSConn()
+
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != "aws_db_parameter_group" {
+				continue
+			}
+			_, err := conn.DeleteDBParameterGroupWithContext(ctx, &rds.DeleteDBParameterGroupInput{
+				DBParameterGroupName: v.DBParameterGroupName,
+			})
+			return err
+		}
+		return nil
+	}
+}
+
+func testAccCheckParameterGroupDestroy(ctx context.Context) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		conn := acctest.Provider.Meta().(*conns.AWSClient).RDSConn()
+
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != "aws_db_parameter_group" {
+				continue
+			}
+
+			// Try to find the Group
+			resp, err := conn.DescribeDBParameterGroupsWithContext(ctx, &rds.DescribeDBParameterGroupsInput{
+				DBParameterGroupName: aws.String(rs.Primary.ID),
+			})
+
+			if err == nil {
+				if len(resp.DBParameterGroups) != 0 &&
+					*resp.DBParameterGroups[0].DBParameterGroupName == rs.Primary.ID {
+					return fmt.Errorf("DB Parameter Group still exists")
+				}
+			}
+
+			// Verify the error
+			newerr, ok := err.(awserr.Error)
+			if !ok {
+				return err
+			}
+			if newerr.Code() != "DBParameterGroupNotFound" {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckParameterGroupAttributes(v *rds.DBParameterGroup, name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if *v.DBParameterGroupName != name {
+			return fmt.Errorf("Bad Parameter Group name, expected (%s), got (%s)", name, *v.DBParameterGroupName)
+		}
+
+		if *v.DBParameterGroupFamily != "mysql5.6" {
+			return fmt.Errorf("bad family: %#v", v.DBParameterGroupFamily)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckParameterGroupExists(ctx context.Context, rName string, v *rds.DBParameterGroup) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[rName]
+		if !ok {
+			return fmt.Errorf("Not found: %s", rName)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No DB Parameter Group ID is set")
+		}
+
+		conn := acctest.Provider.Meta().(*conns.AWSClient).RDSConn()
+
+		opts := rds.DescribeDBParameterGroupsInput{
+			DBParameterGroupName: aws.String(rs.Primary.ID),
+		}
+
+		resp, err := conn.DescribeDBParameterGroupsWithContext(ctx, &opts)
+
+		if err != nil {
+			return err
+		}
+
+		if len(resp.DBParameterGroups) != 1 ||
+			*resp.DBParameterGroups[0].DBParameterGroupName != rs.Primary.ID {
+			return fmt.Errorf("DB Parameter Group not found")
+		}
+
+		*v = *resp.DBParameterGroups[0]
+
+		return nil
+	}
+}
+
+func testAccCheckParameterNotUserDefined(ctx context.Context, rName, paramName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[rName]
+		if !ok {
+			return fmt.Errorf("Not found: %s", rName)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No DB Parameter Group ID is set")
+		}
+
+		conn := acctest.Provider.Meta().(*conns.AWSClient).RDSConn()
+
+		opts := rds.DescribeDBParametersInput{
+			DBParameterGroupName: aws.String(rs.Primary.ID),
+			Source:               aws.String("user"),
+		}
+
+		userDefined := false
+		err := conn.DescribeDBParametersPagesWithContext(ctx, &opts, func(page *rds.DescribeDBParametersOutput, lastPage bool) bool {
+			for _, param := range page.Parameters {
+				if *param.ParameterName == paramName {
+					userDefined = true
+					return false
+				}
+			}
+			return true
+		})
+
+		if userDefined {
+			return fmt.Errorf("DB Parameter is user defined")
+		}
+
+		return err
+	}
+}
+
+func testAccParameterGroupConfig_basic(rName string) string {
+	return fmt.Sprintf(`
+resource "aws_db_parameter_group" "test" {
+  name   = %[1]q
+  family = "mysql5.6"
+
+  parameter {
+    name  = "character_set_server"
+    value = "utf8"
+  }
+
+  parameter {
+    name  = "character_set_client"
+    value = "utf8"
+  }
+
+  parameter {
+    name  = "character_set_results"
+    value = "utf8"
+  }
+}
+`, rName)
+}
+
+func testAccParameterGroupConfig_orderedParameters(rName, value string) string {
+	return fmt.Sprintf(`
+resource "aws_db_parameter_group" "test" {
+  name   = %[1]q
+  family = "mysql5.6"
+
+  ordered_parameter {
+    name  = "character_set_server"
+    value = %[2]q
+  }
+
+  ordered_parameter {
+    name  = "character_set_client"
+    value = "utf8"
+  }
+
+  ordered_parameter {
+    name  = "character_set_results"
+    value = %[2]q
+  }
+}
+`, rName, value)
+}
+
+func testAccParameterGroupConfig_parameterGroupConstraint(rName, charset, collation string) string {
+	return fmt.Sprintf(`
+resource "aws_db_parameter_group" "test" {
+  name   = %[1]q
+  family = "mysql5.6"
+
+  parameter {
+    name  = "character_set_server"
+    value = %[2]q
+  }
+
+  parameter {
+    name  = "collation_server"
+    value = %[3]q
+  }
+
+  parameter {
+    name  = "max_connections"
+    value = "100"
+  }
+
+  parameter_group_constraint {
+    names = ["character_set_server", "collation_server"]
+  }
+}
+`, rName, charset, collation)
+}
+
+func testAccParameterGroupConfig_skipUnchangedParameters(rName, characterSet string) string {
+	return fmt.Sprintf(`
+resource "aws_db_parameter_group" "test" {
+  name                      = %[1]q
+  family                    = "mysql8.0"
+  skip_unchanged_parameters = true
+
+  parameter {
+    name  = "character_set_server"
+    value = %[2]q
+  }
+}
+`, rName, characterSet)
+}
+
+func testAccParameterGroupConfig_resetAllParametersOnClear(rName string, withParameters bool) string {
+	var parameters string
+	if withParameters {
+		parameters = `
+  parameter {
+    name  = "character_set_server"
+    value = "utf8mb4"
+  }
+
+  parameter {
+    name  = "character_set_client"
+    value = "utf8mb4"
+  }
+`
+	}
+
+	return fmt.Sprintf(`
+resource "aws_db_parameter_group" "test" {
+  name                          = %[1]q
+  family                        = "mysql8.0"
+  reset_all_parameters_on_clear = true
+%[2]s}
+`, rName, parameters)
+}
+
+func testAccParameterGroupConfig_invalidParameter(rName string) string {
+	return fmt.Sprintf(`
+resource "aws_db_parameter_group" "test" {
+  name   = %[1]q
+  family = "mysql5.6"
+
+  parameter {
+    name  = "tf_acc_test_not_a_parameter"
+    value = "1"
+  }
+}
+`, rName)
+}
+
+func testAccParameterGroupConfig_alreadyExists(rName string) string {
+	return fmt.Sprintf(`
+resource "aws_db_parameter_group" "existing" {
+  name   = %[1]q
+  family = "mysql5.6"
+}
+
+resource "aws_db_parameter_group" "test" {
+  name   = aws_db_parameter_group.existing.name
+  family = "mysql5.6"
 }
 `, rName)
 }
@@ -0,0 +1,286 @@
+package rds_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/rds"
+	sdkacctest "github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/hashicorp/terraform-provider-aws/internal/acctest"
+	"github.com/hashicorp/terraform-provider-aws/internal/conns"
+	tfrds "github.com/hashicorp/terraform-provider-aws/internal/service/rds"
+	"github.com/hashicorp/terraform-provider-aws/internal/tfresource"
+)
+
+func TestParameterParseResourceID(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		Input             string
+		ExpectedGroupName string
+		ExpectedName      string
+		ExpectError       bool
+	}{
+		{
+			Input:             "test-group,character_set_server",
+			ExpectedGroupName: "test-group",
+			ExpectedName:      "character_set_server",
+		},
+		{
+			Input:       "test-group",
+			ExpectError: true,
+		},
+		{
+			Input:       ",character_set_server",
+			ExpectError: true,
+		},
+		{
+			Input:       "test-group,",
+			ExpectError: true,
+		},
+		{
+			Input:       "test-group,character_set_server,extra",
+			ExpectError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.Input, func(t *testing.T) {
+			t.Parallel()
+
+			groupName, name, err := tfrds.ParameterParseResourceID(testCase.Input)
+
+			if testCase.ExpectError {
+				if err == nil {
+					t.Fatalf("expected error for %q, got none", testCase.Input)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if groupName != testCase.ExpectedGroupName || name != testCase.ExpectedName {
+				t.Errorf("got %q, %q, expected %q, %q", groupName, name, testCase.ExpectedGroupName, testCase.ExpectedName)
+			}
+
+			if got := tfrds.ParameterCreateResourceID(groupName, name); got != testCase.Input {
+				t.Errorf("got ID %q, expected %q", got, testCase.Input)
+			}
+		})
+	}
+}
+
+func TestAccRDSParameter_basic(t *testing.T) {
+	ctx := acctest.Context(t)
+	var v rds.Parameter
+	resourceName := "aws_db_parameter.test"
+	groupName := fmt.Sprintf("parameter-group-test-terraform-%d", sdkacctest.RandInt())
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ErrorCheck:               acctest.ErrorCheck(t, rds.EndpointsID),
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckParameterDestroy(ctx),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccParameterConfig_basic(groupName, "utf8mb4"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckParameterExists(ctx, resourceName, &v),
+					resource.TestCheckResourceAttr(resourceName, "apply_method", "immediate"),
+					resource.TestCheckResourceAttr(resourceName, "name", "character_set_server"),
+					resource.TestCheckResourceAttrPair(resourceName, "parameter_group_name", "aws_db_parameter_group.test", "name"),
+					resource.TestCheckResourceAttr(resourceName, "value", "utf8mb4"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccParameterConfig_basic(groupName, "latin1"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckParameterExists(ctx, resourceName, &v),
+					resource.TestCheckResourceAttr(resourceName, "value", "latin1"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccRDSParameter_disappears(t *testing.T) {
+	ctx := acctest.Context(t)
+	var v rds.Parameter
+	resourceName := "aws_db_parameter.test"
+	groupName := fmt.Sprintf("parameter-group-test-terraform-%d", sdkacctest.RandInt())
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ErrorCheck:               acctest.ErrorCheck(t, rds.EndpointsID),
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckParameterDestroy(ctx),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccParameterConfig_basic(groupName, "utf8mb4"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckParameterExists(ctx, resourceName, &v),
+					acctest.CheckResourceDisappears(ctx, acctest.Provider, tfrds.ResourceParameter(), resourceName),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestAccRDSParameter_forEach(t *testing.T) {
+	ctx := acctest.Context(t)
+	var v1, v2 rds.Parameter
+	groupName := fmt.Sprintf("parameter-group-test-terraform-%d", sdkacctest.RandInt())
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ErrorCheck:               acctest.ErrorCheck(t, rds.EndpointsID),
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckParameterDestroy(ctx),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccParameterConfig_forEach(groupName, map[string]string{
+					"character_set_client": "utf8mb4",
+					"character_set_server": "utf8mb4",
+				}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckParameterExists(ctx, `aws_db_parameter.test["character_set_client"]`, &v1),
+					testAccCheckParameterExists(ctx, `aws_db_parameter.test["character_set_server"]`, &v2),
+					resource.TestCheckResourceAttr(`aws_db_parameter.test["character_set_client"]`, "value", "utf8mb4"),
+					resource.TestCheckResourceAttr(`aws_db_parameter.test["character_set_server"]`, "value", "utf8mb4"),
+				),
+			},
+			{
+				Config: testAccParameterConfig_forEach(groupName, map[string]string{
+					"character_set_server": "latin1",
+				}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckParameterExists(ctx, `aws_db_parameter.test["character_set_server"]`, &v2),
+					resource.TestCheckResourceAttr(`aws_db_parameter.test["character_set_server"]`, "value", "latin1"),
+					testAccCheckParameterNotUserDefined(ctx, "aws_db_parameter_group.test", "character_set_client"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckParameterDestroy(ctx context.Context) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		conn := acctest.Provider.Meta().(*conns.AWSClient).RDSConn()
+
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != "aws_db_parameter" {
+				continue
+			}
+
+			parameter, err := tfrds.FindDBParameterByTwoPartKey(ctx, conn, rs.Primary.Attributes["parameter_group_name"], rs.Primary.Attributes["name"])
+
+			if tfresource.NotFound(err) {
+				continue
+			}
+
+			if err != nil {
+				return err
+			}
+
+			if aws.StringValue(parameter.Source) == "user" {
+				return fmt.Errorf("RDS DB Parameter %s still exists", rs.Primary.ID)
+			}
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckParameterExists(ctx context.Context, n string, v *rds.Parameter) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No RDS DB Parameter ID is set")
+		}
+
+		parameterGroupName, name, err := tfrds.ParameterParseResourceID(rs.Primary.ID)
+
+		if err != nil {
+			return err
+		}
+
+		conn := acctest.Provider.Meta().(*conns.AWSClient).RDSConn()
+
+		output, err := tfrds.FindDBParameterByTwoPartKey(ctx, conn, parameterGroupName, name)
+
+		if err != nil {
+			return err
+		}
+
+		if aws.StringValue(output.Source) != "user" {
+			return fmt.Errorf("RDS DB Parameter %s has source %s", rs.Primary.ID, aws.StringValue(output.Source))
+		}
+
+		*v = *output
+
+		return nil
+	}
+}
+
+func testAccParameterConfig_base(rName string) string {
+	return fmt.Sprintf(`
+resource "aws_db_parameter_group" "test" {
+  name   = %[1]q
+  family = "mysql8.0"
+
+  lifecycle {
+    ignore_changes = [parameter]
+  }
+}
+`, rName)
+}
+
+func testAccParameterConfig_basic(rName, characterSet string) string {
+	return acctest.ConfigCompose(testAccParameterConfig_base(rName), fmt.Sprintf(`
+resource "aws_db_parameter" "test" {
+  parameter_group_name = aws_db_parameter_group.test.name
+  name                 = "character_set_server"
+  value                = %[1]q
+}
+`, characterSet))
+}
+
+func testAccParameterConfig_forEach(rName string, parameters map[string]string) string {
+	var values string
+	for k, v := range parameters {
+		values += fmt.Sprintf("    %[1]s = %[2]q\n", k, v)
+	}
+
+	return acctest.ConfigCompose(testAccParameterConfig_base(rName), fmt.Sprintf(`
+locals {
+  parameters = {
+%[1]s  }
+}
+
+resource "aws_db_parameter" "test" {
+  for_each = local.parameters
+
+  parameter_group_name = aws_db_parameter_group.test.name
+  name                 = each.key
+  value                = each.value
+}
+`, values))
+}
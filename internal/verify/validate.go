@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/endpoints"
 	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -295,6 +296,27 @@ func ValidRegionName(v interface{}, k string) (ws []string, errors []error) {
 	return
 }
 
+// ValidRegionNameInPartition returns an error if the specified region name is
+// malformed or does not belong to the specified partition, according to the
+// AWS SDK's endpoint metadata.
+func ValidRegionNameInPartition(region, partition string) error {
+	if _, errs := ValidRegionName(region, "region"); len(errs) > 0 {
+		return errs[0]
+	}
+
+	p, ok := endpoints.PartitionForRegion(endpoints.DefaultPartitions(), region)
+
+	if !ok {
+		return fmt.Errorf("region (%s) is not in a known partition", region)
+	}
+
+	if p.ID() != partition {
+		return fmt.Errorf("region (%s) is in partition (%s), expected partition (%s)", region, p.ID(), partition)
+	}
+
+	return nil
+}
+
 func ValidStringIsJSONOrYAML(v interface{}, k string) (ws []string, errors []error) {
 	if looksLikeJSONString(v) {
 		if _, err := structure.NormalizeJsonString(v); err != nil {
@@ -179,6 +179,44 @@ func TestValidAccountID(t *testing.T) {
 	}
 }
 
+func TestValidRegionNameInPartition(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		Region    string
+		Partition string
+		ExpectErr bool
+	}{
+		{Region: "us-west-2", Partition: "aws"},
+		{Region: "eu-central-1", Partition: "aws"},
+		{Region: "us-gov-west-1", Partition: "aws-us-gov"},
+		{Region: "us-gov-east-1", Partition: "aws-us-gov"},
+		{Region: "cn-north-1", Partition: "aws-cn"},
+		{Region: "cn-northwest-1", Partition: "aws-cn"},
+		{Region: "us-iso-east-1", Partition: "aws-iso"},
+		{Region: "us-gov-west-1", Partition: "aws", ExpectErr: true},
+		{Region: "cn-north-1", Partition: "aws", ExpectErr: true},
+		{Region: "us-west-2", Partition: "aws-us-gov", ExpectErr: true},
+		{Region: "cn-north-1", Partition: "aws-us-gov", ExpectErr: true},
+		{Region: "us-east-1", Partition: "aws-cn", ExpectErr: true},
+		{Region: "us-gov-west-1", Partition: "aws-cn", ExpectErr: true},
+		{Region: "not-a-region", Partition: "aws", ExpectErr: true},
+		{Region: "us-west-2a", Partition: "aws", ExpectErr: true},
+	}
+
+	for _, testCase := range testCases {
+		err := ValidRegionNameInPartition(testCase.Region, testCase.Partition)
+
+		if err == nil && testCase.ExpectErr {
+			t.Errorf("expected error for region %q in partition %q", testCase.Region, testCase.Partition)
+		}
+
+		if err != nil && !testCase.ExpectErr {
+			t.Errorf("unexpected error for region %q in partition %q: %s", testCase.Region, testCase.Partition, err)
+		}
+	}
+}
+
 func TestValidARN(t *testing.T) {
 	t.Parallel()
 